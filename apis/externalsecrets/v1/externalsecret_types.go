@@ -19,6 +19,8 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 // SecretStoreRef defines which SecretStore to fetch the ExternalSecret data.
@@ -55,6 +57,22 @@ const (
 	CreatePolicyNone ExternalSecretCreationPolicy = "None"
 )
 
+// ExternalSecretDriftPolicy defines how changes of the Secret that were made outside of the ExternalSecret are handled.
+// +kubebuilder:validation:Enum=Overwrite;Report;Adopt
+type ExternalSecretDriftPolicy string
+
+const (
+	// DriftPolicyOverwrite overwrites changes of the Secret with the data of the provider.
+	DriftPolicyOverwrite ExternalSecretDriftPolicy = "Overwrite"
+
+	// DriftPolicyReport keeps changes of the Secret and sets the Drifted condition with the changed keys.
+	DriftPolicyReport ExternalSecretDriftPolicy = "Report"
+
+	// DriftPolicyAdopt pushes changed keys of the Secret to the provider if the store is writable.
+	// Changes that can not be pushed are kept and reported like with DriftPolicyReport.
+	DriftPolicyAdopt ExternalSecretDriftPolicy = "Adopt"
+)
+
 // ExternalSecretDeletionPolicy defines rules on how to delete the resulting Secret.
 // +kubebuilder:validation:Enum=Delete;Merge;Retain
 type ExternalSecretDeletionPolicy string
@@ -127,12 +145,15 @@ const (
 
 // TemplateEngineVersion specifies the template engine version that should be used to
 // compile/execute the template.
-// +kubebuilder:validation:Enum=v2
+// +kubebuilder:validation:Enum=v2;cel
 type TemplateEngineVersion string
 
 const (
-	// TemplateEngineV2 is the currently supported template engine version.
+	// TemplateEngineV2 renders templates with Go text/template and the sprig functions.
 	TemplateEngineV2 TemplateEngineVersion = "v2"
+
+	// TemplateEngineCEL evaluates each template as a Common Expression Language (CEL) expression.
+	TemplateEngineCEL TemplateEngineVersion = "cel"
 )
 
 // TemplateFrom specifies a source for templates.
@@ -232,6 +253,13 @@ type ExternalSecretTarget struct {
 	// +kubebuilder:default="Retain"
 	DeletionPolicy ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
 
+	// DriftPolicy defines how changes of the Secret that were made outside of the
+	// ExternalSecret, e.g. with kubectl edit, are handled.
+	// Defaults to "Overwrite"
+	// +optional
+	// +kubebuilder:default="Overwrite"
+	DriftPolicy ExternalSecretDriftPolicy `json:"driftPolicy,omitempty"`
+
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
 	Template *ExternalSecretTemplate `json:"template,omitempty"`
@@ -292,6 +320,37 @@ type ExternalSecretDataRemoteRef struct {
 	// Used to define a decoding Strategy
 	// +kubebuilder:default="None"
 	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+
+	// +optional
+	// Used to decrypt the value after the decoding Strategy has been applied
+	Decryption *ExternalSecretDecryption `json:"decryption,omitempty"`
+}
+
+// ExternalSecretDecryptionMethod defines the method used to decrypt secret values.
+// +kubebuilder:validation:Enum=Age;PGP
+type ExternalSecretDecryptionMethod string
+
+const (
+	// ExternalSecretDecryptionAge specifies that values are decrypted using age.
+	ExternalSecretDecryptionAge ExternalSecretDecryptionMethod = "Age"
+	// ExternalSecretDecryptionPGP specifies that values are decrypted using OpenPGP.
+	ExternalSecretDecryptionPGP ExternalSecretDecryptionMethod = "PGP"
+)
+
+// ExternalSecretDecryption defines how an encrypted value of the Provider is decrypted.
+// Armored and binary ciphertexts are supported.
+type ExternalSecretDecryption struct {
+	// Method used to decrypt the value.
+	Method ExternalSecretDecryptionMethod `json:"method"`
+
+	// KeySecretRef references the age identities or the OpenPGP private key
+	// used to decrypt the value. The Secret must be in the namespace of the ExternalSecret.
+	KeySecretRef esmeta.SecretKeySelector `json:"keySecretRef"`
+
+	// PassphraseSecretRef references the passphrase of an encrypted OpenPGP private key.
+	// Only used with the PGP method.
+	// +optional
+	PassphraseSecretRef *esmeta.SecretKeySelector `json:"passphraseSecretRef,omitempty"`
 }
 
 // ExternalSecretMetadataPolicy defines policies for fetching metadata from provider secrets.
@@ -317,7 +376,8 @@ const (
 )
 
 // ExternalSecretDecodingStrategy defines strategies for decoding secret values.
-// +kubebuilder:validation:Enum=Auto;Base64;Base64URL;None
+// Chained strategies like `Base64+Gzip` are applied from left to right.
+// +kubebuilder:validation:Enum=Auto;Base64;Base64URL;Hex;Gzip;Zlib;Base64+Gzip;Base64+Zlib;None
 type ExternalSecretDecodingStrategy string
 
 const (
@@ -327,6 +387,16 @@ const (
 	ExternalSecretDecodeBase64 ExternalSecretDecodingStrategy = "Base64"
 	// ExternalSecretDecodeBase64URL specifies that values should be decoded using Base64URL.
 	ExternalSecretDecodeBase64URL ExternalSecretDecodingStrategy = "Base64URL"
+	// ExternalSecretDecodeHex specifies that values should be decoded using hex.
+	ExternalSecretDecodeHex ExternalSecretDecodingStrategy = "Hex"
+	// ExternalSecretDecodeGzip specifies that values should be decompressed using gzip.
+	ExternalSecretDecodeGzip ExternalSecretDecodingStrategy = "Gzip"
+	// ExternalSecretDecodeZlib specifies that values should be decompressed using zlib.
+	ExternalSecretDecodeZlib ExternalSecretDecodingStrategy = "Zlib"
+	// ExternalSecretDecodeBase64Gzip specifies that values should be decoded using Base64 and then decompressed using gzip.
+	ExternalSecretDecodeBase64Gzip ExternalSecretDecodingStrategy = "Base64+Gzip"
+	// ExternalSecretDecodeBase64Zlib specifies that values should be decoded using Base64 and then decompressed using zlib.
+	ExternalSecretDecodeBase64Zlib ExternalSecretDecodingStrategy = "Base64+Zlib"
 	// ExternalSecretDecodeNone specifies that no decoding should be performed.
 	ExternalSecretDecodeNone ExternalSecretDecodingStrategy = "None"
 )
@@ -376,6 +446,19 @@ type ExternalSecretRewrite struct {
 	// The resulting key will be the output of the template applied by the operation.
 	// +optional
 	Transform *ExternalSecretRewriteTransform `json:"transform,omitempty"`
+
+	// Used to flatten nested JSON values into one key per leaf.
+	// The resulting keys will be the parent and child keys joined with the separator.
+	// +optional
+	Flatten *ExternalSecretRewriteFlatten `json:"flatten,omitempty"`
+
+	// Used to extract fields of a JSON value into new keys using JSONPath.
+	// +optional
+	Extract *ExternalSecretRewriteExtract `json:"extract,omitempty"`
+
+	// Used to drop keys based on regular expressions.
+	// +optional
+	Filter *ExternalSecretRewriteFilter `json:"filter,omitempty"`
 }
 
 // ExternalSecretRewriteMerge defines configuration for merging secret values.
@@ -454,6 +537,49 @@ type ExternalSecretRewriteTransform struct {
 	Template string `json:"template"`
 }
 
+// ExternalSecretRewriteFlatten defines configuration for flattening nested JSON values.
+type ExternalSecretRewriteFlatten struct {
+	// Used to join the keys of nested values.
+	// +optional
+	// +kubebuilder:default="_"
+	Separator string `json:"separator,omitempty"`
+
+	// Used to limit the number of levels that are flattened.
+	// Values below the maximum depth are kept as JSON. 0 means no limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDepth int `json:"maxDepth,omitempty"`
+}
+
+// ExternalSecretRewriteExtract defines configuration for extracting fields of a JSON value.
+type ExternalSecretRewriteExtract struct {
+	// Used to define the key that holds the JSON value to extract the fields from.
+	Source string `json:"source"`
+
+	// Used to define the new keys and the JSONPath expressions of their values,
+	// e.g. `username: $.credentials.user`.
+	// Strings are written as is, other values are written as JSON.
+	// +kubebuilder:validation:MinProperties=1
+	Keys map[string]string `json:"keys"`
+
+	// Used to keep the source key in the result. The source key is removed by default.
+	// +optional
+	KeepSource bool `json:"keepSource,omitempty"`
+}
+
+// ExternalSecretRewriteFilter defines configuration for dropping keys.
+// +kubebuilder:validation:MinProperties=1
+type ExternalSecretRewriteFilter struct {
+	// Used to define a regular expression that keys must match to be kept.
+	// +optional
+	Include string `json:"include,omitempty"`
+
+	// Used to define a regular expression of keys to drop.
+	// Applied after include.
+	// +optional
+	Exclude string `json:"exclude,omitempty"`
+}
+
 // ExternalSecretFind defines configuration for finding secrets in the provider.
 type ExternalSecretFind struct {
 	// A root path to start the find operations.
@@ -499,6 +625,54 @@ const (
 	RefreshPolicyOnChange ExternalSecretRefreshPolicy = "OnChange"
 )
 
+// RefreshSchedule refreshes a resource at the times of a cron schedule.
+type RefreshSchedule struct {
+	// Cron is a standard cron expression with five fields, e.g. "0 2 * * *" for 02:00 every day.
+	// +kubebuilder:validation:MinLength=1
+	Cron string `json:"cron"`
+
+	// TimeZone is the IANA name of the time zone the cron expression is evaluated in, e.g. "Europe/Berlin".
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Jitter delays every scheduled refresh by a random duration up to Jitter,
+	// to spread the load of many resources with the same schedule.
+	// The delay is stable for a resource and a scheduled time.
+	// +optional
+	Jitter *metav1.Duration `json:"jitter,omitempty"`
+}
+
+// SyncWindowKind defines whether a sync window allows or denies updates.
+// +kubebuilder:validation:Enum=Allow;Deny
+type SyncWindowKind string
+
+const (
+	// SyncWindowAllow allows updates while the window is open.
+	// If any Allow window is defined, updates are only made while one of them is open.
+	SyncWindowAllow SyncWindowKind = "Allow"
+	// SyncWindowDeny denies updates while the window is open, Deny windows take precedence over Allow windows.
+	SyncWindowDeny SyncWindowKind = "Deny"
+)
+
+// SyncWindow is a recurring time window in which updates are allowed or denied.
+type SyncWindow struct {
+	// Kind of the window, Allow or Deny.
+	Kind SyncWindowKind `json:"kind"`
+
+	// Schedule is a standard cron expression with five fields for the start of the window,
+	// e.g. "0 8 * * 1-5" for 08:00 from Monday to Friday.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration the window stays open after each start, e.g. "10h".
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA name of the time zone the schedule is evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 // ExternalSecretSpec defines the desired state of ExternalSecret.
 type ExternalSecretSpec struct {
 	// +optional
@@ -524,6 +698,16 @@ type ExternalSecretSpec struct {
 	// +kubebuilder:default="1h0m0s"
 	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 
+	// RefreshSchedule refreshes the ExternalSecret at the times of a cron schedule
+	// instead of after the RefreshInterval. Only used with the Periodic RefreshPolicy.
+	// +optional
+	RefreshSchedule *RefreshSchedule `json:"refreshSchedule,omitempty"`
+
+	// SyncWindows restrict the times at which an existing target is updated.
+	// Refreshes outside of the windows are deferred until a window allows them.
+	// +optional
+	SyncWindows []SyncWindow `json:"syncWindows,omitempty"`
+
 	// Data defines the connection between the Kubernetes Secret keys and the Provider data
 	// +optional
 	Data []ExternalSecretData `json:"data,omitempty"`
@@ -532,6 +716,55 @@ type ExternalSecretSpec struct {
 	// If multiple entries are specified, the Secret keys are merged in the specified order
 	// +optional
 	DataFrom []ExternalSecretDataFromRemoteRef `json:"dataFrom,omitempty"`
+
+	// GeneratorRotationPolicy controls how long credentials of stateful generators
+	// stay valid after they have been rotated.
+	// If not set, superseded credentials are cleaned up after the
+	// `--generator-gc-grace-period` of the controller.
+	// +optional
+	GeneratorRotationPolicy *GeneratorRotationPolicy `json:"generatorRotationPolicy,omitempty"`
+
+	// DryRun fetches, rewrites and templates the data without writing the target.
+	// The changes that would be made to the target are recorded in status.plan.
+	// Not supported with generic targets.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// RecordProvenance records the source of every key of the provider data in status.provenance.
+	// +optional
+	RecordProvenance bool `json:"recordProvenance,omitempty"`
+
+	// ExpiringWindow is the time before the expiry of a remote secret in which the Expiring condition is set.
+	// Only providers that report the expiry of secrets support it, see status.expiresAt.
+	// Defaults to 168h.
+	// +optional
+	ExpiringWindow *metav1.Duration `json:"expiringWindow,omitempty"`
+}
+
+// GeneratorRotationPolicy defines how previous credentials of stateful generators
+// are kept alive after a rotation, so consumers with long-lived connections
+// can switch over to the new credential.
+type GeneratorRotationPolicy struct {
+	// PreviousVersions is the number of superseded credentials that are kept alive
+	// in addition to the one that was replaced by the latest rotation.
+	// The replaced credential is always kept until the next rotation.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	PreviousVersions int `json:"previousVersions,omitempty"`
+
+	// TTL is the time a superseded credential is kept alive before it is cleaned up.
+	// Defaults to the refreshInterval of the ExternalSecret.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// PreviousKeySuffix exposes the previous value of every generated key
+	// in the target Secret under the key with this suffix appended,
+	// e.g. `password` and `password-previous`.
+	// Not supported with generic targets, target templates or rewrites of generated keys.
+	// +optional
+	// +kubebuilder:validation:Pattern:=^[-._a-zA-Z0-9]+$
+	PreviousKeySuffix string `json:"previousKeySuffix,omitempty"`
 }
 
 // StoreSourceRef allows you to override the SecretStore source
@@ -571,7 +804,7 @@ type GeneratorRef struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 
 	// Specify the Kind of the generator resource
-	// +kubebuilder:validation:Enum=ACRAccessToken;ClusterGenerator;CloudsmithAccessToken;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;QuayAccessToken;Password;SSHKey;STSSessionToken;UUID;VaultDynamicSecret;Webhook;Grafana;MFA
+	// +kubebuilder:validation:Enum=ACRAccessToken;ClusterGenerator;CloudsmithAccessToken;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;QuayAccessToken;Password;SSHKey;STSSessionToken;UUID;VaultDynamicSecret;Webhook;Grafana;MFA;CryptoKey;GeneratorPipeline;ServiceAccountToken;HarborRobotAccount
 	Kind string `json:"kind"`
 
 	// Specify the name of the generator resource
@@ -582,7 +815,7 @@ type GeneratorRef struct {
 }
 
 // ExternalSecretConditionType defines a value type for ExternalSecret conditions.
-// +kubebuilder:validation:Enum=Ready;Deleted
+// +kubebuilder:validation:Enum=Ready;Deleted;Drifted;Expiring
 type ExternalSecretConditionType string
 
 const (
@@ -590,6 +823,10 @@ const (
 	ExternalSecretReady ExternalSecretConditionType = "Ready"
 	// ExternalSecretDeleted indicates that the external secret has been deleted.
 	ExternalSecretDeleted ExternalSecretConditionType = "Deleted"
+	// ExternalSecretDrifted indicates that the Secret has been changed outside of the external secret.
+	ExternalSecretDrifted ExternalSecretConditionType = "Drifted"
+	// ExternalSecretExpiring indicates that a remote secret expires soon or has expired.
+	ExternalSecretExpiring ExternalSecretConditionType = "Expiring"
 )
 
 // ExternalSecretStatusCondition defines a status condition of an ExternalSecret resource.
@@ -616,6 +853,16 @@ const (
 	ConditionReasonSecretDeleted = "SecretDeleted"
 	// ConditionReasonSecretMissing indicates that the secret is missing.
 	ConditionReasonSecretMissing = "SecretMissing"
+	// ConditionReasonSecretDrifted indicates that the secret has been changed outside of the external secret.
+	ConditionReasonSecretDrifted = "SecretDrifted"
+	// ConditionReasonSecretAdoptError indicates that the changes of the secret could not be pushed to the provider.
+	ConditionReasonSecretAdoptError = "SecretAdoptError"
+	// ConditionReasonDryRun indicates that the changes to the secret have been planned without writing it.
+	ConditionReasonDryRun = "DryRun"
+	// ConditionReasonSecretExpiring indicates that a remote secret expires within the expiring window.
+	ConditionReasonSecretExpiring = "SecretExpiring"
+	// ConditionReasonSecretExpired indicates that a remote secret has expired.
+	ConditionReasonSecretExpired = "SecretExpired"
 
 	// ReasonUpdateFailed indicates that the update operation failed.
 	ReasonUpdateFailed = "UpdateFailed"
@@ -629,6 +876,10 @@ const (
 	ReasonDeleted = "Deleted"
 	// ReasonMissingProviderSecret indicates that the provider secret is missing.
 	ReasonMissingProviderSecret = "MissingProviderSecret"
+	// ReasonDrifted indicates that the secret has been changed outside of the external secret.
+	ReasonDrifted = "Drifted"
+	// ReasonAdopted indicates that the changes of the secret have been pushed to the provider.
+	ReasonAdopted = "Adopted"
 
 	// ConditionReasonResourceSynced indicates that the secrets was synced.
 	ConditionReasonResourceSynced = "ResourceSynced"
@@ -647,6 +898,18 @@ type ExternalSecretStatus struct {
 	// the target secret updated
 	RefreshTime metav1.Time `json:"refreshTime,omitempty"`
 
+	// ExpiresAt is the earliest expiration of the values produced by generators
+	// and of the remote secrets whose provider reports their expiry.
+	// The ExternalSecret is refreshed before this time, regardless of its refreshInterval,
+	// so that renewed values and rotated secrets are picked up.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// NextRefreshTime is the time of the next scheduled refresh,
+	// set if a refreshSchedule or syncWindows are used.
+	// +optional
+	NextRefreshTime *metav1.Time `json:"nextRefreshTime,omitempty"`
+
 	// SyncedResourceVersion keeps track of the last synced version
 	SyncedResourceVersion string `json:"syncedResourceVersion,omitempty"`
 
@@ -655,6 +918,108 @@ type ExternalSecretStatus struct {
 
 	// Binding represents a servicebinding.io Provisioned Service reference to the secret
 	Binding corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	// Plan lists the changes a dry run would make to the target secret.
+	// Only set if spec.dryRun is enabled.
+	// +optional
+	Plan *ExternalSecretPlan `json:"plan,omitempty"`
+
+	// Provenance lists the sources of the keys of the provider data,
+	// which are the keys of the target unless a template changes them.
+	// Only set if spec.recordProvenance is enabled.
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+	Provenance []ExternalSecretKeyProvenance `json:"provenance,omitempty"`
+}
+
+// ExternalSecretKeyProvenance lists the sources of a key of the provider data.
+type ExternalSecretKeyProvenance struct {
+	// Key is the key of the provider data.
+	Key string `json:"key"`
+
+	// Sources are the remote values the value of the key has been produced from.
+	// There is more than one source if a rewrite has merged several values.
+	// +optional
+	Sources []ExternalSecretKeySource `json:"sources,omitempty"`
+}
+
+// ExternalSecretKeySource is a remote value a key of the provider data has been produced from.
+type ExternalSecretKeySource struct {
+	// Ref is the entry of the ExternalSecret that fetched the value, e.g. `spec.data[0]` or `spec.dataFrom[1].find`.
+	Ref string `json:"ref"`
+
+	// StoreKind is the kind of the secret store the value has been fetched from.
+	// +optional
+	StoreKind string `json:"storeKind,omitempty"`
+
+	// StoreName is the name of the secret store the value has been fetched from.
+	// +optional
+	StoreName string `json:"storeName,omitempty"`
+
+	// RemoteKey is the key of the remote secret.
+	// +optional
+	RemoteKey string `json:"remoteKey,omitempty"`
+
+	// Property is the property of the remote secret, or the key of the generated values.
+	// +optional
+	Property string `json:"property,omitempty"`
+
+	// Version is the version of the remote secret, if one has been requested.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// GeneratorKind is the kind of the generator that produced the value.
+	// +optional
+	GeneratorKind string `json:"generatorKind,omitempty"`
+
+	// GeneratorName is the name of the generator that produced the value.
+	// +optional
+	GeneratorName string `json:"generatorName,omitempty"`
+}
+
+// ExternalSecretPlanAction is the change a dry run would make to a key of the target secret.
+// +kubebuilder:validation:Enum=Add;Change;Remove
+type ExternalSecretPlanAction string
+
+const (
+	// PlanActionAdd is used for keys that would be added to the secret.
+	PlanActionAdd ExternalSecretPlanAction = "Add"
+	// PlanActionChange is used for keys whose value would be changed.
+	PlanActionChange ExternalSecretPlanAction = "Change"
+	// PlanActionRemove is used for keys that would be removed from the secret.
+	PlanActionRemove ExternalSecretPlanAction = "Remove"
+)
+
+// ExternalSecretPlan is the result of a dry run of an ExternalSecret.
+type ExternalSecretPlan struct {
+	// Target is the name of the secret that would be written.
+	Target string `json:"target"`
+
+	// Create is true if the secret does not exist and would be created.
+	// +optional
+	Create bool `json:"create,omitempty"`
+
+	// Keys are the keys of the secret that would be added, changed or removed.
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+	Keys []ExternalSecretPlanKey `json:"keys,omitempty"`
+}
+
+// ExternalSecretPlanKey is a key of the target secret that would be changed by a dry run.
+type ExternalSecretPlanKey struct {
+	// Key is the key of the secret.
+	Key string `json:"key"`
+
+	// Action is the change that would be made to the key.
+	Action ExternalSecretPlanAction `json:"action"`
+
+	// Hash is the HMAC-SHA256 of the value that would be written, in hex.
+	// It is keyed with a key held by the controller, so that low-entropy values
+	// can not be guessed from the status. Not set for removed keys.
+	// +optional
+	Hash string `json:"hash,omitempty"`
 }
 
 // ExternalSecret is the Schema for the external-secrets API.
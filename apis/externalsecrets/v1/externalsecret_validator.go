@@ -20,8 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -29,16 +32,30 @@ import (
 var _ admission.CustomValidator = &ExternalSecretValidator{}
 
 // ExternalSecretValidator implements a validating webhook for ExternalSecrets.
-type ExternalSecretValidator struct{}
+// +kubebuilder:object:generate=false
+type ExternalSecretValidator struct {
+	// Reader reads the ClusterSecretStores and namespaces to check the remote keys
+	// against the access policies of the stores. The check is skipped if Reader is nil.
+	Reader client.Reader
+}
 
 // ValidateCreate is called on creation of ExternalSecret resource object.
-func (esv *ExternalSecretValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(obj)
+func (esv *ExternalSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return esv.validate(ctx, obj)
 }
 
 // ValidateUpdate is called when updating an ExternalSecret resource object.
-func (esv *ExternalSecretValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(newObj)
+func (esv *ExternalSecretValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return esv.validate(ctx, newObj)
+}
+
+func (esv *ExternalSecretValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	warns, err := validateExternalSecret(obj)
+	if err != nil || esv.Reader == nil {
+		return warns, err
+	}
+	policyWarns, err := validateRemoteKeyAccess(ctx, esv.Reader, obj.(*ExternalSecret))
+	return append(warns, policyWarns...), err
 }
 
 // ValidateDelete is called when deleting an ExternalSecret resource object.
@@ -57,6 +74,18 @@ func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 		errs = errors.Join(errs, err)
 	}
 
+	if err := validateGeneratorRotationPolicy(es); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := ValidateSchedules(es.Spec.RefreshSchedule, es.Spec.SyncWindows); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if es.Spec.DryRun && es.Spec.Target.Manifest != nil {
+		errs = errors.Join(errs, errors.New("dryRun must not be used with a manifest target"))
+	}
+
 	if len(es.Spec.Data) == 0 && len(es.Spec.DataFrom) == 0 {
 		errs = errors.Join(errs, errors.New("either data or dataFrom should be specified"))
 	}
@@ -75,10 +104,42 @@ func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 		}
 	}
 
+	if err := validateTemplate(es.Spec.Target.Template); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
 	errs = validateDuplicateKeys(es, errs)
 	return nil, errs
 }
 
+// validateTemplate compiles the inline templates with the compile check that
+// is registered for the engine version. Templates of ConfigMaps and Secrets
+// are only compiled by the controller.
+func validateTemplate(tpl *ExternalSecretTemplate) error {
+	if tpl == nil {
+		return nil
+	}
+	validate, ok := GetTemplateValidator(tpl.EngineVersion)
+	if !ok {
+		return nil
+	}
+	var errs error
+	for _, k := range slices.Sorted(maps.Keys(tpl.Data)) {
+		if err := validate(tpl.Data[k], TemplateScopeValues); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid template at key %s: %w", k, err))
+		}
+	}
+	for i, from := range tpl.TemplateFrom {
+		if from.Literal == nil {
+			continue
+		}
+		if err := validate(*from.Literal, TemplateScopeKeysAndValues); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid template at templateFrom[%d].literal: %w", i, err))
+		}
+	}
+	return errs
+}
+
 func validateSourceRef(ref ExternalSecretDataFromRemoteRef) error {
 	if ref.SourceRef != nil && ref.SourceRef.GeneratorRef == nil && ref.SourceRef.SecretStoreRef == nil {
 		return errors.New("generatorRef or storeRef must be set when using sourceRef in dataFrom")
@@ -115,6 +176,40 @@ func validatePolicies(es *ExternalSecret) error {
 		errs = errors.Join(errs, errors.New("deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with"))
 	}
 
+	if driftPolicy := es.Spec.Target.DriftPolicy; driftPolicy != "" && driftPolicy != DriftPolicyOverwrite {
+		if es.Spec.Target.CreationPolicy == CreatePolicyNone {
+			errs = errors.Join(errs, fmt.Errorf("driftPolicy=%s must not be used with creationPolicy=None. There is no Secret to drift", driftPolicy))
+		}
+		if es.Spec.Target.Manifest != nil {
+			errs = errors.Join(errs, fmt.Errorf("driftPolicy=%s must not be used with a manifest target", driftPolicy))
+		}
+	}
+
+	return errs
+}
+
+func validateGeneratorRotationPolicy(es *ExternalSecret) error {
+	policy := es.Spec.GeneratorRotationPolicy
+	if policy == nil {
+		return nil
+	}
+	if policy.PreviousKeySuffix == "" {
+		return nil
+	}
+	// the previous values are read from the target Secret, so its keys and values
+	// must be the generated ones.
+	var errs error
+	if es.Spec.Target.Manifest != nil {
+		errs = errors.Join(errs, errors.New("generatorRotationPolicy.previousKeySuffix must not be used with a manifest target"))
+	}
+	if es.Spec.Target.Template != nil {
+		errs = errors.Join(errs, errors.New("generatorRotationPolicy.previousKeySuffix must not be used with a target template"))
+	}
+	for i, ref := range es.Spec.DataFrom {
+		if ref.SourceRef != nil && ref.SourceRef.GeneratorRef != nil && len(ref.Rewrite) > 0 {
+			errs = errors.Join(errs, fmt.Errorf("generatorRotationPolicy.previousKeySuffix must not be used with rewrites of generated keys in dataFrom[%d]", i))
+		}
+	}
 	return errs
 }
 
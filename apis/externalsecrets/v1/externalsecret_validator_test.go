@@ -17,8 +17,11 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -131,6 +134,36 @@ func TestValidateExternalSecret(t *testing.T) {
 			},
 			expectedErr: "either extract, find, or sourceRef must be set to dataFrom",
 		},
+		{
+			name: "drift policy report without secret",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						CreationPolicy: CreatePolicyNone,
+						DriftPolicy:    DriftPolicyReport,
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "driftPolicy=Report must not be used with creationPolicy=None. There is no Secret to drift",
+		},
+		{
+			name: "drift policy adopt with manifest target",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						DriftPolicy: DriftPolicyAdopt,
+						Manifest:    &ManifestReference{APIVersion: "v1", Kind: "ConfigMap"},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "driftPolicy=Adopt must not be used with a manifest target",
+		},
 		{
 			name: "empty sourceRef",
 			obj: &ExternalSecret{
@@ -157,6 +190,86 @@ func TestValidateExternalSecret(t *testing.T) {
 			expectedErr: `deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with
 either data or dataFrom should be specified`,
 		},
+		{
+			name: "previous key suffix with manifest target",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Manifest: &ManifestReference{},
+					},
+					GeneratorRotationPolicy: &GeneratorRotationPolicy{
+						PreviousKeySuffix: "-previous",
+					},
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							SourceRef: &StoreGeneratorSourceRef{
+								GeneratorRef: &GeneratorRef{},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "generatorRotationPolicy.previousKeySuffix must not be used with a manifest target",
+		},
+		{
+			name: "previous key suffix with template",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Template: &ExternalSecretTemplate{
+							Data: map[string]string{"password": "{{ .password | upper }}"},
+						},
+					},
+					GeneratorRotationPolicy: &GeneratorRotationPolicy{
+						PreviousKeySuffix: "-previous",
+					},
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							SourceRef: &StoreGeneratorSourceRef{
+								GeneratorRef: &GeneratorRef{},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "generatorRotationPolicy.previousKeySuffix must not be used with a target template",
+		},
+		{
+			name: "previous key suffix with rewrite of generated keys",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					GeneratorRotationPolicy: &GeneratorRotationPolicy{
+						PreviousKeySuffix: "-previous",
+					},
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							SourceRef: &StoreGeneratorSourceRef{
+								GeneratorRef: &GeneratorRef{},
+							},
+							Rewrite: []ExternalSecretRewrite{
+								{Regexp: &ExternalSecretRewriteRegexp{Source: "password", Target: "db-password"}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "generatorRotationPolicy.previousKeySuffix must not be used with rewrites of generated keys in dataFrom[0]",
+		},
+		{
+			name: "dry run with manifest target",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DryRun: true,
+					Target: ExternalSecretTarget{
+						Manifest: &ManifestReference{APIVersion: "v1", Kind: "ConfigMap"},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "dryRun must not be used with a manifest target",
+		},
 		{
 			name: "valid",
 			obj: &ExternalSecret{
@@ -204,6 +317,43 @@ either data or dataFrom should be specified`,
 			},
 			expectedErr: "duplicate secretKey found: SERVICE_NAME",
 		},
+		{
+			name: "valid refresh schedule and sync windows",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					RefreshSchedule: &RefreshSchedule{Cron: "0 2 * * *", TimeZone: "Europe/Berlin"},
+					SyncWindows: []SyncWindow{
+						{Kind: SyncWindowDeny, Schedule: "0 8 * * 1-5", Duration: metav1.Duration{Duration: 10 * time.Hour}},
+					},
+					Data: []ExternalSecretData{{}},
+				},
+			},
+		},
+		{
+			name: "invalid refresh schedule and sync windows",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					RefreshSchedule: &RefreshSchedule{Cron: "0 2 * * *", TimeZone: "Mars/Olympus"},
+					SyncWindows: []SyncWindow{
+						{Kind: SyncWindowDeny, Schedule: "0 8 * * 1-5"},
+					},
+					Data: []ExternalSecretData{{}},
+				},
+			},
+			expectedErr: "refreshSchedule: invalid time zone \"Mars/Olympus\": unknown time zone Mars/Olympus\nsyncWindows[0]: duration must be positive",
+		},
+		{
+			name: "invalid sync window kind",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					SyncWindows: []SyncWindow{
+						{Kind: "Maybe", Schedule: "0 8 * * 1-5", Duration: metav1.Duration{Duration: time.Hour}},
+					},
+					Data: []ExternalSecretData{{}},
+				},
+			},
+			expectedErr: "syncWindows[0]: invalid sync window kind \"Maybe\"",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -224,3 +374,68 @@ either data or dataFrom should be specified`,
 		})
 	}
 }
+
+func TestValidateExternalSecretTemplate(t *testing.T) {
+	const engine TemplateEngineVersion = "test"
+	RegisterTemplateValidator(engine, func(tpl string, scope TemplateScope) error {
+		if tpl == "invalid" {
+			return fmt.Errorf("invalid %s template", scope)
+		}
+		return nil
+	})
+	literal := "invalid"
+	tests := []struct {
+		name        string
+		template    *ExternalSecretTemplate
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			template: &ExternalSecretTemplate{
+				EngineVersion: engine,
+				Data:          map[string]string{"key": "valid"},
+			},
+		},
+		{
+			name: "engine without validator",
+			template: &ExternalSecretTemplate{
+				EngineVersion: TemplateEngineV2,
+				Data:          map[string]string{"key": "invalid"},
+			},
+		},
+		{
+			name: "invalid data and literal",
+			template: &ExternalSecretTemplate{
+				EngineVersion: engine,
+				Data:          map[string]string{"b": "invalid", "a": "invalid", "c": "valid"},
+				TemplateFrom: []TemplateFrom{
+					{ConfigMap: &TemplateRef{Name: "templates"}},
+					{Literal: &literal},
+				},
+			},
+			expectedErr: "invalid template at key a: invalid Values template\n" +
+				"invalid template at key b: invalid Values template\n" +
+				"invalid template at templateFrom[1].literal: invalid KeysAndValues template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{Template: tt.template},
+					Data:   []ExternalSecretData{{SecretKey: "key"}},
+				},
+			}
+			_, err := validateExternalSecret(es)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("validateExternalSecret() returned an unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Fatalf("validateExternalSecret() returned an unexpected error: got: %v, expected: %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
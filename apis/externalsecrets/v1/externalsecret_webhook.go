@@ -24,6 +24,6 @@ import (
 func (es *ExternalSecret) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(es).
-		WithValidator(&ExternalSecretValidator{}).
+		WithValidator(&ExternalSecretValidator{Reader: mgr.GetAPIReader()}).
 		Complete()
 }
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -99,6 +100,114 @@ type SecretsClient interface {
 	Close(ctx context.Context) error
 }
 
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretsWatcher is an optional interface of a SecretsClient whose backend
+// can notify about changed secrets. The controller keeps a long-lived watch per
+// store and refreshes the ExternalSecrets that read a changed key, instead of
+// waiting for their refreshInterval.
+type SecretsWatcher interface {
+	// WatchSecrets blocks and calls onChange with the keys of changed secrets
+	// until ctx is done or the watch fails. It returns nil once ctx is done.
+	// After an error the watch is re-established with a new client.
+	WatchSecrets(ctx context.Context, onChange func(keys []string)) error
+}
+
+// RefreshAnyKey is passed to Refresher.RequestRefresh if any key of a store may have changed,
+// e.g. while a watch of the store was down. It refreshes all ExternalSecrets that read from the store.
+const RefreshAnyKey = "*"
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// Refresher refreshes the ExternalSecrets that read one of the keys from a store,
+// e.g. on a change reported by a SecretsWatcher or a notification of the backend.
+type Refresher interface {
+	// RequestRefresh refreshes the ExternalSecrets that read one of the keys from the store
+	// and returns the number of ExternalSecrets that have been enqueued.
+	RequestRefresh(ctx context.Context, store SecretStoreRef, namespace string, keys []string) (int, error)
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretMetadataGetter is an optional interface of a SecretsClient whose backend
+// knows when secrets expire. The controller uses it instead of GetSecret to warn
+// about secrets that are about to expire and to refresh them before they expire.
+type SecretMetadataGetter interface {
+	// GetSecretWithMetadata returns a single secret like GetSecret,
+	// together with the metadata of the returned version.
+	GetSecretWithMetadata(ctx context.Context, ref ExternalSecretDataRemoteRef) ([]byte, *SecretMetadata, error)
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// CredentialsExpirer is an optional interface of a SecretsClient whose credentials
+// expire and are not renewed by the client. The client pool of the controller
+// replaces such a client before its credentials expire.
+type CredentialsExpirer interface {
+	// CredentialsExpireAt returns the time the credentials of the client expire,
+	// or the zero time if they do not expire.
+	CredentialsExpireAt() time.Time
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// PoolableClientProvider is an optional interface of a Provider whose clients
+// may be kept open and shared by concurrent reconciles. The client pool of the
+// controller only pools the clients of providers that implement it: their
+// clients must be safe for concurrent use, must not cache remote values, and
+// must implement CredentialsExpirer if their credentials are not renewed.
+type PoolableClientProvider interface {
+	// PoolableClients returns true if the clients of the provider may be pooled.
+	PoolableClients() bool
+}
+
+// +kubebuilder:object:generate=false
+type pooledClientKey struct{}
+
+// WithPooledClient returns a context for Provider.NewClient which tells the
+// provider that the client is kept in the client pool.
+func WithPooledClient(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pooledClientKey{}, true)
+}
+
+// IsPooledClient returns true if the client created with ctx is kept in the client pool,
+// so that providers can skip measures which are only needed for clients of a single reconcile.
+func IsPooledClient(ctx context.Context) bool {
+	pooled, _ := ctx.Value(pooledClientKey{}).(bool)
+	return pooled
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretMetadata is the metadata of a version of a remote secret.
+// Zero values mean the provider does not know the value.
+type SecretMetadata struct {
+	// Version is the version of the secret.
+	Version string
+	// ExpiresAt is the time the secret expires.
+	ExpiresAt time.Time
+	// LastRotatedAt is the time the version has been created.
+	LastRotatedAt time.Time
+}
+
 // NoSecretErr is a sentinel error for when a secret is not found.
 var NoSecretErr = NoSecretError{}
 
@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	errParseCron      = "invalid cron expression %q: %w"
+	errTimeZone       = "invalid time zone %q: %w"
+	errWindowKind     = "invalid sync window kind %q"
+	errWindowLength   = "duration must be positive"
+	errNegativeJitter = "jitter must not be negative"
+)
+
+// ParseCronSchedule parses a standard cron expression, evaluated in the time zone.
+// An empty time zone is UTC.
+func ParseCronSchedule(expr, timeZone string) (cron.Schedule, *time.Location, error) {
+	sched, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errParseCron, expr, err)
+	}
+	loc := time.UTC
+	if timeZone != "" {
+		loc, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, nil, fmt.Errorf(errTimeZone, timeZone, err)
+		}
+	}
+	return sched, loc, nil
+}
+
+// ParseSyncWindow checks the kind and the duration of the sync window and parses its schedule.
+func ParseSyncWindow(w SyncWindow) (cron.Schedule, *time.Location, error) {
+	if w.Kind != SyncWindowAllow && w.Kind != SyncWindowDeny {
+		return nil, nil, fmt.Errorf(errWindowKind, w.Kind)
+	}
+	if w.Duration.Duration <= 0 {
+		return nil, nil, errors.New(errWindowLength)
+	}
+	return ParseCronSchedule(w.Schedule, w.TimeZone)
+}
+
+// ValidateSchedules checks the refresh schedule and the sync windows of an
+// ExternalSecret or PushSecret. It is used by both the webhook and the controllers.
+func ValidateSchedules(refresh *RefreshSchedule, windows []SyncWindow) error {
+	var errs error
+	if refresh != nil {
+		if _, _, err := ParseCronSchedule(refresh.Cron, refresh.TimeZone); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("refreshSchedule: %w", err))
+		}
+		if refresh.Jitter != nil && refresh.Jitter.Duration < 0 {
+			errs = errors.Join(errs, fmt.Errorf("refreshSchedule: %s", errNegativeJitter))
+		}
+	}
+	for i, w := range windows {
+		if _, _, err := ParseSyncWindow(w); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("syncWindows[%d]: %w", i, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,294 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// namespaceNameLabel is the label that holds the name of every namespace.
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// Matches returns true if the condition chooses the namespace with the given labels.
+func (c *ClusterSecretStoreCondition) Matches(namespace string, namespaceLabels map[string]string) (bool, error) {
+	nsLabels := labels.Set(namespaceLabels)
+	var labelSelectors []*metav1.LabelSelector
+	if c.NamespaceSelector != nil {
+		labelSelectors = append(labelSelectors, c.NamespaceSelector)
+	}
+	for _, n := range c.Namespaces {
+		labelSelectors = append(labelSelectors, &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				namespaceNameLabel: n,
+			},
+		})
+	}
+
+	for _, ls := range labelSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(ls)
+		if err != nil {
+			return false, fmt.Errorf("failed to convert label selector into selector %v: %w", ls, err)
+		}
+		if selector.Matches(nsLabels) {
+			return true, nil
+		}
+	}
+
+	for _, reg := range c.NamespaceRegexes {
+		match, err := regexp.MatchString(reg, namespace)
+		if err != nil {
+			// Should not happen since store validation already verified the regexes.
+			return false, fmt.Errorf("failed to compile regex %v: %w", reg, err)
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *ClusterSecretStoreCondition) empty() bool {
+	return c.NamespaceSelector == nil && len(c.Namespaces) == 0 && len(c.NamespaceRegexes) == 0
+}
+
+// RemoteKeyPolicy is the result of the access policies of a ClusterSecretStore for a namespace.
+// A nil RemoteKeyPolicy allows all keys.
+// +kubebuilder:object:generate=false
+type RemoteKeyPolicy struct {
+	allowed []*regexp.Regexp
+	denied  []*regexp.Regexp
+	// restricted is true if a policy has allowedKeys, so that keys must match one of them.
+	restricted bool
+}
+
+// NewRemoteKeyPolicy returns the policy that the access policies of the store apply to the namespace
+// with the given labels. It returns nil if the store is not a ClusterSecretStore or no policy applies.
+func NewRemoteKeyPolicy(store GenericStore, namespace string, namespaceLabels map[string]string) (*RemoteKeyPolicy, error) {
+	if store.GetKind() != ClusterSecretStoreKind || len(store.GetSpec().AccessPolicies) == 0 {
+		return nil, nil
+	}
+	var policy *RemoteKeyPolicy
+	for i, accessPolicy := range store.GetSpec().AccessPolicies {
+		applies := accessPolicy.empty()
+		if !applies {
+			var err error
+			applies, err = accessPolicy.Matches(namespace, namespaceLabels)
+			if err != nil {
+				return nil, fmt.Errorf("spec.accessPolicies[%d]: %w", i, err)
+			}
+		}
+		if !applies {
+			continue
+		}
+		if policy == nil {
+			policy = &RemoteKeyPolicy{}
+		}
+		allowed, err := compileKeyPatterns(accessPolicy.AllowedKeys, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("spec.accessPolicies[%d].allowedKeys: %w", i, err)
+		}
+		denied, err := compileKeyPatterns(accessPolicy.DeniedKeys, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("spec.accessPolicies[%d].deniedKeys: %w", i, err)
+		}
+		policy.allowed = append(policy.allowed, allowed...)
+		policy.denied = append(policy.denied, denied...)
+		policy.restricted = policy.restricted || len(accessPolicy.AllowedKeys) > 0
+	}
+	return policy, nil
+}
+
+// Allows returns true if the namespace of the policy may access the remote key.
+// The key is matched in its canonical form, keys with "." or ".." segments are denied.
+func (p *RemoteKeyPolicy) Allows(key string) bool {
+	if p == nil {
+		return true
+	}
+	key, ok := canonicalRemoteKey(key)
+	if !ok {
+		return false
+	}
+	for _, denied := range p.denied {
+		if denied.MatchString(key) {
+			return false
+		}
+	}
+	if !p.restricted {
+		return true
+	}
+	for _, allowed := range p.allowed {
+		if allowed.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalRemoteKey strips the leading slash and duplicate slashes of the key, so that
+// different spellings of a key match the same patterns. It returns false if the key has
+// "." or ".." segments, as providers with hierarchical keys may resolve them to other keys.
+func canonicalRemoteKey(key string) (string, bool) {
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return "", false
+		}
+	}
+	return strings.TrimPrefix(path.Clean("/"+key), "/"), true
+}
+
+// compileKeyPatterns replaces {{ .Namespace }} in the patterns and compiles them to match whole keys.
+func compileKeyPatterns(patterns []string, namespace string) ([]*regexp.Regexp, error) {
+	out := make([]*regexp.Regexp, 0, len(patterns))
+	for i, pattern := range patterns {
+		tpl, err := template.New("pattern").Option("missingkey=error").Parse(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %d: %w", i, err)
+		}
+		var expanded strings.Builder
+		if err := tpl.Execute(&expanded, struct{ Namespace string }{Namespace: regexp.QuoteMeta(namespace)}); err != nil {
+			return nil, fmt.Errorf("invalid pattern %d: %w", i, err)
+		}
+		re, err := regexp.Compile("^(?:" + expanded.String() + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %d: %w", i, err)
+		}
+		out = append(out, re)
+	}
+	return out, nil
+}
+
+// validateAccessPolicies compiles the patterns of the access policies of the store.
+func validateAccessPolicies(store GenericStore) error {
+	var errs []string
+	for i, accessPolicy := range store.GetSpec().AccessPolicies {
+		for ri, r := range accessPolicy.NamespaceRegexes {
+			if _, err := regexp.Compile(r); err != nil {
+				errs = append(errs, fmt.Sprintf("spec.accessPolicies[%d].namespaceRegexes[%d]: %v", i, ri, err))
+			}
+		}
+		// the namespace does not change whether a pattern is valid, as it is quoted
+		if _, err := compileKeyPatterns(accessPolicy.AllowedKeys, "namespace"); err != nil {
+			errs = append(errs, fmt.Sprintf("spec.accessPolicies[%d].allowedKeys: %v", i, err))
+		}
+		if _, err := compileKeyPatterns(accessPolicy.DeniedKeys, "namespace"); err != nil {
+			errs = append(errs, fmt.Sprintf("spec.accessPolicies[%d].deniedKeys: %v", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid access policies: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// RemoteKeyRef is a remote key an ExternalSecret or PushSecret accesses through a store.
+// +kubebuilder:object:generate=false
+type RemoteKeyRef struct {
+	// Field of the key in the spec of the resource, used in errors.
+	Field string
+	// Key is the remote key as it is written in the resource.
+	Key string
+	// StoreRef is the store the key is accessed through.
+	StoreRef SecretStoreRef
+}
+
+// validateRemoteKeyAccess checks the remote keys the ExternalSecret reads from ClusterSecretStores
+// against the access policies of the stores. Keys found by dataFrom.find are filtered by the controller.
+func validateRemoteKeyAccess(ctx context.Context, reader client.Reader, es *ExternalSecret) (admission.Warnings, error) {
+	var refs []RemoteKeyRef
+	for i, data := range es.Spec.Data {
+		storeRef := es.Spec.SecretStoreRef
+		if data.SourceRef != nil {
+			if data.SourceRef.GeneratorRef != nil {
+				continue
+			}
+			if data.SourceRef.SecretStoreRef.Name != "" {
+				storeRef = data.SourceRef.SecretStoreRef
+			}
+		}
+		refs = append(refs, RemoteKeyRef{Field: fmt.Sprintf("data[%d].remoteRef.key", i), Key: data.RemoteRef.Key, StoreRef: storeRef})
+	}
+	for i, data := range es.Spec.DataFrom {
+		if data.Extract == nil {
+			continue
+		}
+		storeRef := es.Spec.SecretStoreRef
+		if data.SourceRef != nil && data.SourceRef.SecretStoreRef != nil {
+			storeRef = *data.SourceRef.SecretStoreRef
+		}
+		refs = append(refs, RemoteKeyRef{Field: fmt.Sprintf("dataFrom[%d].extract.key", i), Key: data.Extract.Key, StoreRef: storeRef})
+	}
+	return ValidateRemoteKeyAccess(ctx, reader, es.Namespace, refs)
+}
+
+// ValidateRemoteKeyAccess checks the remote keys a resource in namespace accesses through
+// ClusterSecretStores against the access policies of the stores, keys of SecretStores are not checked.
+// Stores that do not exist yet are skipped, and lookups that fail only produce a warning.
+func ValidateRemoteKeyAccess(ctx context.Context, reader client.Reader, namespace string, refs []RemoteKeyRef) (admission.Warnings, error) {
+	var warns admission.Warnings
+	var errs error
+	policies := make(map[string]*RemoteKeyPolicy)
+	for _, ref := range refs {
+		if ref.StoreRef.Kind != ClusterSecretStoreKind {
+			continue
+		}
+		policy, ok := policies[ref.StoreRef.Name]
+		if !ok {
+			var err error
+			policy, err = getRemoteKeyPolicy(ctx, reader, ref.StoreRef.Name, namespace)
+			if err != nil {
+				warns = append(warns, fmt.Sprintf("unable to check the access policies of ClusterSecretStore %q: %v", ref.StoreRef.Name, err))
+			}
+			policies[ref.StoreRef.Name] = policy
+		}
+		if !policy.Allows(ref.Key) {
+			errs = errors.Join(errs, fmt.Errorf("%s: access to remote key %q is denied for namespace %q by spec.accessPolicies of ClusterSecretStore %q", ref.Field, ref.Key, namespace, ref.StoreRef.Name))
+		}
+	}
+	return warns, errs
+}
+
+// getRemoteKeyPolicy returns the policy of the ClusterSecretStore for the namespace, or nil if the store does not exist.
+func getRemoteKeyPolicy(ctx context.Context, reader client.Reader, storeName, namespace string) (*RemoteKeyPolicy, error) {
+	var store ClusterSecretStore
+	if err := reader.Get(ctx, client.ObjectKey{Name: storeName}, &store); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(store.Spec.AccessPolicies) == 0 {
+		return nil, nil
+	}
+	var ns corev1.Namespace
+	if err := reader.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return nil, err
+	}
+	return NewRemoteKeyPolicy(&store, namespace, ns.GetLabels())
+}
@@ -0,0 +1,204 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRemoteKeyPolicy(t *testing.T) {
+	store := &ClusterSecretStore{
+		Spec: SecretStoreSpec{
+			AccessPolicies: []ClusterSecretStoreAccessPolicy{
+				{
+					// applies to all namespaces
+					DeniedKeys: []string{`admin/.*`},
+				},
+				{
+					ClusterSecretStoreCondition: ClusterSecretStoreCondition{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+					},
+					AllowedKeys: []string{`teams/{{ .Namespace }}/.*`, `shared/.*`},
+					DeniedKeys:  []string{`shared/internal`},
+				},
+				{
+					ClusterSecretStoreCondition: ClusterSecretStoreCondition{
+						NamespaceRegexes: []string{`^b-`},
+					},
+					AllowedKeys: []string{`{{ .Namespace }}`},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		labels    map[string]string
+		allowed   []string
+		denied    []string
+	}{
+		{
+			name:      "only the policy without namespaces applies",
+			namespace: "other",
+			allowed:   []string{"teams/a/db", "anything"},
+			denied:    []string{"admin/root"},
+		},
+		{
+			name:      "allowed keys of the matching policy",
+			namespace: "a.one",
+			labels:    map[string]string{"team": "a"},
+			allowed:   []string{"teams/a.one/db", "shared/config", "/teams/a.one/db", "teams//a.one/db/"},
+			denied: []string{
+				"teams/a-one/db", "teams/other/db", "shared/internal", "admin/root", "prefix/teams/a.one/db",
+				// keys are matched in their canonical form
+				"/admin/root", "admin//root", "shared//internal", "shared/internal/",
+				// dot segments are denied, even if the key matches a pattern
+				"teams/a.one/../other/db", "teams/a.one/./db", "shared/../admin/root",
+			},
+		},
+		{
+			name:      "the namespace is the key",
+			namespace: "b-one",
+			allowed:   []string{"b-one"},
+			denied:    []string{"b-one/db", "b-two"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewRemoteKeyPolicy(store, tt.namespace, tt.labels)
+			require.NoError(t, err)
+			require.NotNil(t, policy)
+			for _, key := range tt.allowed {
+				assert.True(t, policy.Allows(key), "key %q should be allowed", key)
+			}
+			for _, key := range tt.denied {
+				assert.False(t, policy.Allows(key), "key %q should be denied", key)
+			}
+		})
+	}
+
+	policy, err := NewRemoteKeyPolicy(&SecretStore{Spec: store.Spec}, "other", nil)
+	require.NoError(t, err)
+	assert.Nil(t, policy, "access policies of a SecretStore are ignored")
+	assert.True(t, policy.Allows("admin/root"))
+}
+
+func TestValidateAccessPolicies(t *testing.T) {
+	valid := &ClusterSecretStore{Spec: SecretStoreSpec{AccessPolicies: []ClusterSecretStoreAccessPolicy{{
+		AllowedKeys: []string{`teams/{{ .Namespace }}/.*`},
+	}}}}
+	require.NoError(t, validateAccessPolicies(valid))
+
+	invalid := &ClusterSecretStore{Spec: SecretStoreSpec{AccessPolicies: []ClusterSecretStoreAccessPolicy{{
+		ClusterSecretStoreCondition: ClusterSecretStoreCondition{NamespaceRegexes: []string{`\1`}},
+		AllowedKeys:                 []string{`teams/{{ .Name }}`},
+		DeniedKeys:                  []string{`(`},
+	}}}}
+	err := validateAccessPolicies(invalid)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "spec.accessPolicies[0].namespaceRegexes[0]")
+	assert.ErrorContains(t, err, "spec.accessPolicies[0].allowedKeys")
+	assert.ErrorContains(t, err, "spec.accessPolicies[0].deniedKeys")
+}
+
+// policyReader returns the objects by name, and fails for the names in errs.
+type policyReader struct {
+	client.Reader
+	objects map[string]client.Object
+	errs    map[string]error
+}
+
+func (r *policyReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	if err, ok := r.errs[key.Name]; ok {
+		return err
+	}
+	found, ok := r.objects[key.Name]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	switch o := obj.(type) {
+	case *ClusterSecretStore:
+		*o = *found.(*ClusterSecretStore)
+	case *corev1.Namespace:
+		*o = *found.(*corev1.Namespace)
+	}
+	return nil
+}
+
+func TestValidateRemoteKeyAccess(t *testing.T) {
+	reader := &policyReader{
+		objects: map[string]client.Object{
+			"restricted": &ClusterSecretStore{Spec: SecretStoreSpec{AccessPolicies: []ClusterSecretStoreAccessPolicy{{
+				AllowedKeys: []string{`{{ .Namespace }}/.*`},
+			}}}},
+			"team-a": &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		},
+		errs: map[string]error{"forbidden": errors.New("forbidden")},
+	}
+	validator := &ExternalSecretValidator{Reader: reader}
+	es := &ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "team-a"},
+		Spec: ExternalSecretSpec{
+			SecretStoreRef: SecretStoreRef{Name: "restricted", Kind: ClusterSecretStoreKind},
+			Data: []ExternalSecretData{
+				{SecretKey: "allowed", RemoteRef: ExternalSecretDataRemoteRef{Key: "team-a/db"}},
+				{SecretKey: "missing", RemoteRef: ExternalSecretDataRemoteRef{Key: "team-b/db"}, SourceRef: &StoreSourceRef{
+					SecretStoreRef: SecretStoreRef{Name: "missing", Kind: ClusterSecretStoreKind},
+				}},
+				{SecretKey: "forbidden", RemoteRef: ExternalSecretDataRemoteRef{Key: "team-b/db"}, SourceRef: &StoreSourceRef{
+					SecretStoreRef: SecretStoreRef{Name: "forbidden", Kind: ClusterSecretStoreKind},
+				}},
+			},
+			DataFrom: []ExternalSecretDataFromRemoteRef{
+				{Extract: &ExternalSecretDataRemoteRef{Key: "team-b/db"}},
+				{Find: &ExternalSecretFind{Name: &FindName{RegExp: ".*"}}},
+			},
+		},
+	}
+
+	warns, err := validator.ValidateCreate(context.Background(), es)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `dataFrom[0].extract.key: access to remote key "team-b/db" is denied for namespace "team-a" by spec.accessPolicies of ClusterSecretStore "restricted"`)
+	assert.NotContains(t, err.Error(), "data[")
+	assert.Equal(t, []string{`unable to check the access policies of ClusterSecretStore "forbidden": forbidden`}, []string(warns))
+
+	es.Spec.DataFrom = es.Spec.DataFrom[1:]
+	_, err = validator.ValidateCreate(context.Background(), es)
+	require.NoError(t, err)
+
+	for _, key := range []string{"team-a/../team-b/db", "/team-a/./db", "./team-a/db"} {
+		_, err := ValidateRemoteKeyAccess(context.Background(), reader, "team-a", []RemoteKeyRef{
+			{Field: "key", Key: key, StoreRef: SecretStoreRef{Name: "restricted", Kind: ClusterSecretStoreKind}},
+		})
+		assert.Error(t, err, "key %q should be denied", key)
+	}
+	_, err = ValidateRemoteKeyAccess(context.Background(), reader, "team-a", []RemoteKeyRef{
+		{Field: "key", Key: "/team-a//db", StoreRef: SecretStoreRef{Name: "restricted", Kind: ClusterSecretStoreKind}},
+	})
+	assert.NoError(t, err)
+}
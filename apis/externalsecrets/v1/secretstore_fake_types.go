@@ -16,6 +16,10 @@ limitations under the License.
 
 package v1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // FakeProvider configures a fake provider that returns static values.
 type FakeProvider struct {
 	Data             []FakeProviderData `json:"data"`
@@ -27,4 +31,7 @@ type FakeProviderData struct {
 	Key     string `json:"key"`
 	Value   string `json:"value"`
 	Version string `json:"version,omitempty"`
+	// ExpiresAt is the expiry the provider reports for the secret.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// GitProvider configures a store to sync secrets from files of a Git repository.
+// Files encrypted with SOPS are decrypted with the configured age or OpenPGP keys.
+type GitProvider struct {
+	// URL of the Git repository, e.g. https://github.com/org/repo.git or ssh://git@github.com/org/repo.git.
+	URL string `json:"url"`
+
+	// Ref is the branch or tag to read the files from. Defaults to the default branch of the repository.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Path is the directory of the repository that remote keys are relative to.
+	// Defaults to the root of the repository.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Auth configures how the operator authenticates with the Git server.
+	// +optional
+	Auth *GitAuth `json:"auth,omitempty"`
+
+	// SOPS configures the keys used to decrypt SOPS-encrypted files.
+	// +optional
+	SOPS *GitSOPS `json:"sops,omitempty"`
+
+	// RefreshInterval is the time a shallow clone of the repository is cached
+	// before the ref is fetched again.
+	// +kubebuilder:default="1m"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// GitAuth contains the credentials used to access a Git repository.
+// Only one of basic or ssh may be set.
+type GitAuth struct {
+	// Basic authenticates with a username and a password or access token over HTTPS.
+	// +optional
+	Basic *GitBasicAuth `json:"basic,omitempty"`
+
+	// SSH authenticates with a private key over SSH.
+	// +optional
+	SSH *GitSSHAuth `json:"ssh,omitempty"`
+}
+
+// GitBasicAuth contains the HTTP basic auth credentials of a Git repository.
+type GitBasicAuth struct {
+	// Username used for basic auth. Most Git servers accept any non-empty
+	// username when authenticating with an access token.
+	// +kubebuilder:default="git"
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references the password or access token.
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// GitSSHAuth contains the SSH credentials of a Git repository.
+type GitSSHAuth struct {
+	// User used to connect to the SSH server.
+	// +kubebuilder:default="git"
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// PrivateKeySecretRef references the PEM encoded SSH private key.
+	PrivateKeySecretRef esmeta.SecretKeySelector `json:"privateKeySecretRef"`
+
+	// PassphraseSecretRef references the passphrase of the private key.
+	// +optional
+	PassphraseSecretRef *esmeta.SecretKeySelector `json:"passphraseSecretRef,omitempty"`
+
+	// KnownHostsSecretRef references the known_hosts entries used to verify the host key of the server.
+	KnownHostsSecretRef esmeta.SecretKeySelector `json:"knownHostsSecretRef"`
+}
+
+// GitSOPS contains the keys used to decrypt SOPS-encrypted files.
+// At least one of ageSecretRef or pgpSecretRef must be set.
+type GitSOPS struct {
+	// AgeSecretRef references age identities in the format of an age key file.
+	// +optional
+	AgeSecretRef *esmeta.SecretKeySelector `json:"ageSecretRef,omitempty"`
+
+	// PGPSecretRef references an armored OpenPGP private key.
+	// +optional
+	PGPSecretRef *esmeta.SecretKeySelector `json:"pgpSecretRef,omitempty"`
+
+	// PGPPassphraseSecretRef references the passphrase of the OpenPGP private key.
+	// +optional
+	PGPPassphraseSecretRef *esmeta.SecretKeySelector `json:"pgpPassphraseSecretRef,omitempty"`
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// PluginProvider configures a store to sync secrets using a provider plugin.
+// The plugin runs in a separate process and implements the gRPC contract
+// of the external-secrets plugin protocol.
+type PluginProvider struct {
+	// Address of the plugin. Either a unix domain socket, e.g. unix:///var/run/eso/my-plugin.sock,
+	// or a host and port, e.g. my-plugin.plugins.svc:8443.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// TLS configures the connection to plugins that are reached over the network.
+	// It is required for such plugins, unless insecure is set.
+	// +optional
+	TLS *PluginTLS `json:"tls,omitempty"`
+
+	// Insecure allows an unencrypted connection to a plugin that is reached over the network.
+	// The secretRefs and all secrets are sent in plain text then. Connections to unix domain
+	// sockets do not need it.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Timeout of a single call to the plugin. Defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Config is passed to the plugin as is with every call.
+	// The structure of the config is plugin specific, please look it up in the plugin documentation.
+	// +optional
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// SecretRefs are resolved by the controller and passed to the plugin with every call,
+	// so plugins can use credentials without access to the Kubernetes API.
+	// The keys of the map are the names the plugin receives the values with.
+	// +optional
+	SecretRefs map[string]esmeta.SecretKeySelector `json:"secretRefs,omitempty"`
+}
+
+// PluginTLS configures mutual TLS for the connection to a plugin.
+type PluginTLS struct {
+	// CABundle is a PEM-encoded CA certificate to validate the certificate of the plugin.
+	// Defaults to the system trust store.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// CAProvider is the provider for the CA bundle to validate the certificate of the plugin.
+	// +optional
+	CAProvider *CAProvider `json:"caProvider,omitempty"`
+
+	// ServerName is used to verify the certificate of the plugin. Defaults to the host of the address.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// CertSecretRef is the client certificate that is presented to the plugin.
+	// If no key for the Secret is specified, external-secret will default to 'tls.crt'.
+	// +optional
+	CertSecretRef *esmeta.SecretKeySelector `json:"certSecretRef,omitempty"`
+
+	// KeySecretRef is the private key of the client certificate.
+	// If no key for the Secret is specified, external-secret will default to 'tls.key'.
+	// +optional
+	KeySecretRef *esmeta.SecretKeySelector `json:"keySecretRef,omitempty"`
+}
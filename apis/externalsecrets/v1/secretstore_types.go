@@ -42,6 +42,17 @@ type SecretStoreSpec struct {
 	// Used to constrain a ClusterSecretStore to specific namespaces. Relevant only to ClusterSecretStore.
 	// +optional
 	Conditions []ClusterSecretStoreCondition `json:"conditions,omitempty"`
+
+	// Used to constrain the remote keys that namespaces may read and write through a ClusterSecretStore.
+	// Relevant only to ClusterSecretStore.
+	// +optional
+	AccessPolicies []ClusterSecretStoreAccessPolicy `json:"accessPolicies,omitempty"`
+
+	// Allows ExternalSecrets with target.driftPolicy=Adopt to push changes of their target Secrets to the
+	// provider through this store. Anyone who may edit such a Secret can then write to the remote secrets
+	// the ExternalSecret reads. Adoption must also be enabled with the --unsafe-allow-drift-adoption flag of the controller.
+	// +optional
+	AllowDriftAdoption bool `json:"allowDriftAdoption,omitempty"`
 }
 
 // ClusterSecretStoreCondition describes a condition by which to choose namespaces to process ExternalSecrets in
@@ -63,6 +74,27 @@ type ClusterSecretStoreCondition struct {
 	NamespaceRegexes []string `json:"namespaceRegexes,omitempty"`
 }
 
+// ClusterSecretStoreAccessPolicy restricts the remote keys that the ExternalSecrets and PushSecrets
+// of the namespaces it applies to may read and write through a ClusterSecretStore.
+// A key is denied if it matches a deniedKeys pattern of any policy that applies to the namespace.
+// Otherwise it is allowed, unless a policy that applies to the namespace has allowedKeys and the key
+// does not match any of them. Namespaces no policy applies to are not restricted.
+// Keys are matched without a leading slash and duplicate slashes, keys with "." or ".." segments are denied.
+// Patterns are regular expressions that must match the whole key, {{ .Namespace }} is replaced by the namespace.
+type ClusterSecretStoreAccessPolicy struct {
+	// Choose the namespaces the policy applies to like the namespaces of conditions.
+	// A policy that does not choose any namespaces applies to all namespaces.
+	ClusterSecretStoreCondition `json:",inline"`
+
+	// Patterns of the remote keys the namespaces may access.
+	// +optional
+	AllowedKeys []string `json:"allowedKeys,omitempty"`
+
+	// Patterns of the remote keys the namespaces must not access, they take precedence over allowedKeys.
+	// +optional
+	DeniedKeys []string `json:"deniedKeys,omitempty"`
+}
+
 // SecretStoreProvider contains the provider-specific configuration.
 // +kubebuilder:validation:MinProperties=1
 // +kubebuilder:validation:MaxProperties=1
@@ -116,6 +148,10 @@ type SecretStoreProvider struct {
 	// +optional
 	Gitlab *GitlabProvider `json:"gitlab,omitempty"`
 
+	// Git configures this store to sync secrets from SOPS-encrypted files of a Git repository
+	// +optional
+	Git *GitProvider `json:"git,omitempty"`
+
 	// OnePassword configures this store to sync secrets using the 1Password Cloud provider
 	// +optional
 	OnePassword *OnePasswordProvider `json:"onepassword,omitempty"`
@@ -219,6 +255,11 @@ type SecretStoreProvider struct {
 	// Barbican configures this store to sync secrets using the OpenStack Barbican provider
 	// +optional
 	Barbican *BarbicanProvider `json:"barbican,omitempty"`
+
+	// Plugin configures this store to sync secrets using a provider plugin
+	// that runs in a separate process and is called over gRPC.
+	// +optional
+	Plugin *PluginProvider `json:"plugin,omitempty"`
 }
 
 // CAProviderType defines the type of provider for certificate authority.
@@ -33,6 +33,8 @@ const (
 	errInvalidStore       = "invalid store"
 	warnStoreUnmaintained = "store %s isn't currently maintained. Please plan and prepare accordingly."
 	warnStoreDeprecated   = "store %s is deprecated and will stop working on the next major version. Please plan and prepare accordingly."
+
+	warnAccessPoliciesIgnored = "spec.accessPolicies is only enforced for ClusterSecretStores"
 )
 
 // GenericStoreValidator implements webhook validation for SecretStore and ClusterSecretStore resources.
@@ -65,6 +67,9 @@ func validateStore(store GenericStore) (admission.Warnings, error) {
 	if err := validateConditions(store); err != nil {
 		return nil, err
 	}
+	if err := validateAccessPolicies(store); err != nil {
+		return nil, err
+	}
 
 	provider, err := GetProvider(store)
 	if err != nil {
@@ -75,6 +80,9 @@ func validateStore(store GenericStore) (admission.Warnings, error) {
 		return nil, err
 	}
 	warns, err := provider.ValidateStore(store)
+	if store.GetKind() != ClusterSecretStoreKind && len(store.GetSpec().AccessPolicies) > 0 {
+		warns = append(warns, warnAccessPoliciesIgnored)
+	}
 	switch status {
 	case MaintenanceStatusNotMaintained:
 		warns = append(warns, fmt.Sprintf(warnStoreUnmaintained, store.GetName()))
@@ -0,0 +1,49 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"sync"
+)
+
+// TemplateValidateFunc checks that a template of the given scope compiles.
+// +kubebuilder:object:generate=false
+type TemplateValidateFunc func(tpl string, scope TemplateScope) error
+
+var templateValidators = make(map[TemplateEngineVersion]TemplateValidateFunc)
+var templateValidatorsLock sync.RWMutex
+
+// RegisterTemplateValidator registers the compile check of a template engine
+// version, which is used by the ExternalSecret webhook. RegisterTemplateValidator
+// panics if a check for the version is already registered.
+func RegisterTemplateValidator(version TemplateEngineVersion, fn TemplateValidateFunc) {
+	templateValidatorsLock.Lock()
+	defer templateValidatorsLock.Unlock()
+	if _, exists := templateValidators[version]; exists {
+		panic(fmt.Sprintf("template validator %q already registered", version))
+	}
+	templateValidators[version] = fn
+}
+
+// GetTemplateValidator returns the compile check of a template engine version.
+func GetTemplateValidator(version TemplateEngineVersion) (TemplateValidateFunc, bool) {
+	templateValidatorsLock.RLock()
+	defer templateValidatorsLock.RUnlock()
+	fn, ok := templateValidators[version]
+	return fn, ok
+}
@@ -22,6 +22,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 // PushSecret condition reasons.
@@ -30,6 +31,8 @@ const (
 	ReasonSynced = "Synced"
 	// ReasonErrored indicates that the push secret encountered an error during sync.
 	ReasonErrored = "Errored"
+	// ReasonDryRun indicates that the changes to the providers have been planned without pushing.
+	ReasonDryRun = "DryRun"
 )
 
 // PushSecretStoreRef contains a reference on how to sync to a SecretStore.
@@ -85,12 +88,48 @@ const (
 	PushSecretConversionReverseUnicode PushSecretConversionStrategy = "ReverseUnicode"
 )
 
+// PushSecretEncodingStrategy defines how secret values are encoded before they are pushed to providers.
+// It uses the same names as the decoding strategies of an ExternalSecret, so a value pushed with
+// `Base64+Gzip` can be read back with the `Base64+Gzip` decoding strategy. Chained strategies
+// are applied from right to left, e.g. `Base64+Gzip` compresses the value and then encodes it using Base64.
+// +kubebuilder:validation:Enum=None;Base64;Base64URL;Hex;Gzip;Zlib;Base64+Gzip;Base64+Zlib
+type PushSecretEncodingStrategy string
+
+const (
+	// PushSecretEncodeNone indicates that the secret value is pushed as-is.
+	PushSecretEncodeNone PushSecretEncodingStrategy = "None"
+	// PushSecretEncodeBase64 indicates that the secret value is encoded using Base64.
+	PushSecretEncodeBase64 PushSecretEncodingStrategy = "Base64"
+	// PushSecretEncodeBase64URL indicates that the secret value is encoded using Base64URL.
+	PushSecretEncodeBase64URL PushSecretEncodingStrategy = "Base64URL"
+	// PushSecretEncodeHex indicates that the secret value is encoded using hex.
+	PushSecretEncodeHex PushSecretEncodingStrategy = "Hex"
+	// PushSecretEncodeGzip indicates that the secret value is compressed using gzip.
+	PushSecretEncodeGzip PushSecretEncodingStrategy = "Gzip"
+	// PushSecretEncodeZlib indicates that the secret value is compressed using zlib.
+	PushSecretEncodeZlib PushSecretEncodingStrategy = "Zlib"
+	// PushSecretEncodeBase64Gzip indicates that the secret value is compressed using gzip and then encoded using Base64.
+	PushSecretEncodeBase64Gzip PushSecretEncodingStrategy = "Base64+Gzip"
+	// PushSecretEncodeBase64Zlib indicates that the secret value is compressed using zlib and then encoded using Base64.
+	PushSecretEncodeBase64Zlib PushSecretEncodingStrategy = "Base64+Zlib"
+)
+
 // PushSecretSpec configures the behavior of the PushSecret.
 type PushSecretSpec struct {
 	// The Interval to which External Secrets will try to push a secret definition
 	// +kubebuilder:default="1h0m0s"
 	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 
+	// RefreshSchedule pushes the secrets at the times of a cron schedule
+	// instead of after the RefreshInterval.
+	// +optional
+	RefreshSchedule *esv1.RefreshSchedule `json:"refreshSchedule,omitempty"`
+
+	// SyncWindows restrict the times at which secrets that have been pushed before are updated.
+	// Pushes outside of the windows are deferred until a window allows them.
+	// +optional
+	SyncWindows []esv1.SyncWindow `json:"syncWindows,omitempty"`
+
 	SecretStoreRefs []PushSecretStoreRef `json:"secretStoreRefs"`
 
 	// UpdatePolicy to handle Secrets in the provider.
@@ -112,6 +151,11 @@ type PushSecretSpec struct {
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
 	Template *esv1.ExternalSecretTemplate `json:"template,omitempty"`
+
+	// DryRun resolves and templates the secrets without pushing them to the providers.
+	// The changes that would be made to the providers are recorded in status.plan.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // PushSecretSecret defines a Secret that will be used as a source for pushing to providers.
@@ -183,6 +227,35 @@ type PushSecretData struct {
 	// Used to define a conversion Strategy for the secret keys
 	// +kubebuilder:default="None"
 	ConversionStrategy PushSecretConversionStrategy `json:"conversionStrategy,omitempty"`
+	// +optional
+	// Used to define an encoding Strategy for the secret values
+	// +kubebuilder:default="None"
+	EncodingStrategy PushSecretEncodingStrategy `json:"encodingStrategy,omitempty"`
+	// +optional
+	// Used to encrypt the secret values before the encoding Strategy is applied
+	Encryption *PushSecretEncryption `json:"encryption,omitempty"`
+}
+
+// PushSecretEncryptionMethod defines the method used to encrypt secret values before they are pushed.
+// +kubebuilder:validation:Enum=Age;PGP
+type PushSecretEncryptionMethod string
+
+const (
+	// PushSecretEncryptionAge indicates that the secret value is encrypted using age.
+	PushSecretEncryptionAge PushSecretEncryptionMethod = "Age"
+	// PushSecretEncryptionPGP indicates that the secret value is encrypted using OpenPGP.
+	PushSecretEncryptionPGP PushSecretEncryptionMethod = "PGP"
+)
+
+// PushSecretEncryption defines how a secret value is encrypted before it is pushed to the provider.
+// The value is stored as armored ciphertext.
+type PushSecretEncryption struct {
+	// Method used to encrypt the value.
+	Method PushSecretEncryptionMethod `json:"method"`
+
+	// RecipientsSecretRef references the age recipients or the OpenPGP public keys
+	// used to encrypt the value. The Secret must be in the namespace of the PushSecret.
+	RecipientsSecretRef esmeta.SecretKeySelector `json:"recipientsSecretRef"`
 }
 
 // GetMetadata returns the metadata of the PushSecretData.
@@ -239,6 +312,11 @@ type PushSecretStatus struct {
 	// the target secret updated
 	RefreshTime metav1.Time `json:"refreshTime,omitempty"`
 
+	// NextRefreshTime is the time of the next scheduled push,
+	// set if a refreshSchedule or syncWindows are used.
+	// +optional
+	NextRefreshTime *metav1.Time `json:"nextRefreshTime,omitempty"`
+
 	// SyncedResourceVersion keeps track of the last synced version.
 	SyncedResourceVersion string `json:"syncedResourceVersion,omitempty"`
 	// Synced PushSecrets, including secrets that already exist in provider.
@@ -247,6 +325,44 @@ type PushSecretStatus struct {
 	SyncedPushSecrets SyncedPushSecretsMap `json:"syncedPushSecrets,omitempty"`
 	// +optional
 	Conditions []PushSecretStatusCondition `json:"conditions,omitempty"`
+
+	// Plan lists the changes a dry run would make to the providers.
+	// Only set if spec.dryRun is enabled.
+	// +optional
+	Plan *PushSecretPlan `json:"plan,omitempty"`
+}
+
+// PushSecretPlanAction is the change a dry run would make to a secret of a provider.
+// +kubebuilder:validation:Enum=Create;Update;CreateOrUpdate;Delete
+type PushSecretPlanAction string
+
+const (
+	// PlanActionCreate is used for remote secrets that do not exist and would be created.
+	PlanActionCreate PushSecretPlanAction = "Create"
+	// PlanActionUpdate is used for remote secrets that exist and would be updated.
+	PlanActionUpdate PushSecretPlanAction = "Update"
+	// PlanActionCreateOrUpdate is used if the provider can not tell whether the remote secret exists.
+	PlanActionCreateOrUpdate PushSecretPlanAction = "CreateOrUpdate"
+	// PlanActionDelete is used for remote secrets that would be deleted.
+	PlanActionDelete PushSecretPlanAction = "Delete"
+)
+
+// PushSecretPlan is the result of a dry run of a PushSecret.
+type PushSecretPlan struct {
+	// Entries are the remote secrets that would be created, updated or deleted.
+	// +optional
+	Entries []PushSecretPlanEntry `json:"entries,omitempty"`
+}
+
+// PushSecretPlanEntry is a remote secret that would be changed by a dry run.
+type PushSecretPlanEntry struct {
+	// Store is the kind and name of the secret store, e.g. `SecretStore/vault`.
+	Store string `json:"store"`
+
+	PushSecretRemoteRef `json:",inline"`
+
+	// Action is the change that would be made to the remote secret.
+	Action PushSecretPlanAction `json:"action"`
 }
 
 // +kubebuilder:object:root=true
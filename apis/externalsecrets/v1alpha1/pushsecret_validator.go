@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+// Ensures PushSecretValidator implements the admission.CustomValidator interface correctly.
+var _ admission.CustomValidator = &PushSecretValidator{}
+
+// PushSecretValidator implements a validating webhook for PushSecrets.
+// +kubebuilder:object:generate=false
+type PushSecretValidator struct {
+	// Reader reads the ClusterSecretStores and namespaces to check the remote keys
+	// against the access policies of the stores. The check is skipped if Reader is nil.
+	Reader client.Reader
+}
+
+// ValidateCreate is called on creation of PushSecret resource object.
+func (psv *PushSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return psv.validate(ctx, obj)
+}
+
+// ValidateUpdate is called when updating a PushSecret resource object.
+func (psv *PushSecretValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return psv.validate(ctx, newObj)
+}
+
+// ValidateDelete is called when deleting a PushSecret resource object.
+func (psv *PushSecretValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (psv *PushSecretValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ps, ok := obj.(*PushSecret)
+	if !ok {
+		return nil, errors.New("unexpected type")
+	}
+	if psv.Reader == nil {
+		return nil, nil
+	}
+	return validateRemoteKeyAccess(ctx, psv.Reader, ps)
+}
+
+// validateRemoteKeyAccess checks the remote keys the PushSecret writes to ClusterSecretStores
+// against the access policies of the stores. Stores chosen by a label selector are listed,
+// if they can not be listed the PushSecret is admitted with a warning.
+func validateRemoteKeyAccess(ctx context.Context, reader client.Reader, ps *PushSecret) (admission.Warnings, error) {
+	var warns admission.Warnings
+	var stores []string
+	for i, storeRef := range ps.Spec.SecretStoreRefs {
+		if storeRef.Kind != esv1.ClusterSecretStoreKind {
+			continue
+		}
+		if storeRef.LabelSelector == nil {
+			stores = append(stores, storeRef.Name)
+			continue
+		}
+		selected, err := selectClusterSecretStores(ctx, reader, storeRef.LabelSelector)
+		if err != nil {
+			warns = append(warns, fmt.Sprintf("unable to check the access policies of the ClusterSecretStores of spec.secretStoreRefs[%d]: %v", i, err))
+			continue
+		}
+		stores = append(stores, selected...)
+	}
+	slices.Sort(stores)
+
+	var refs []esv1.RemoteKeyRef
+	for _, store := range slices.Compact(stores) {
+		for i, data := range ps.Spec.Data {
+			refs = append(refs, esv1.RemoteKeyRef{
+				Field:    fmt.Sprintf("data[%d].match.remoteRef.remoteKey", i),
+				Key:      data.GetRemoteKey(),
+				StoreRef: esv1.SecretStoreRef{Name: store, Kind: esv1.ClusterSecretStoreKind},
+			})
+		}
+	}
+	policyWarns, err := esv1.ValidateRemoteKeyAccess(ctx, reader, ps.Namespace, refs)
+	return append(warns, policyWarns...), err
+}
+
+// selectClusterSecretStores returns the names of the ClusterSecretStores that match the selector.
+func selectClusterSecretStores(ctx context.Context, reader client.Reader, selector *metav1.LabelSelector) ([]string, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	var list esv1.ClusterSecretStoreList
+	if err := reader.List(ctx, &list, client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for i := range list.Items {
+		names = append(names, list.Items[i].Name)
+	}
+	return names, nil
+}
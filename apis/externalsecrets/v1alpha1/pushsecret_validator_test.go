@@ -0,0 +1,100 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+func TestPushSecretValidateRemoteKeyAccess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, esv1.AddToScheme(scheme))
+	restricted := esv1.SecretStoreSpec{AccessPolicies: []esv1.ClusterSecretStoreAccessPolicy{{
+		AllowedKeys: []string{`{{ .Namespace }}/.*`},
+	}}}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&esv1.ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: "restricted"}, Spec: restricted},
+		&esv1.ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: "labeled", Labels: map[string]string{"tier": "shared"}}, Spec: restricted},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+	).Build()
+	validator := &PushSecretValidator{Reader: reader}
+
+	newPushSecret := func(storeRef PushSecretStoreRef, remoteKeys ...string) *PushSecret {
+		ps := &PushSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "team-a"},
+			Spec:       PushSecretSpec{SecretStoreRefs: []PushSecretStoreRef{storeRef}},
+		}
+		for _, key := range remoteKeys {
+			ps.Spec.Data = append(ps.Spec.Data, PushSecretData{Match: PushSecretMatch{RemoteRef: PushSecretRemoteRef{RemoteKey: key}}})
+		}
+		return ps
+	}
+
+	tests := []struct {
+		name    string
+		ps      *PushSecret
+		wantErr string
+	}{
+		{
+			name: "allowed key",
+			ps:   newPushSecret(PushSecretStoreRef{Name: "restricted", Kind: esv1.ClusterSecretStoreKind}, "team-a/db"),
+		},
+		{
+			name:    "denied key",
+			ps:      newPushSecret(PushSecretStoreRef{Name: "restricted", Kind: esv1.ClusterSecretStoreKind}, "team-a/db", "team-b/db"),
+			wantErr: `data[1].match.remoteRef.remoteKey: access to remote key "team-b/db" is denied for namespace "team-a" by spec.accessPolicies of ClusterSecretStore "restricted"`,
+		},
+		{
+			name: "denied key of a store chosen by labels",
+			ps: newPushSecret(PushSecretStoreRef{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "shared"}},
+				Kind:          esv1.ClusterSecretStoreKind,
+			}, "team-b/db"),
+			wantErr: `data[0].match.remoteRef.remoteKey: access to remote key "team-b/db" is denied for namespace "team-a" by spec.accessPolicies of ClusterSecretStore "labeled"`,
+		},
+		{
+			name: "SecretStores are not checked",
+			ps:   newPushSecret(PushSecretStoreRef{Name: "restricted", Kind: esv1.SecretStoreKind}, "team-b/db"),
+		},
+		{
+			name: "missing store",
+			ps:   newPushSecret(PushSecretStoreRef{Name: "missing", Kind: esv1.ClusterSecretStoreKind}, "team-b/db"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warns, err := validator.ValidateCreate(context.Background(), tt.ps)
+			assert.Empty(t, warns)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -24,7 +24,7 @@ import (
 	externalsecretsv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -227,6 +227,11 @@ func (in *PushSecretData) DeepCopyInto(out *PushSecretData) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(PushSecretEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushSecretData.
@@ -239,6 +244,22 @@ func (in *PushSecretData) DeepCopy() *PushSecretData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushSecretEncryption) DeepCopyInto(out *PushSecretEncryption) {
+	*out = *in
+	in.RecipientsSecretRef.DeepCopyInto(&out.RecipientsSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushSecretEncryption.
+func (in *PushSecretEncryption) DeepCopy() *PushSecretEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(PushSecretEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PushSecretList) DeepCopyInto(out *PushSecretList) {
 	*out = *in
@@ -316,6 +337,42 @@ func (in *PushSecretMetadata) DeepCopy() *PushSecretMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushSecretPlan) DeepCopyInto(out *PushSecretPlan) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]PushSecretPlanEntry, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushSecretPlan.
+func (in *PushSecretPlan) DeepCopy() *PushSecretPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(PushSecretPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushSecretPlanEntry) DeepCopyInto(out *PushSecretPlanEntry) {
+	*out = *in
+	out.PushSecretRemoteRef = in.PushSecretRemoteRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushSecretPlanEntry.
+func (in *PushSecretPlanEntry) DeepCopy() *PushSecretPlanEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(PushSecretPlanEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PushSecretRemoteRef) DeepCopyInto(out *PushSecretRemoteRef) {
 	*out = *in
@@ -384,6 +441,16 @@ func (in *PushSecretSpec) DeepCopyInto(out *PushSecretSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.RefreshSchedule != nil {
+		in, out := &in.RefreshSchedule, &out.RefreshSchedule
+		*out = new(externalsecretsv1.RefreshSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncWindows != nil {
+		in, out := &in.SyncWindows, &out.SyncWindows
+		*out = make([]externalsecretsv1.SyncWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.SecretStoreRefs != nil {
 		in, out := &in.SecretStoreRefs, &out.SecretStoreRefs
 		*out = make([]PushSecretStoreRef, len(*in))
@@ -420,6 +487,10 @@ func (in *PushSecretSpec) DeepCopy() *PushSecretSpec {
 func (in *PushSecretStatus) DeepCopyInto(out *PushSecretStatus) {
 	*out = *in
 	in.RefreshTime.DeepCopyInto(&out.RefreshTime)
+	if in.NextRefreshTime != nil {
+		in, out := &in.NextRefreshTime, &out.NextRefreshTime
+		*out = (*in).DeepCopy()
+	}
 	if in.SyncedPushSecrets != nil {
 		in, out := &in.SyncedPushSecrets, &out.SyncedPushSecrets
 		*out = make(SyncedPushSecretsMap, len(*in))
@@ -445,6 +516,11 @@ func (in *PushSecretStatus) DeepCopyInto(out *PushSecretStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(PushSecretPlan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushSecretStatus.
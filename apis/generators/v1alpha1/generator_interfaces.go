@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"time"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -53,5 +54,40 @@ type Generator interface {
 	) error
 }
 
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// StatefulGenerator is an optional interface for generators that need
+// the state produced by their previous run, e.g. to carry over previous
+// keys during rotation.
+type StatefulGenerator interface {
+	// GenerateWithState behaves like Generate, but additionally receives the
+	// state returned by the previous call. The previous state is nil
+	// on the first run or if generator state is disabled.
+	GenerateWithState(
+		ctx context.Context,
+		obj *apiextensions.JSON,
+		previous GeneratorProviderState,
+		kube client.Client,
+		namespace string,
+	) (map[string][]byte, GeneratorProviderState, error)
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// ExpiringGenerator is an optional interface for generators that produce
+// short-lived values. The controller refreshes the values before they expire,
+// even if the refresh interval of the referencing resource is longer.
+type ExpiringGenerator interface {
+	// ExpiresAt returns the time the values returned by Generate expire.
+	// It returns false if the values do not expire.
+	ExpiresAt(data map[string][]byte) (time.Time, bool)
+}
+
 // GeneratorProviderState represents the state of a generator provider that can be stored and retrieved.
 type GeneratorProviderState *apiextensions.JSON
@@ -73,6 +73,14 @@ var (
 	ClusterGeneratorKind = reflect.TypeOf(ClusterGenerator{}).Name()
 	// CloudsmithAccessTokenKind is the kind name for CloudsmithAccessToken resource.
 	CloudsmithAccessTokenKind = reflect.TypeOf(CloudsmithAccessToken{}).Name()
+	// CryptoKeyKind is the kind name for CryptoKey resource.
+	CryptoKeyKind = reflect.TypeOf(CryptoKey{}).Name()
+	// GeneratorPipelineKind is the kind name for GeneratorPipeline resource.
+	GeneratorPipelineKind = reflect.TypeOf(GeneratorPipeline{}).Name()
+	// ServiceAccountTokenKind is the kind name for ServiceAccountToken resource.
+	ServiceAccountTokenKind = reflect.TypeOf(ServiceAccountToken{}).Name()
+	// HarborRobotAccountKind is the kind name for HarborRobotAccount resource.
+	HarborRobotAccountKind = reflect.TypeOf(HarborRobotAccount{}).Name()
 )
 
 func init() {
@@ -109,4 +117,8 @@ func init() {
 	SchemeBuilder.Register(&Webhook{}, &WebhookList{})
 	SchemeBuilder.Register(&Grafana{}, &GrafanaList{})
 	SchemeBuilder.Register(&MFA{}, &MFAList{})
+	SchemeBuilder.Register(&CryptoKey{}, &CryptoKeyList{})
+	SchemeBuilder.Register(&GeneratorPipeline{}, &GeneratorPipelineList{})
+	SchemeBuilder.Register(&ServiceAccountToken{}, &ServiceAccountTokenList{})
+	SchemeBuilder.Register(&HarborRobotAccount{}, &HarborRobotAccountList{})
 }
@@ -30,7 +30,7 @@ type ClusterGeneratorSpec struct {
 }
 
 // GeneratorKind represents a kind of generator.
-// +kubebuilder:validation:Enum=ACRAccessToken;CloudsmithAccessToken;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;QuayAccessToken;Password;SSHKey;STSSessionToken;UUID;VaultDynamicSecret;Webhook;Grafana
+// +kubebuilder:validation:Enum=ACRAccessToken;CloudsmithAccessToken;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;QuayAccessToken;Password;SSHKey;STSSessionToken;UUID;VaultDynamicSecret;Webhook;Grafana;CryptoKey;GeneratorPipeline;ServiceAccountToken;HarborRobotAccount
 type GeneratorKind string
 
 const (
@@ -64,6 +64,14 @@ const (
 	GeneratorKindMFA GeneratorKind = "MFA"
 	// GeneratorKindCloudsmithAccessToken represents a Cloudsmith access token generator.
 	GeneratorKindCloudsmithAccessToken GeneratorKind = "CloudsmithAccessToken"
+	// GeneratorKindCryptoKey represents a symmetric key and key pair generator.
+	GeneratorKindCryptoKey GeneratorKind = "CryptoKey"
+	// GeneratorKindGeneratorPipeline represents a generator pipeline.
+	GeneratorKindGeneratorPipeline GeneratorKind = "GeneratorPipeline"
+	// GeneratorKindServiceAccountToken represents a Kubernetes ServiceAccount token generator.
+	GeneratorKindServiceAccountToken GeneratorKind = "ServiceAccountToken"
+	// GeneratorKindHarborRobotAccount represents a Harbor robot account generator.
+	GeneratorKindHarborRobotAccount GeneratorKind = "HarborRobotAccount"
 )
 
 // GeneratorSpec defines the configuration for various supported generator types.
@@ -85,6 +93,10 @@ type GeneratorSpec struct {
 	WebhookSpec               *WebhookSpec               `json:"webhookSpec,omitempty"`
 	GrafanaSpec               *GrafanaSpec               `json:"grafanaSpec,omitempty"`
 	MFASpec                   *MFASpec                   `json:"mfaSpec,omitempty"`
+	CryptoKeySpec             *CryptoKeySpec             `json:"cryptoKeySpec,omitempty"`
+	GeneratorPipelineSpec     *GeneratorPipelineSpec     `json:"generatorPipelineSpec,omitempty"`
+	ServiceAccountTokenSpec   *ServiceAccountTokenSpec   `json:"serviceAccountTokenSpec,omitempty"`
+	HarborRobotAccountSpec    *HarborRobotAccountSpec    `json:"harborRobotAccountSpec,omitempty"`
 }
 
 // ClusterGenerator represents a cluster-wide generator which can be referenced as part of `generatorRef` fields.
@@ -0,0 +1,130 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CryptoKeyType is the type of key generated by the CryptoKey generator.
+// +kubebuilder:validation:Enum=AES-128;AES-256;HMAC-SHA256;HMAC-SHA512;Fernet;Ed25519;ECDSA;RSA
+type CryptoKeyType string
+
+const (
+	// CryptoKeyTypeAES128 generates a 128 bit AES key.
+	CryptoKeyTypeAES128 CryptoKeyType = "AES-128"
+	// CryptoKeyTypeAES256 generates a 256 bit AES key.
+	CryptoKeyTypeAES256 CryptoKeyType = "AES-256"
+	// CryptoKeyTypeHMACSHA256 generates a 256 bit key for HMAC-SHA256.
+	CryptoKeyTypeHMACSHA256 CryptoKeyType = "HMAC-SHA256"
+	// CryptoKeyTypeHMACSHA512 generates a 512 bit key for HMAC-SHA512.
+	CryptoKeyTypeHMACSHA512 CryptoKeyType = "HMAC-SHA512"
+	// CryptoKeyTypeFernet generates a Fernet key as used by e.g. Django or python cryptography.
+	CryptoKeyTypeFernet CryptoKeyType = "Fernet"
+	// CryptoKeyTypeEd25519 generates an Ed25519 key pair.
+	CryptoKeyTypeEd25519 CryptoKeyType = "Ed25519"
+	// CryptoKeyTypeECDSA generates an ECDSA key pair.
+	CryptoKeyTypeECDSA CryptoKeyType = "ECDSA"
+	// CryptoKeyTypeRSA generates an RSA key pair.
+	CryptoKeyTypeRSA CryptoKeyType = "RSA"
+)
+
+// CryptoKeyEncoding is the encoding of the keys generated by the CryptoKey generator.
+// +kubebuilder:validation:Enum=raw;base64;hex;PEM;JWK;JWKS
+type CryptoKeyEncoding string
+
+const (
+	// CryptoKeyEncodingRaw emits the key bytes as is.
+	// Key pairs are emitted as PKCS#8 (private) and PKIX (public) DER.
+	CryptoKeyEncodingRaw CryptoKeyEncoding = "raw"
+	// CryptoKeyEncodingBase64 emits the raw key bytes base64 encoded.
+	CryptoKeyEncodingBase64 CryptoKeyEncoding = "base64"
+	// CryptoKeyEncodingHex emits the raw key bytes hex encoded.
+	CryptoKeyEncodingHex CryptoKeyEncoding = "hex"
+	// CryptoKeyEncodingPEM emits key pairs PEM encoded. Not supported for symmetric keys.
+	CryptoKeyEncodingPEM CryptoKeyEncoding = "PEM"
+	// CryptoKeyEncodingJWK emits the keys as JSON Web Keys.
+	CryptoKeyEncodingJWK CryptoKeyEncoding = "JWK"
+	// CryptoKeyEncodingJWKS emits the keys as JSON Web Keys plus a JSON Web Key Set.
+	CryptoKeyEncodingJWKS CryptoKeyEncoding = "JWKS"
+)
+
+// CryptoKeySpec controls the behavior of the crypto key generator.
+type CryptoKeySpec struct {
+	// Type specifies the type of key to generate.
+	// Symmetric key types (AES-*, HMAC-*, Fernet) emit a single `key`,
+	// asymmetric key types (Ed25519, ECDSA, RSA) emit a `privateKey` and a `publicKey`.
+	Type CryptoKeyType `json:"type"`
+
+	// KeySize specifies the key size for RSA keys (default: 2048) and the curve for ECDSA keys (default: 256).
+	// For RSA keys: 2048, 3072, 4096
+	// For ECDSA keys: 256, 384, 521
+	// Ignored for all other key types.
+	// +optional
+	// +kubebuilder:validation:Enum=256;384;521;2048;3072;4096
+	KeySize *int `json:"keySize,omitempty"`
+
+	// Encoding specifies the encoding of the generated keys.
+	// Fernet keys are always emitted in their url-safe base64 form and only support `raw`.
+	// When `JWKS` is used, a JSON Web Key Set is emitted as `jwks` in addition to the key(s).
+	// +optional
+	// +kubebuilder:default="raw"
+	Encoding CryptoKeyEncoding `json:"encoding,omitempty"`
+
+	// KeySetSize is the number of keys kept in the emitted JSON Web Key Set, including
+	// the newly generated one. Previous keys are taken from the generator state, so
+	// that verification keys overlap during rotation.
+	// Requires the `JWKS` encoding and an asymmetric key type, the set contains the public keys only.
+	// Symmetric key types only support a keySetSize of 1, as the previous keys would have
+	// to be stored in the generator state.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	KeySetSize int `json:"keySetSize,omitempty"`
+}
+
+// CryptoKeyState is the state type produced by the CryptoKey generator.
+// It is only produced when keySetSize is greater than one and contains
+// the key set which was emitted, so it can be carried over on rotation.
+type CryptoKeyState struct {
+	// KeySet is the serialized JSON Web Key Set, newest key first.
+	KeySet string `json:"keySet"`
+}
+
+// CryptoKey generates symmetric keys and key pairs for
+// encryption and signing, optionally encoded as JSON Web Keys.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type CryptoKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CryptoKeySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CryptoKeyList contains a list of CryptoKey resources.
+type CryptoKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CryptoKey `json:"items"`
+}
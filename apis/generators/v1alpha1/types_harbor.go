@@ -0,0 +1,117 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HarborRobotAccountSpec controls the behavior of the Harbor robot account generator.
+type HarborRobotAccountSpec struct {
+	// URL is the URL of the Harbor instance, e.g. https://harbor.example.com.
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle used to validate the certificate of the Harbor instance.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Auth is the authentication configuration to authenticate
+	// against the Harbor instance.
+	Auth HarborAuth `json:"auth"`
+
+	// Project is the name of the Harbor project the robot account is created in.
+	// +kubebuilder:validation:MinLength:=1
+	Project string `json:"project"`
+
+	// NamePrefix is the prefix of the robot account name.
+	// A random suffix is appended, so every generated robot account is unique.
+	// +optional
+	// +kubebuilder:default="eso"
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-._a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength:=64
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// Description of the robot account.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Duration of the robot account in days. Use -1 for robot accounts that never expire.
+	// +optional
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=-1
+	Duration int64 `json:"duration,omitempty"`
+
+	// Permissions of the robot account within the project.
+	// +kubebuilder:validation:MinItems=1
+	Permissions []HarborRobotPermission `json:"permissions"`
+}
+
+// HarborAuth defines the authentication methods for connecting to a Harbor instance.
+type HarborAuth struct {
+	// Basic auth credentials used to authenticate against the Harbor instance.
+	// Note: the user needs permissions to create and delete robot accounts in the project.
+	Basic HarborBasicAuth `json:"basic"`
+}
+
+// HarborBasicAuth defines the credentials for basic authentication with Harbor.
+type HarborBasicAuth struct {
+	// A basic auth username used to authenticate against the Harbor instance.
+	Username string `json:"username"`
+	// A basic auth password used to authenticate against the Harbor instance.
+	Password SecretKeySelector `json:"password"`
+}
+
+// HarborRobotPermission grants an action on a resource of the project to the robot account.
+type HarborRobotPermission struct {
+	// Resource is the project resource, e.g. repository, artifact or tag.
+	// +kubebuilder:validation:MinLength:=1
+	Resource string `json:"resource"`
+	// Action on the resource, e.g. pull, push, delete, list or read.
+	// +kubebuilder:validation:MinLength:=1
+	Action string `json:"action"`
+}
+
+// HarborRobotAccountState is the state type produced by the Harbor robot account generator.
+// It contains the ID of the robot account, which is deleted on cleanup, and the URL of the
+// Harbor instance it was created in, so that cleanup does not depend on a later spec.
+type HarborRobotAccountState struct {
+	RobotID int64  `json:"robotID"`
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+}
+
+// HarborRobotAccount generates project-scoped Harbor robot accounts for pulling/pushing images.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type HarborRobotAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HarborRobotAccountSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HarborRobotAccountList contains a list of HarborRobotAccount resources.
+type HarborRobotAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HarborRobotAccount `json:"items"`
+}
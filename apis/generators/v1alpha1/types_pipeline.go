@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GeneratorPipelineSpec controls the behavior of the generator pipeline.
+type GeneratorPipelineSpec struct {
+	// Steps are executed in the given order. The outputs of all steps are merged,
+	// outputs of later steps take precedence.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Steps []GeneratorPipelineStep `json:"steps"`
+}
+
+// GeneratorPipelineStep is a single generator invocation of a pipeline.
+type GeneratorPipelineStep struct {
+	// Name of the step. Later steps can reference the output of this step
+	// in their spec with `{{ .<name>.<key> }}`.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern:=^[a-zA-Z][a-zA-Z0-9_]*$
+	Name string `json:"name"`
+
+	// GeneratorRef points to the generator used in this step.
+	GeneratorRef GeneratorPipelineStepRef `json:"generatorRef"`
+
+	// Spec is merged into the spec of the referenced generator.
+	// String values are rendered as templates with the outputs of the previous steps.
+	// +optional
+	Spec *apiextensions.JSON `json:"spec,omitempty"`
+}
+
+// GeneratorPipelineStepRef points to a generator in the namespace of the pipeline invocation.
+type GeneratorPipelineStepRef struct {
+	// Specify the apiVersion of the generator resource
+	// +kubebuilder:default="generators.external-secrets.io/v1alpha1"
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Specify the Kind of the generator resource. Pipelines can not be nested.
+	// +kubebuilder:validation:Enum=ACRAccessToken;ClusterGenerator;CloudsmithAccessToken;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;QuayAccessToken;Password;SSHKey;STSSessionToken;UUID;VaultDynamicSecret;Webhook;Grafana;MFA;CryptoKey;ServiceAccountToken;HarborRobotAccount
+	Kind string `json:"kind"`
+
+	// Specify the name of the generator resource
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	Name string `json:"name"`
+}
+
+// GeneratorPipelineState is the state type produced by the GeneratorPipeline generator.
+// It contains the state of every step, so all steps are cleaned up together.
+type GeneratorPipelineState struct {
+	Steps []GeneratorPipelineStepState `json:"steps,omitempty"`
+}
+
+// GeneratorPipelineStepState is the state produced by a single step.
+type GeneratorPipelineStepState struct {
+	// Name of the step.
+	Name string `json:"name"`
+	// Kind of the generator which produced the state.
+	Kind string `json:"kind"`
+	// Resource is the generator manifest of the step with the static values of the step spec,
+	// it is used to clean up the step. Templated values of the step spec are left out,
+	// so that values generated by previous steps are not stored in the state.
+	Resource *apiextensions.JSON `json:"resource"`
+	// State is the state produced by the generator of the step.
+	// +optional
+	State *apiextensions.JSON `json:"state,omitempty"`
+}
+
+// GeneratorPipeline runs several generators in order and
+// feeds the output of each step into the spec of the following steps.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type GeneratorPipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GeneratorPipelineSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GeneratorPipelineList contains a list of GeneratorPipeline resources.
+type GeneratorPipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GeneratorPipeline `json:"items"`
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+// ServiceAccountTokenSpec controls the behavior of the ServiceAccount token generator.
+type ServiceAccountTokenSpec struct {
+	// ServiceAccountName is the name of the ServiceAccount to request a token for.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// Audiences are the intended audiences of the token.
+	// Defaults to the audiences of the API server.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// ExpirationSeconds is the requested validity of the token.
+	// The API server may return a token with a shorter validity.
+	// The ExternalSecret is refreshed before the token expires.
+	// +optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+
+	// BoundObjectRef binds the token to the lifetime of a Pod or Secret.
+	// The object must live in the namespace of the ServiceAccount.
+	// +optional
+	BoundObjectRef *ServiceAccountTokenBoundObjectRef `json:"boundObjectRef,omitempty"`
+
+	// Provider configures the cluster to request the token from.
+	// If not set, the token is requested from the cluster the controller runs in,
+	// for a ServiceAccount in the namespace of the ExternalSecret.
+	// Otherwise, the token is requested for a ServiceAccount in `remoteNamespace`.
+	// +optional
+	Provider *esv1.KubernetesProvider `json:"provider,omitempty"`
+}
+
+// ServiceAccountTokenBoundObjectRef references an object the token is bound to.
+type ServiceAccountTokenBoundObjectRef struct {
+	// Kind of the referenced object.
+	// +kubebuilder:validation:Enum=Pod;Secret
+	Kind string `json:"kind"`
+
+	// Name of the referenced object.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	Name string `json:"name"`
+
+	// UID of the referenced object. If set, the API server rejects the request
+	// if the object with the given name has a different UID.
+	// +optional
+	UID string `json:"uid,omitempty"`
+}
+
+// ServiceAccountToken generates short-lived tokens for a Kubernetes ServiceAccount
+// using the TokenRequest API.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type ServiceAccountToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceAccountTokenSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceAccountTokenList contains a list of ServiceAccountToken resources.
+type ServiceAccountTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceAccountToken `json:"items"`
+}
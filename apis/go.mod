@@ -3,6 +3,7 @@ module github.com/external-secrets/external-secrets/apis
 go 1.25.7
 
 require (
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/stretchr/testify v1.10.0
 	k8s.io/api v0.34.1
 	k8s.io/apiextensions-apiserver v0.34.1
@@ -17,8 +17,13 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -27,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +44,7 @@ import (
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/audit"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret/cesmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret"
@@ -49,9 +56,11 @@ import (
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/refreshreceiver"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/cssmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/ssmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/sharding"
 	"github.com/external-secrets/external-secrets/runtime/feature"
 
 	// To allow using gcp auth.
@@ -89,6 +98,7 @@ var (
 	enableClusterPushSecretReconciler     bool
 	enablePushSecretReconciler            bool
 	enableFloodGate                       bool
+	enableSecretsWatch                    bool
 	enableGeneratorState                  bool
 	enableExtendedMetricLabels            bool
 	storeRequeueInterval                  time.Duration
@@ -102,10 +112,44 @@ var (
 	tlsMinVersion                         string
 	enableHTTP2                           bool
 	allowGenericTargets                   bool
+	allowDriftAdoption                    bool
+	planHashKeySecretName                 string
+	planHashKeySecretNamespace            string
+	refreshReceiverAddr                   string
+	refreshReceiverHMACSecretFile         string
+	refreshReceiverHMACHeader             string
+	refreshReceiverJWKSURL                string
+	refreshReceiverJWTIssuer              string
+	refreshReceiverJWTAudience            string
+	refreshReceiverJWTClaims              map[string]string
+	refreshReceiverSNSTopicARNs           []string
+	enableSharding                        bool
+	shardLeaseNamespace                   string
+	shardIdentity                         string
+	shardLeaseDuration                    time.Duration
+	shardRenewInterval                    time.Duration
+	auditLogSinks                         []string
+	auditLogFile                          string
+	auditLogFileMaxSize                   int
+	auditLogFileMaxBackups                int
+	auditLogWebhookURL                    string
+	auditLogWebhookBatchSize              int
+	auditLogWebhookFlushInterval          time.Duration
+	enableClientPool                      bool
+	clientPoolSize                        int
+	clientPoolIdleTimeout                 time.Duration
+	clientPoolMaxAge                      time.Duration
 )
 
 const (
 	errCreateController = "unable to create controller"
+
+	// serviceAccountNamespaceFile contains the namespace of the pod.
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	auditSinkStdout  = "stdout"
+	auditSinkFile    = "file"
+	auditSinkWebhook = "webhook"
 )
 
 func init() {
@@ -245,7 +289,36 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, errCreateController, "controller", "GeneratorState")
 			os.Exit(1)
 		}
-		if err = (&externalsecret.Reconciler{
+		auditor, err := setupAuditor()
+		if err != nil {
+			setupLog.Error(err, "unable to create audit log")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := auditor.Close(); err != nil {
+				setupLog.Error(err, "unable to close audit log")
+			}
+		}()
+		var clientPool *secretstore.ClientPool
+		if enableClientPool {
+			if clientPool, err = setupClientPool(mgr); err != nil {
+				setupLog.Error(err, "unable to create client pool")
+				os.Exit(1)
+			}
+		}
+		var shard *sharding.Sharder
+		if enableSharding {
+			// the refresh receiver and the secrets watch run on the leader, which only reconciles its own shard
+			if enableSecretsWatch || refreshReceiverAddr != "" {
+				setupLog.Error(errors.New("--enable-sharding can not be combined with --enable-secrets-watch or --refresh-receiver-addr"), "unable to enable sharding")
+				os.Exit(1)
+			}
+			if shard, err = setupSharding(mgr); err != nil {
+				setupLog.Error(err, "unable to enable sharding")
+				os.Exit(1)
+			}
+		}
+		esReconciler := &externalsecret.Reconciler{
 			Client:                    mgr.GetClient(),
 			SecretClient:              secretClient,
 			Log:                       ctrl.Log.WithName("controllers").WithName("ExternalSecret"),
@@ -257,13 +330,37 @@ var rootCmd = &cobra.Command{
 			EnableFloodGate:           enableFloodGate,
 			EnableGeneratorState:      enableGeneratorState,
 			AllowGenericTargets:       allowGenericTargets,
-		}).SetupWithManager(cmd.Context(), mgr, controller.Options{
+			AllowDriftAdoption:        allowDriftAdoption,
+			Shard:                     shard,
+			Auditor:                   auditor,
+			ClientPool:                clientPool,
+			PlanHashKey:               planHashKey(mgr),
+		}
+		esOpts := controller.Options{
 			MaxConcurrentReconciles: concurrent,
 			RateLimiter:             ctrlcommon.BuildRateLimiter(),
-		}); err != nil {
+		}
+		if shard != nil {
+			// every replica reconciles the ExternalSecrets of its shard
+			esOpts.NeedLeaderElection = ptr.To(false)
+		}
+		if err = esReconciler.SetupWithManager(cmd.Context(), mgr, esOpts); err != nil {
 			setupLog.Error(err, errCreateController, "controller", "ExternalSecret")
 			os.Exit(1)
 		}
+		if enableSecretsWatch {
+			esReconciler.WatchManager = secretstore.NewWatchManager(mgr.GetClient(), esReconciler)
+			if err = mgr.Add(esReconciler.WatchManager); err != nil {
+				setupLog.Error(err, "unable to add secrets watch manager")
+				os.Exit(1)
+			}
+		}
+		if refreshReceiverAddr != "" {
+			if err = setupRefreshReceiver(cmd.Context(), mgr, esReconciler); err != nil {
+				setupLog.Error(err, "unable to create refresh receiver")
+				os.Exit(1)
+			}
+		}
 		if enablePushSecretReconciler {
 			psmetrics.SetUpMetrics()
 			if err = (&pushsecret.Reconciler{
@@ -273,6 +370,8 @@ var rootCmd = &cobra.Command{
 				ControllerClass: controllerClass,
 				RestConfig:      mgr.GetConfig(),
 				RequeueInterval: time.Hour,
+				Auditor:         auditor,
+				ClientPool:      clientPool,
 			}).SetupWithManager(cmd.Context(), mgr, controller.Options{
 				MaxConcurrentReconciles: concurrent,
 				RateLimiter:             ctrlcommon.BuildRateLimiter(),
@@ -330,6 +429,7 @@ var rootCmd = &cobra.Command{
 		setupLog.Info("starting manager")
 		if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 			setupLog.Error(err, "problem running manager")
+			_ = auditor.Close()
 			os.Exit(1)
 		}
 	},
@@ -367,6 +467,23 @@ func init() {
 	rootCmd.Flags().BoolVar(&enableConfigMapsCache, "enable-configmaps-caching", false, "Enable configmaps caching for ALL configmaps in the cluster (WARNING: can increase memory usage).")
 	rootCmd.Flags().BoolVar(&enableManagedSecretsCache, "enable-managed-secrets-caching", true, "Enable secrets caching for secrets managed by an ExternalSecret")
 	rootCmd.Flags().DurationVar(&storeRequeueInterval, "store-requeue-interval", time.Minute*5, "Default Time duration between reconciling (Cluster)SecretStores")
+	rootCmd.Flags().BoolVar(&enableSecretsWatch, "enable-secrets-watch", false, "Enable watching the stores of providers that support it, ExternalSecrets are refreshed when a secret they read changes.")
+	rootCmd.Flags().BoolVar(&enableSharding, "enable-sharding", false, "Shard ExternalSecrets across all replicas of the controller. Every replica reconciles the ExternalSecrets that hash into its shard, replicas coordinate with Leases.")
+	rootCmd.Flags().StringVar(&shardLeaseNamespace, "shard-lease-namespace", "", "Namespace of the shard Leases. Defaults to the namespace of the pod.")
+	rootCmd.Flags().StringVar(&shardIdentity, "shard-identity", "", "Identity of the replica in the shard Leases. Defaults to the hostname, which is the pod name.")
+	rootCmd.Flags().DurationVar(&shardLeaseDuration, "shard-lease-duration", 15*time.Second, "Duration after which the shard of a replica that has not renewed its Lease is taken over by the other replicas.")
+	rootCmd.Flags().DurationVar(&shardRenewInterval, "shard-renew-interval", 5*time.Second, "Interval at which a replica renews its shard Lease and observes the other replicas.")
+	rootCmd.Flags().StringSliceVar(&auditLogSinks, "audit-log-sinks", nil, "Sinks of the audit log of provider reads, pushes and deletes, any of: stdout, file, webhook. The audit log is disabled if empty.")
+	rootCmd.Flags().StringVar(&auditLogFile, "audit-log-file", "", "Path of the audit log file, required with the file sink.")
+	rootCmd.Flags().IntVar(&auditLogFileMaxSize, "audit-log-file-max-size", 100, "Size in megabytes at which the audit log file is rotated, 0 disables the rotation.")
+	rootCmd.Flags().IntVar(&auditLogFileMaxBackups, "audit-log-file-max-backups", 5, "Number of rotated audit log files to keep.")
+	rootCmd.Flags().StringVar(&auditLogWebhookURL, "audit-log-webhook-url", "", "URL the webhook sink POSTs batches of audit events to, required with the webhook sink.")
+	rootCmd.Flags().IntVar(&auditLogWebhookBatchSize, "audit-log-webhook-batch-size", 100, "Maximum number of audit events in a batch of the webhook sink.")
+	rootCmd.Flags().DurationVar(&auditLogWebhookFlushInterval, "audit-log-webhook-flush-interval", 5*time.Second, "Interval at which the webhook sink sends incomplete batches.")
+	rootCmd.Flags().BoolVar(&enableClientPool, "enable-client-pool", false, "Keep provider clients open across reconciles of ExternalSecrets and PushSecrets, instead of authenticating against the provider for every reconcile.")
+	rootCmd.Flags().IntVar(&clientPoolSize, "client-pool-size", 1000, "Maximum number of pooled provider clients, the least recently used client is closed once it is exceeded.")
+	rootCmd.Flags().DurationVar(&clientPoolIdleTimeout, "client-pool-idle-timeout", 10*time.Minute, "Duration after which a pooled provider client that has not been used is closed.")
+	rootCmd.Flags().DurationVar(&clientPoolMaxAge, "client-pool-max-age", time.Hour, "Duration after which a pooled provider client is replaced, to pick up rotated credentials that are not read from a Secret when the client is created. 0 disables it.")
 	rootCmd.Flags().BoolVar(&enableFloodGate, "enable-flood-gate", true, "Enable flood gate. External secret will be reconciled only if the ClusterStore or Store have an healthy or unknown state.")
 	rootCmd.Flags().BoolVar(&enableGeneratorState, "enable-generator-state", true, "Whether the Controller should manage GeneratorState")
 	rootCmd.Flags().BoolVar(&enableExtendedMetricLabels, "enable-extended-metric-labels", false, "Enable recommended kubernetes annotations as labels in metrics.")
@@ -374,12 +491,174 @@ func init() {
 		"If set, HTTP/2 will be enabled for the metrics server")
 	rootCmd.Flags().
 		BoolVar(&allowGenericTargets, "unsafe-allow-generic-targets", false, "Enable support for creating generic resources (ConfigMaps, Custom Resources). WARNING: Using generic resources, please sure all policies are correctly configured.")
+	rootCmd.Flags().StringVar(&planHashKeySecretName, "plan-hash-key-secret-name", "external-secrets-plan-hash-key", "Name of the Secret with the key of the HMAC of values planned by dry runs. It is created if it doesn't exist.")
+	rootCmd.Flags().StringVar(&planHashKeySecretNamespace, "plan-hash-key-secret-namespace", "", "Namespace of the Secret with the key of the HMAC of values planned by dry runs. Defaults to the namespace of the pod.")
+	rootCmd.Flags().
+		BoolVar(&allowDriftAdoption, "unsafe-allow-drift-adoption", false, "Enable driftPolicy=Adopt of ExternalSecrets, which pushes changes of target Secrets to the providers of stores with allowDriftAdoption. WARNING: whoever may edit such a Secret may write to the provider.")
+	rootCmd.Flags().StringVar(&refreshReceiverAddr, "refresh-receiver-addr", "", "The address the refresh receiver binds to. The receiver refreshes ExternalSecrets on change notifications of providers and is disabled if empty.")
+	rootCmd.Flags().StringVar(&refreshReceiverHMACSecretFile, "refresh-receiver-hmac-secret-file", "", "File with the secret to verify the HMAC-SHA256 signature of notifications.")
+	rootCmd.Flags().StringVar(&refreshReceiverHMACHeader, "refresh-receiver-hmac-header", refreshreceiver.HMACSignatureHeader, "Header of the HMAC-SHA256 signature of notifications.")
+	rootCmd.Flags().StringVar(&refreshReceiverJWKSURL, "refresh-receiver-jwks-url", "", "URL of the keys to verify the bearer token of notifications, e.g. https://www.googleapis.com/oauth2/v3/certs.")
+	rootCmd.Flags().StringVar(&refreshReceiverJWTIssuer, "refresh-receiver-jwt-issuer", "", "Issuer of the bearer token of notifications.")
+	rootCmd.Flags().StringVar(&refreshReceiverJWTAudience, "refresh-receiver-jwt-audience", "", "Audience of the bearer token of notifications, required with --refresh-receiver-jwks-url.")
+	rootCmd.Flags().StringToStringVar(&refreshReceiverJWTClaims, "refresh-receiver-jwt-claims", nil, "Claims the bearer token of notifications must contain, e.g. email=pubsub@my-project.iam.gserviceaccount.com.")
+	rootCmd.Flags().StringSliceVar(&refreshReceiverSNSTopicARNs, "refresh-receiver-sns-topic-arns", nil, "AWS SNS topics to accept notifications from. Enables the sns notification format.")
 	fs := feature.Features()
 	for _, f := range fs {
 		rootCmd.Flags().AddFlagSet(f.Flags)
 	}
 }
 
+// setupRefreshReceiver adds the refresh receiver with the verifiers of the flags to the manager.
+func setupRefreshReceiver(ctx context.Context, mgr ctrl.Manager, refresher esv1.Refresher) error {
+	var verifiers []refreshreceiver.Verifier
+	if refreshReceiverHMACSecretFile != "" {
+		secret, err := os.ReadFile(refreshReceiverHMACSecretFile)
+		if err != nil {
+			return err
+		}
+		verifiers = append(verifiers, &refreshreceiver.HMACVerifier{
+			Header: refreshReceiverHMACHeader,
+			Secret: bytes.TrimSpace(secret),
+		})
+	}
+	if refreshReceiverJWKSURL != "" {
+		if refreshReceiverJWTAudience == "" {
+			return errors.New("--refresh-receiver-jwt-audience is required with --refresh-receiver-jwks-url")
+		}
+		verifier, err := refreshreceiver.NewJWTVerifier(ctx, refreshreceiver.JWTOptions{
+			JWKSURL:  refreshReceiverJWKSURL,
+			Issuer:   refreshReceiverJWTIssuer,
+			Audience: refreshReceiverJWTAudience,
+			Claims:   refreshReceiverJWTClaims,
+		})
+		if err != nil {
+			return err
+		}
+		verifiers = append(verifiers, verifier)
+	}
+	receiver, err := refreshreceiver.New(refreshreceiver.Options{
+		Addr:      refreshReceiverAddr,
+		Refresher: refresher,
+		Verifiers: verifiers,
+		Parsers:   refreshreceiver.DefaultParsers(refreshReceiverSNSTopicARNs),
+		Log:       ctrl.Log.WithName("refresh-receiver"),
+	})
+	if err != nil {
+		return err
+	}
+	return mgr.Add(receiver)
+}
+
+// podNamespace returns the namespace of the pod the controller runs in.
+func podNamespace() (string, error) {
+	data, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// planHashKey returns the key of the HMAC of values planned by dry runs.
+// Dry runs fail if the namespace of its Secret is unknown.
+func planHashKey(mgr ctrl.Manager) *externalsecret.PlanHashKey {
+	keyNamespace := planHashKeySecretNamespace
+	if keyNamespace == "" {
+		var err error
+		keyNamespace, err = podNamespace()
+		if err != nil {
+			setupLog.Info("dry runs are disabled, --plan-hash-key-secret-namespace is required outside of a cluster", "error", err.Error())
+			return nil
+		}
+	}
+	return &externalsecret.PlanHashKey{
+		Reader:    mgr.GetAPIReader(),
+		Client:    mgr.GetClient(),
+		Name:      planHashKeySecretName,
+		Namespace: keyNamespace,
+	}
+}
+
+// setupSharding adds the Sharder of the replica to the manager.
+func setupSharding(mgr ctrl.Manager) (*sharding.Sharder, error) {
+	identity := shardIdentity
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		identity = hostname
+	}
+	leaseNamespace := shardLeaseNamespace
+	if leaseNamespace == "" {
+		var err error
+		leaseNamespace, err = podNamespace()
+		if err != nil {
+			return nil, fmt.Errorf("--shard-lease-namespace is required outside of a cluster: %w", err)
+		}
+	}
+	sharding.SetUpMetrics()
+	shard, err := sharding.New(mgr.GetClient(), mgr.GetAPIReader(), sharding.Options{
+		Namespace:     leaseNamespace,
+		Group:         "external-secrets-" + controllerClass,
+		Identity:      identity,
+		LeaseDuration: shardLeaseDuration,
+		RenewInterval: shardRenewInterval,
+		Log:           ctrl.Log.WithName("sharding"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return shard, mgr.Add(shard)
+}
+
+// setupClientPool adds the pool of provider clients to the manager.
+func setupClientPool(mgr ctrl.Manager) (*secretstore.ClientPool, error) {
+	secretstore.SetUpClientPoolMetrics()
+	pool, err := secretstore.NewClientPool(ctrl.Log.WithName("clientpool"), clientPoolSize, clientPoolIdleTimeout, clientPoolMaxAge)
+	if err != nil {
+		return nil, err
+	}
+	return pool, mgr.Add(pool)
+}
+
+// setupAuditor creates the Auditor of the configured audit log sinks.
+// It returns nil if no sinks are configured, which disables the audit log.
+func setupAuditor() (*audit.Auditor, error) {
+	if len(auditLogSinks) == 0 {
+		return nil, nil
+	}
+	log := ctrl.Log.WithName("audit")
+	audit.SetUpMetrics()
+	sinks := make([]audit.Sink, 0, len(auditLogSinks))
+	for _, name := range auditLogSinks {
+		switch name {
+		case auditSinkStdout:
+			sinks = append(sinks, audit.NewWriterSink(os.Stdout))
+		case auditSinkFile:
+			if auditLogFile == "" {
+				return nil, errors.New("--audit-log-file is required with the file sink")
+			}
+			sink, err := audit.NewFileSink(auditLogFile, int64(auditLogFileMaxSize)*1024*1024, auditLogFileMaxBackups)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case auditSinkWebhook:
+			if auditLogWebhookURL == "" {
+				return nil, errors.New("--audit-log-webhook-url is required with the webhook sink")
+			}
+			if auditLogWebhookFlushInterval <= 0 {
+				return nil, errors.New("--audit-log-webhook-flush-interval must be positive")
+			}
+			sinks = append(sinks, audit.NewWebhookSink(log, auditLogWebhookURL, auditLogWebhookBatchSize, auditLogWebhookFlushInterval))
+		default:
+			return nil, fmt.Errorf("unknown audit log sink %q", name)
+		}
+	}
+	return audit.New(log, sinks...), nil
+}
+
 // disableHTTP2 is a TLS configuration function that disables HTTP/2.
 func disableHTTP2(cfg *tls.Config) {
 	cfg.NextProtos = []string{"http/1.1"}
@@ -152,6 +152,10 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, errCreateWebhook, "webhook", "ExternalSecret-v1")
 			os.Exit(1)
 		}
+		if err = (&esv1alpha1.PushSecret{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, errCreateWebhook, "webhook", "PushSecret-v1alpha1")
+			os.Exit(1)
+		}
 		if err = (&esv1.SecretStore{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, errCreateWebhook, "webhook", "SecretStore-v1")
 			os.Exit(1)
@@ -44,6 +44,7 @@ var (
 	templateFromConfigMapFile string
 	templateFromSecretFile    string
 	showVersion               bool
+	decodeOutput              bool
 )
 
 func init() {
@@ -54,6 +55,7 @@ func init() {
 	templateCmd.Flags().StringVar(&templateFromSecretFile, "template-from-secret", "", "Link to a file containing config map data for TemplateFrom.Secret")
 	templateCmd.Flags().StringVar(&outputFile, "output", "", "If set, the output will be written to this file")
 	templateCmd.Flags().BoolVar(&showVersion, "version", false, "If set, only print the version and exit")
+	templateCmd.Flags().BoolVar(&decodeOutput, "decode", false, "If set, the rendered data is printed as plain text instead of base64")
 }
 
 var templateCmd = &cobra.Command{
@@ -133,6 +135,11 @@ func templateRun(_ *cobra.Command, _ []string) error {
 		out = f
 	}
 
+	// show rendered files like .env or .properties as they would be mounted
+	if decodeOutput {
+		decodeSecretData(targetSecret)
+	}
+
 	// display the resulting secret
 	content, err = yaml.Marshal(targetSecret)
 	if err != nil {
@@ -144,6 +151,19 @@ func templateRun(_ *cobra.Command, _ []string) error {
 	return err
 }
 
+func decodeSecretData(secret *corev1.Secret) {
+	if len(secret.Data) == 0 {
+		return
+	}
+	if secret.StringData == nil {
+		secret.StringData = make(map[string]string, len(secret.Data))
+	}
+	for k, v := range secret.Data {
+		secret.StringData[k] = string(v)
+	}
+	secret.Data = nil
+}
+
 func fetchTemplateFromSourceObject(obj *unstructured.Unstructured) (*esv1.ExternalSecretTemplate, error) {
 	var tmpl *esv1.ExternalSecretTemplate
 	switch obj.GetKind() {
@@ -0,0 +1,383 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cryptokey provides functionality for generating symmetric keys, key pairs and key sets.
+package cryptokey
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// Generator implements symmetric key and key pair generation functionality.
+type Generator struct{}
+
+const (
+	defaultRSAKeySize   = 2048
+	defaultECDSAKeySize = 256
+
+	keySymmetric  = "key"
+	keyPrivateKey = "privateKey"
+	keyPublicKey  = "publicKey"
+	keyJWKS       = "jwks"
+
+	errNoSpec              = "no config spec provided"
+	errParseSpec           = "unable to parse spec: %w"
+	errParseState          = "unable to parse previous state: %w"
+	errGenerateKey         = "unable to generate key: %w"
+	errUnsupportedType     = "unsupported key type: %q"
+	errUnsupportedSize     = "unsupported key size %d for key type %s"
+	errUnsupportedEncoding = "unsupported encoding %q for key type %s"
+	errKeySetSize          = "keySetSize greater than 1 requires the JWKS encoding"
+	errKeySetSymmetric     = "keySetSize greater than 1 is not supported for symmetric key type %s, as the previous keys would have to be stored in the generator state"
+)
+
+// Generate creates a new key.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	return g.GenerateWithState(ctx, jsonSpec, nil, kube, namespace)
+}
+
+// GenerateWithState creates a new key. The key set of the previous state is
+// used to carry over previous keys into the emitted JSON Web Key Set.
+func (g *Generator) GenerateWithState(_ context.Context, jsonSpec *apiextensions.JSON, previous genv1alpha1.GeneratorProviderState, _ client.Client, _ string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	return g.generate(jsonSpec, previous)
+}
+
+// Cleanup performs any necessary cleanup after key generation.
+func (g *Generator) Cleanup(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) error {
+	return nil
+}
+
+func (g *Generator) generate(jsonSpec *apiextensions.JSON, previous genv1alpha1.GeneratorProviderState) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	if jsonSpec == nil {
+		return nil, nil, errors.New(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errParseSpec, err)
+	}
+	spec := res.Spec
+
+	encoding := spec.Encoding
+	if encoding == "" {
+		encoding = genv1alpha1.CryptoKeyEncodingRaw
+	}
+	keySetSize := max(spec.KeySetSize, 1)
+	if keySetSize > 1 && encoding != genv1alpha1.CryptoKeyEncodingJWKS {
+		return nil, nil, errors.New(errKeySetSize)
+	}
+	if keySetSize > 1 && isSymmetric(spec.Type) {
+		return nil, nil, fmt.Errorf(errKeySetSymmetric, spec.Type)
+	}
+
+	key, err := generateKey(spec.Type, spec.KeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errGenerateKey, err)
+	}
+
+	switch encoding {
+	case genv1alpha1.CryptoKeyEncodingJWK, genv1alpha1.CryptoKeyEncodingJWKS:
+		return encodeJWK(spec.Type, key, encoding, keySetSize, previous)
+	default:
+		data, err := encodeKey(spec.Type, key, encoding)
+		return data, nil, err
+	}
+}
+
+// generateKey returns a []byte for symmetric key types
+// and a crypto.Signer for asymmetric key types.
+func generateKey(keyType genv1alpha1.CryptoKeyType, keySize *int) (any, error) {
+	switch keyType {
+	case genv1alpha1.CryptoKeyTypeAES128:
+		return randomBytes(16)
+	case genv1alpha1.CryptoKeyTypeAES256, genv1alpha1.CryptoKeyTypeHMACSHA256, genv1alpha1.CryptoKeyTypeFernet:
+		return randomBytes(32)
+	case genv1alpha1.CryptoKeyTypeHMACSHA512:
+		return randomBytes(64)
+	case genv1alpha1.CryptoKeyTypeEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	case genv1alpha1.CryptoKeyTypeECDSA:
+		bits := defaultECDSAKeySize
+		if keySize != nil {
+			bits = *keySize
+		}
+		curve, err := ellipticCurve(bits)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case genv1alpha1.CryptoKeyTypeRSA:
+		bits := defaultRSAKeySize
+		if keySize != nil {
+			bits = *keySize
+		}
+		switch bits {
+		case 2048, 3072, 4096:
+			return rsa.GenerateKey(rand.Reader, bits)
+		default:
+			return nil, fmt.Errorf(errUnsupportedSize, bits, keyType)
+		}
+	default:
+		return nil, fmt.Errorf(errUnsupportedType, keyType)
+	}
+}
+
+// isSymmetric returns true for the key types that generate a single secret key.
+func isSymmetric(keyType genv1alpha1.CryptoKeyType) bool {
+	switch keyType {
+	case genv1alpha1.CryptoKeyTypeAES128, genv1alpha1.CryptoKeyTypeAES256,
+		genv1alpha1.CryptoKeyTypeHMACSHA256, genv1alpha1.CryptoKeyTypeHMACSHA512,
+		genv1alpha1.CryptoKeyTypeFernet:
+		return true
+	default:
+		return false
+	}
+}
+
+func ellipticCurve(bits int) (elliptic.Curve, error) {
+	switch bits {
+	case 256:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf(errUnsupportedSize, bits, genv1alpha1.CryptoKeyTypeECDSA)
+	}
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func encodeKey(keyType genv1alpha1.CryptoKeyType, key any, encoding genv1alpha1.CryptoKeyEncoding) (map[string][]byte, error) {
+	switch k := key.(type) {
+	case []byte:
+		if keyType == genv1alpha1.CryptoKeyTypeFernet {
+			if encoding != genv1alpha1.CryptoKeyEncodingRaw {
+				return nil, fmt.Errorf(errUnsupportedEncoding, encoding, keyType)
+			}
+			return map[string][]byte{
+				keySymmetric: []byte(base64.URLEncoding.EncodeToString(k)),
+			}, nil
+		}
+		if encoding == genv1alpha1.CryptoKeyEncodingPEM {
+			return nil, fmt.Errorf(errUnsupportedEncoding, encoding, keyType)
+		}
+		return map[string][]byte{
+			keySymmetric: encodeBytes(k, encoding),
+		}, nil
+	case crypto.Signer:
+		privateKey, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := x509.MarshalPKIXPublicKey(k.Public())
+		if err != nil {
+			return nil, err
+		}
+		if encoding == genv1alpha1.CryptoKeyEncodingPEM {
+			return map[string][]byte{
+				keyPrivateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey}),
+				keyPublicKey:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}),
+			}, nil
+		}
+		return map[string][]byte{
+			keyPrivateKey: encodeBytes(privateKey, encoding),
+			keyPublicKey:  encodeBytes(publicKey, encoding),
+		}, nil
+	default:
+		return nil, fmt.Errorf(errUnsupportedType, keyType)
+	}
+}
+
+func encodeBytes(b []byte, encoding genv1alpha1.CryptoKeyEncoding) []byte {
+	switch encoding {
+	case genv1alpha1.CryptoKeyEncodingBase64:
+		return []byte(base64.StdEncoding.EncodeToString(b))
+	case genv1alpha1.CryptoKeyEncodingHex:
+		return []byte(hex.EncodeToString(b))
+	default:
+		return b
+	}
+}
+
+func encodeJWK(keyType genv1alpha1.CryptoKeyType, key any, encoding genv1alpha1.CryptoKeyEncoding, keySetSize int, previous genv1alpha1.GeneratorProviderState) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	if keyType == genv1alpha1.CryptoKeyTypeFernet {
+		return nil, nil, fmt.Errorf(errUnsupportedEncoding, encoding, keyType)
+	}
+	privateJWK, err := jwk.FromRaw(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := setJWKMetadata(privateJWK, keyType, key); err != nil {
+		return nil, nil, err
+	}
+
+	data := make(map[string][]byte)
+	// the key which ends up in the key set. For symmetric keys
+	// this is the secret key itself as it's needed for verification.
+	setJWK := privateJWK
+	if _, ok := key.([]byte); ok {
+		if data[keySymmetric], err = json.Marshal(privateJWK); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		setJWK, err = jwk.PublicKeyOf(privateJWK)
+		if err != nil {
+			return nil, nil, err
+		}
+		if data[keyPrivateKey], err = json.Marshal(privateJWK); err != nil {
+			return nil, nil, err
+		}
+		if data[keyPublicKey], err = json.Marshal(setJWK); err != nil {
+			return nil, nil, err
+		}
+	}
+	if encoding != genv1alpha1.CryptoKeyEncodingJWKS {
+		return data, nil, nil
+	}
+
+	set, err := keySet(setJWK, keySetSize, previous)
+	if err != nil {
+		return nil, nil, err
+	}
+	if data[keyJWKS], err = json.Marshal(set); err != nil {
+		return nil, nil, err
+	}
+	// only keep state around if we need it for the next rotation
+	if keySetSize == 1 {
+		return data, nil, nil
+	}
+	state, err := json.Marshal(&genv1alpha1.CryptoKeyState{
+		KeySet: string(data[keyJWKS]),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, &apiextensions.JSON{Raw: state}, nil
+}
+
+func setJWKMetadata(key jwk.Key, keyType genv1alpha1.CryptoKeyType, raw any) error {
+	usage := jwk.ForSignature
+	var alg jwa.KeyAlgorithm
+	switch keyType {
+	case genv1alpha1.CryptoKeyTypeAES128, genv1alpha1.CryptoKeyTypeAES256:
+		usage = jwk.ForEncryption
+	case genv1alpha1.CryptoKeyTypeHMACSHA256:
+		alg = jwa.HS256
+	case genv1alpha1.CryptoKeyTypeHMACSHA512:
+		alg = jwa.HS512
+	case genv1alpha1.CryptoKeyTypeEd25519:
+		alg = jwa.EdDSA
+	case genv1alpha1.CryptoKeyTypeRSA:
+		alg = jwa.RS256
+	case genv1alpha1.CryptoKeyTypeECDSA:
+		switch raw.(*ecdsa.PrivateKey).Curve {
+		case elliptic.P384():
+			alg = jwa.ES384
+		case elliptic.P521():
+			alg = jwa.ES512
+		default:
+			alg = jwa.ES256
+		}
+	}
+	if err := key.Set(jwk.KeyUsageKey, usage); err != nil {
+		return err
+	}
+	if alg != nil {
+		if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+			return err
+		}
+	}
+	// use the RFC 7638 thumbprint as key id
+	return jwk.AssignKeyID(key)
+}
+
+// keySet builds a key set with the new key first, followed by
+// up to keySetSize-1 keys of the previously emitted key set.
+func keySet(newKey jwk.Key, keySetSize int, previous genv1alpha1.GeneratorProviderState) (jwk.Set, error) {
+	set := jwk.NewSet()
+	if err := set.AddKey(newKey); err != nil {
+		return nil, err
+	}
+	if previous == nil || keySetSize == 1 {
+		return set, nil
+	}
+	var state genv1alpha1.CryptoKeyState
+	if err := json.Unmarshal(previous.Raw, &state); err != nil {
+		return nil, fmt.Errorf(errParseState, err)
+	}
+	if state.KeySet == "" {
+		return set, nil
+	}
+	previousSet, err := jwk.Parse([]byte(state.KeySet))
+	if err != nil {
+		return nil, fmt.Errorf(errParseState, err)
+	}
+	for i := 0; i < previousSet.Len() && set.Len() < keySetSize; i++ {
+		key, _ := previousSet.Key(i)
+		if key.KeyID() == newKey.KeyID() {
+			continue
+		}
+		if err := set.AddKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.CryptoKey, error) {
+	var spec genv1alpha1.CryptoKey
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+// NewGenerator creates a new Generator instance.
+func NewGenerator() genv1alpha1.Generator {
+	return &Generator{}
+}
+
+// Kind returns the generator kind.
+func Kind() string {
+	return string(genv1alpha1.GeneratorKindCryptoKey)
+}
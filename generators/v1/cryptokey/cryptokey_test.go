@@ -0,0 +1,250 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cryptokey
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func TestGenerate(t *testing.T) {
+	g := &Generator{}
+
+	tests := []struct {
+		name        string
+		jsonSpec    *apiextensions.JSON
+		expectedErr string
+		validate    func(t *testing.T, result map[string][]byte)
+	}{
+		{
+			name:        "nil spec should return error",
+			jsonSpec:    nil,
+			expectedErr: errNoSpec,
+		},
+		{
+			name:        "missing type should return error",
+			jsonSpec:    &apiextensions.JSON{Raw: []byte(`{"spec":{}}`)},
+			expectedErr: "unsupported key type",
+		},
+		{
+			name:     "aes-256 raw",
+			jsonSpec: &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"AES-256"}}`)},
+			validate: func(t *testing.T, result map[string][]byte) {
+				assert.Len(t, result["key"], 32)
+			},
+		},
+		{
+			name:     "aes-128 base64",
+			jsonSpec: &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"AES-128","encoding":"base64"}}`)},
+			validate: func(t *testing.T, result map[string][]byte) {
+				raw, err := base64.StdEncoding.DecodeString(string(result["key"]))
+				require.NoError(t, err)
+				assert.Len(t, raw, 16)
+			},
+		},
+		{
+			name:     "hmac-sha512 hex",
+			jsonSpec: &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"HMAC-SHA512","encoding":"hex"}}`)},
+			validate: func(t *testing.T, result map[string][]byte) {
+				raw, err := hex.DecodeString(string(result["key"]))
+				require.NoError(t, err)
+				assert.Len(t, raw, 64)
+			},
+		},
+		{
+			name:        "symmetric key with pem should return error",
+			jsonSpec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"HMAC-SHA256","encoding":"PEM"}}`)},
+			expectedErr: `unsupported encoding "PEM"`,
+		},
+		{
+			name:     "fernet",
+			jsonSpec: &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"Fernet"}}`)},
+			validate: func(t *testing.T, result map[string][]byte) {
+				raw, err := base64.URLEncoding.DecodeString(string(result["key"]))
+				require.NoError(t, err)
+				assert.Len(t, raw, 32)
+			},
+		},
+		{
+			name:        "fernet with jwk should return error",
+			jsonSpec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"Fernet","encoding":"JWK"}}`)},
+			expectedErr: `unsupported encoding "JWK"`,
+		},
+		{
+			name:     "ed25519 pem",
+			jsonSpec: &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"Ed25519","encoding":"PEM"}}`)},
+			validate: func(t *testing.T, result map[string][]byte) {
+				block, _ := pem.Decode(result["privateKey"])
+				require.NotNil(t, block)
+				assert.Equal(t, "PRIVATE KEY", block.Type)
+				key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+				require.NoError(t, err)
+				assert.IsType(t, ed25519.PrivateKey{}, key)
+				block, _ = pem.Decode(result["publicKey"])
+				require.NotNil(t, block)
+				assert.Equal(t, "PUBLIC KEY", block.Type)
+			},
+		},
+		{
+			name:     "ecdsa raw with custom size",
+			jsonSpec: &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"ECDSA","keySize":384}}`)},
+			validate: func(t *testing.T, result map[string][]byte) {
+				key, err := x509.ParsePKCS8PrivateKey(result["privateKey"])
+				require.NoError(t, err)
+				assert.Equal(t, 384, key.(*ecdsa.PrivateKey).Curve.Params().BitSize)
+				_, err = x509.ParsePKIXPublicKey(result["publicKey"])
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:        "ecdsa with unsupported size should return error",
+			jsonSpec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"ECDSA","keySize":512}}`)},
+			expectedErr: "unsupported key size 512",
+		},
+		{
+			name:     "rsa jwk",
+			jsonSpec: &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"RSA","encoding":"JWK"}}`)},
+			validate: func(t *testing.T, result map[string][]byte) {
+				key, err := jwk.ParseKey(result["privateKey"])
+				require.NoError(t, err)
+				assert.Equal(t, "RS256", key.Algorithm().String())
+				assert.NotEmpty(t, key.KeyID())
+				var raw rsa.PrivateKey
+				require.NoError(t, key.Raw(&raw))
+				pub, err := jwk.ParseKey(result["publicKey"])
+				require.NoError(t, err)
+				assert.Equal(t, key.KeyID(), pub.KeyID())
+				assert.NotContains(t, string(result["publicKey"]), `"d"`)
+			},
+		},
+		{
+			name:     "hmac jwks",
+			jsonSpec: &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"HMAC-SHA256","encoding":"JWKS"}}`)},
+			validate: func(t *testing.T, result map[string][]byte) {
+				key, err := jwk.ParseKey(result["key"])
+				require.NoError(t, err)
+				assert.Equal(t, "HS256", key.Algorithm().String())
+				set, err := jwk.Parse(result["jwks"])
+				require.NoError(t, err)
+				assert.Equal(t, 1, set.Len())
+			},
+		},
+		{
+			name:        "keySetSize without jwks should return error",
+			jsonSpec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"Ed25519","encoding":"JWK","keySetSize":2}}`)},
+			expectedErr: errKeySetSize,
+		},
+		{
+			name:        "keySetSize with symmetric key should return error",
+			jsonSpec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"HMAC-SHA256","encoding":"JWKS","keySetSize":2}}`)},
+			expectedErr: "keySetSize greater than 1 is not supported for symmetric key type HMAC-SHA256",
+		},
+		{
+			name:        "rsa with unsupported size should return error",
+			jsonSpec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"RSA","keySize":8192}}`)},
+			expectedErr: "unsupported key size 8192",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, state, err := g.Generate(context.Background(), tt.jsonSpec, nil, "")
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, state)
+			if tt.validate != nil {
+				tt.validate(t, result)
+			}
+		})
+	}
+}
+
+func TestGenerateKeySetRotation(t *testing.T) {
+	g := &Generator{}
+	spec := &apiextensions.JSON{Raw: []byte(`{"spec":{"type":"Ed25519","encoding":"JWKS","keySetSize":2}}`)}
+
+	var (
+		previous genv1alpha1.GeneratorProviderState
+		kids     []string
+	)
+	for range 3 {
+		result, state, err := g.GenerateWithState(context.Background(), spec, previous, nil, "")
+		require.NoError(t, err)
+		require.NotNil(t, state)
+
+		pub, err := jwk.ParseKey(result["publicKey"])
+		require.NoError(t, err)
+		kids = append(kids, pub.KeyID())
+
+		set, err := jwk.Parse(result["jwks"])
+		require.NoError(t, err)
+		assert.LessOrEqual(t, set.Len(), 2)
+		first, _ := set.Key(0)
+		assert.Equal(t, pub.KeyID(), first.KeyID())
+		for i := range set.Len() {
+			key, _ := set.Key(i)
+			assert.NotContains(t, mustMarshal(t, key), `"d"`)
+		}
+
+		var parsedState genv1alpha1.CryptoKeyState
+		require.NoError(t, json.Unmarshal(state.Raw, &parsedState))
+		assert.JSONEq(t, string(result["jwks"]), parsedState.KeySet)
+		previous = state
+	}
+
+	// the last key set must contain the last two keys, newest first.
+	set, err := jwk.Parse([]byte(mustState(t, previous).KeySet))
+	require.NoError(t, err)
+	require.Equal(t, 2, set.Len())
+	first, _ := set.Key(0)
+	second, _ := set.Key(1)
+	assert.Equal(t, kids[2], first.KeyID())
+	assert.Equal(t, kids[1], second.KeyID())
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func mustState(t *testing.T, state genv1alpha1.GeneratorProviderState) genv1alpha1.CryptoKeyState {
+	t.Helper()
+	var parsed genv1alpha1.CryptoKeyState
+	require.NoError(t, json.Unmarshal(state.Raw, &parsed))
+	return parsed
+}
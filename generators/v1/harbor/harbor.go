@@ -0,0 +1,313 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package harbor provides a generator for project-scoped Harbor robot accounts.
+package harbor
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+// Generator implements robot account generation for Harbor.
+type Generator struct {
+	httpClient *http.Client
+}
+
+// robot is the robot account model of the Harbor API.
+type robot struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Duration    int64             `json:"duration"`
+	Level       string            `json:"level"`
+	Permissions []robotPermission `json:"permissions"`
+}
+
+type robotPermission struct {
+	Kind      string        `json:"kind"`
+	Namespace string        `json:"namespace"`
+	Access    []robotAccess `json:"access"`
+}
+
+type robotAccess struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+// robotCreated is the response of the Harbor API when a robot account was created.
+type robotCreated struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Secret string `json:"secret"`
+}
+
+const (
+	robotsPath   = "/api/v2.0/robots"
+	robotLevel   = "project"
+	robotKind    = "project"
+	suffixLength = 4
+
+	defaultNamePrefix = "eso"
+
+	keyRegistry = "registry"
+	keyUsername = "username"
+	keyPassword = "password"
+	keyAuth     = "auth"
+
+	errNoSpec         = "no config spec provided"
+	errParseSpec      = "unable to parse spec: %w"
+	errParseState     = "unable to parse state: %w"
+	errMissingState   = "missing previous state"
+	errParseURL       = "unable to parse url: %w"
+	errInvalidCA      = "unable to parse caBundle"
+	errGetPassword    = "unable to get password: %w"
+	errCreateRobot    = "unable to create robot account: %w"
+	errDeleteRobot    = "unable to delete robot account %d: %w"
+	errUnexpectedCode = "unexpected status code %d: %s"
+
+	httpClientTimeout = 10 * time.Second
+)
+
+// Generate creates a new robot account in the configured Harbor project.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	if jsonSpec == nil {
+		return nil, nil, errors.New(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errParseSpec, err)
+	}
+	cl, err := g.newClient(ctx, &res.Spec, kube, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	suffix := make([]byte, suffixLength)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, nil, err
+	}
+	prefix := res.Spec.NamePrefix
+	if prefix == "" {
+		prefix = defaultNamePrefix
+	}
+	access := make([]robotAccess, 0, len(res.Spec.Permissions))
+	for _, p := range res.Spec.Permissions {
+		access = append(access, robotAccess{Resource: p.Resource, Action: p.Action})
+	}
+	created, err := cl.createRobot(ctx, &robot{
+		Name:        prefix + "-" + hex.EncodeToString(suffix),
+		Description: res.Spec.Description,
+		Duration:    res.Spec.Duration,
+		Level:       robotLevel,
+		Permissions: []robotPermission{{
+			Kind:      robotKind,
+			Namespace: res.Spec.Project,
+			Access:    access,
+		}},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf(errCreateRobot, err)
+	}
+
+	rawState, err := json.Marshal(&genv1alpha1.HarborRobotAccountState{
+		RobotID: created.ID,
+		Name:    created.Name,
+		URL:     res.Spec.URL,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return map[string][]byte{
+		keyRegistry: []byte(cl.baseURL.Host),
+		keyUsername: []byte(created.Name),
+		keyPassword: []byte(created.Secret),
+		keyAuth:     []byte(b64.StdEncoding.EncodeToString([]byte(created.Name + ":" + created.Secret))),
+	}, &apiextensions.JSON{Raw: rawState}, nil
+}
+
+// Cleanup deletes the robot account referenced by the state.
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, previousStatus genv1alpha1.GeneratorProviderState, kube client.Client, namespace string) error {
+	if previousStatus == nil {
+		return errors.New(errMissingState)
+	}
+	if jsonSpec == nil {
+		return errors.New(errNoSpec)
+	}
+	var state genv1alpha1.HarborRobotAccountState
+	if err := json.Unmarshal(previousStatus.Raw, &state); err != nil {
+		return fmt.Errorf(errParseState, err)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return fmt.Errorf(errParseSpec, err)
+	}
+	// the robot account is deleted in the Harbor instance it was created in.
+	if state.URL != "" {
+		res.Spec.URL = state.URL
+	}
+	cl, err := g.newClient(ctx, &res.Spec, kube, namespace)
+	if err != nil {
+		return err
+	}
+	if err := cl.deleteRobot(ctx, state.RobotID); err != nil {
+		return fmt.Errorf(errDeleteRobot, state.RobotID, err)
+	}
+	return nil
+}
+
+// harborClient is a minimal client of the Harbor v2.0 API.
+type harborClient struct {
+	baseURL  *url.URL
+	username string
+	password string
+	hc       *http.Client
+}
+
+func (g *Generator) newClient(ctx context.Context, spec *genv1alpha1.HarborRobotAccountSpec, kube client.Client, namespace string) (*harborClient, error) {
+	baseURL, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf(errParseURL, err)
+	}
+	password, err := resolvers.SecretKeyRef(ctx, kube, resolvers.EmptyStoreKind, namespace, &esmeta.SecretKeySelector{
+		Namespace: &namespace,
+		Name:      spec.Auth.Basic.Password.Name,
+		Key:       spec.Auth.Basic.Password.Key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errGetPassword, err)
+	}
+
+	hc := g.httpClient
+	if hc == nil {
+		hc = &http.Client{
+			Timeout: httpClientTimeout,
+		}
+		if len(spec.CABundle) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(spec.CABundle) {
+				return nil, errors.New(errInvalidCA)
+			}
+			hc.Transport = &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:    pool,
+					MinVersion: tls.VersionTLS12,
+				},
+			}
+		}
+	}
+	return &harborClient{
+		baseURL:  baseURL,
+		username: spec.Auth.Basic.Username,
+		password: password,
+		hc:       hc,
+	}, nil
+}
+
+func (c *harborClient) createRobot(ctx context.Context, r *robot) (*robotCreated, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, http.MethodPost, c.baseURL.JoinPath(robotsPath).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, unexpectedStatus(resp)
+	}
+	var created robotCreated
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *harborClient) deleteRobot(ctx context.Context, id int64) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.baseURL.JoinPath(robotsPath, strconv.FormatInt(id, 10)).String(), nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	// the robot account may have been deleted manually or expired.
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return unexpectedStatus(resp)
+}
+
+func (c *harborClient) do(ctx context.Context, method, target string, body []byte) (*http.Response, error) {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.hc.Do(req)
+}
+
+func unexpectedStatus(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf(errUnexpectedCode, resp.StatusCode, string(body))
+}
+
+func parseSpec(data []byte) (*genv1alpha1.HarborRobotAccount, error) {
+	var spec genv1alpha1.HarborRobotAccount
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+// NewGenerator creates a new Generator instance.
+func NewGenerator() genv1alpha1.Generator {
+	return &Generator{}
+}
+
+// Kind returns the generator kind.
+func Kind() string {
+	return string(genv1alpha1.GeneratorKindHarborRobotAccount)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package harbor
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// fakeHarbor is a minimal fake of the robot account endpoints of the Harbor API.
+type fakeHarbor struct {
+	mu     sync.Mutex
+	nextID int64
+	robots map[int64]robot
+}
+
+func (f *fakeHarbor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, ok := r.BasicAuth()
+	if !ok || user != "admin" || pass != "harbor-password" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"UNAUTHORIZED","message":"unauthorized"}]}`))
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == robotsPath:
+		var rb robot
+		if err := json.NewDecoder(r.Body).Decode(&rb); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		f.robots[f.nextID] = rb
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(robotCreated{
+			ID:     f.nextID,
+			Name:   "robot$" + rb.Permissions[0].Namespace + "+" + rb.Name,
+			Secret: "secret-" + strconv.FormatInt(f.nextID, 10),
+		})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, robotsPath+"/"):
+		id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, robotsPath+"/"), 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, ok := f.robots[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.robots, id)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newSpec(t *testing.T, serverURL, passwordSecret string) *apiextensions.JSON {
+	t.Helper()
+	raw, err := json.Marshal(&genv1alpha1.HarborRobotAccount{
+		Spec: genv1alpha1.HarborRobotAccountSpec{
+			URL: serverURL,
+			Auth: genv1alpha1.HarborAuth{
+				Basic: genv1alpha1.HarborBasicAuth{
+					Username: "admin",
+					Password: genv1alpha1.SecretKeySelector{Name: passwordSecret, Key: "password"},
+				},
+			},
+			Project:     "apps",
+			NamePrefix:  "pull",
+			Duration:    7,
+			Permissions: []genv1alpha1.HarborRobotPermission{{Resource: "repository", Action: "pull"}},
+		},
+	})
+	require.NoError(t, err)
+	return &apiextensions.JSON{Raw: raw}
+}
+
+func TestGenerateAndCleanup(t *testing.T) {
+	harbor := &fakeHarbor{robots: map[int64]robot{}}
+	server := httptest.NewServer(harbor)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "harbor-admin", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("harbor-password")},
+	}).Build()
+	spec := newSpec(t, server.URL, "harbor-admin")
+	g := &Generator{}
+
+	data, state, err := g.Generate(context.Background(), spec, kube, "default")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	require.Len(t, harbor.robots, 1)
+	created := harbor.robots[1]
+	assert.True(t, strings.HasPrefix(created.Name, "pull-"))
+	assert.Equal(t, int64(7), created.Duration)
+	assert.Equal(t, robotLevel, created.Level)
+	require.Len(t, created.Permissions, 1)
+	assert.Equal(t, "apps", created.Permissions[0].Namespace)
+	assert.Equal(t, []robotAccess{{Resource: "repository", Action: "pull"}}, created.Permissions[0].Access)
+
+	username := "robot$apps+" + created.Name
+	assert.Equal(t, serverURL.Host, string(data[keyRegistry]))
+	assert.Equal(t, username, string(data[keyUsername]))
+	assert.Equal(t, "secret-1", string(data[keyPassword]))
+	assert.Equal(t, b64.StdEncoding.EncodeToString([]byte(username+":secret-1")), string(data[keyAuth]))
+
+	var parsed genv1alpha1.HarborRobotAccountState
+	require.NoError(t, json.Unmarshal(state.Raw, &parsed))
+	assert.Equal(t, int64(1), parsed.RobotID)
+	assert.Equal(t, server.URL, parsed.URL)
+
+	// every generation creates a new robot account
+	_, _, err = g.Generate(context.Background(), spec, kube, "default")
+	require.NoError(t, err)
+	require.Len(t, harbor.robots, 2)
+	assert.NotEqual(t, harbor.robots[1].Name, harbor.robots[2].Name)
+
+	// the robot account is deleted in the Harbor instance of the state, even if the spec changed
+	require.NoError(t, g.Cleanup(context.Background(), newSpec(t, "http://127.0.0.1:1", "harbor-admin"), state, kube, "default"))
+	assert.Len(t, harbor.robots, 1)
+	assert.NotContains(t, harbor.robots, int64(1))
+
+	// deleting a robot account that does not exist anymore succeeds
+	require.NoError(t, g.Cleanup(context.Background(), spec, state, kube, "default"))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	harbor := &fakeHarbor{robots: map[int64]robot{}}
+	server := httptest.NewServer(harbor)
+	defer server.Close()
+
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "wrong-password", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("nope")},
+	}).Build()
+
+	tests := []struct {
+		name        string
+		spec        *apiextensions.JSON
+		expectedErr string
+	}{
+		{
+			name:        "no spec",
+			expectedErr: errNoSpec,
+		},
+		{
+			name:        "missing password secret",
+			spec:        newSpec(t, server.URL, "missing"),
+			expectedErr: "unable to get password",
+		},
+		{
+			name:        "unauthorized",
+			spec:        newSpec(t, server.URL, "wrong-password"),
+			expectedErr: "unable to create robot account: unexpected status code 401",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := (&Generator{}).Generate(context.Background(), tt.spec, kube, "default")
+			require.ErrorContains(t, err, tt.expectedErr)
+			assert.Empty(t, harbor.robots)
+		})
+	}
+}
+
+func TestCleanupWithoutState(t *testing.T) {
+	err := (&Generator{}).Cleanup(context.Background(), &apiextensions.JSON{Raw: []byte(`{}`)}, nil, nil, "default")
+	require.EqualError(t, err, errMissingState)
+}
@@ -0,0 +1,402 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pipeline provides a generator that runs several generators in order
+// and feeds the output of each step into the spec of the following steps.
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	tpl "text/template"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+	"github.com/external-secrets/external-secrets/runtime/statemanager"
+	estemplate "github.com/external-secrets/external-secrets/runtime/template/v2"
+)
+
+// Generator runs the steps of a GeneratorPipeline.
+type Generator struct{}
+
+const (
+	errNoSpec        = "no config spec provided"
+	errParseSpec     = "unable to parse spec: %w"
+	errParseState    = "unable to parse state: %w"
+	errNoKubeClient  = "a kubernetes client is required to resolve pipeline steps"
+	errNestedPipe    = "step %q: pipelines can not be nested"
+	errResolveStep   = "step %q: %w"
+	errRenderStep    = "step %q: unable to render spec: %w"
+	errGenerateStep  = "step %q: %w"
+	errCleanupStep   = "step %q: unable to clean up: %w"
+	errUnknownKind   = "unknown generator kind %q"
+	errStepSpecField = "spec of the referenced generator is not an object"
+)
+
+// Generate runs all steps of the pipeline.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	return g.GenerateWithState(ctx, jsonSpec, nil, kube, namespace)
+}
+
+// GenerateWithState runs all steps of the pipeline. The state of every step
+// is passed to the step generator if it implements genv1alpha1.StatefulGenerator.
+// The steps are rolled back within the statemanager transaction carried by ctx,
+// so a failing step rolls back every step as well as the other generators of
+// the reconciled resource. Without a transaction, the pipeline rolls back its own steps.
+func (g *Generator) GenerateWithState(ctx context.Context, jsonSpec *apiextensions.JSON, previous genv1alpha1.GeneratorProviderState, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	if jsonSpec == nil {
+		return nil, nil, errors.New(errNoSpec)
+	}
+	if kube == nil {
+		return nil, nil, errors.New(errNoKubeClient)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errParseSpec, err)
+	}
+	previousState, err := parseState(previous)
+	if err != nil {
+		return nil, nil, err
+	}
+	previousSteps := make(map[string]*apiextensions.JSON, len(previousState.Steps))
+	for _, step := range previousState.Steps {
+		previousSteps[step.Name] = step.State
+	}
+
+	genState, shared := statemanager.FromContext(ctx)
+	if !shared {
+		genState = statemanager.New(ctx, kube, kube.Scheme(), namespace, nil)
+	}
+	var (
+		state     genv1alpha1.GeneratorPipelineState
+		completed bool
+	)
+	// once the pipeline returned its state, rolling it back is up to the caller,
+	// which cleans up all steps through Cleanup.
+	genState.EnqueueRollback(func() error {
+		if completed {
+			return nil
+		}
+		return cleanup(ctx, &state, kube, namespace)
+	})
+	fail := func(err error) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+		if shared {
+			return nil, nil, err
+		}
+		return nil, nil, errors.Join(err, genState.Rollback())
+	}
+
+	outputs := make(map[string]map[string]string, len(res.Spec.Steps))
+	data := make(map[string][]byte)
+	for _, step := range res.Spec.Steps {
+		stepData, stepState, err := g.runStep(ctx, step, outputs, previousSteps[step.Name], kube, namespace)
+		if err != nil {
+			return fail(err)
+		}
+		if stepState.State != nil {
+			state.Steps = append(state.Steps, *stepState)
+		}
+		output := make(map[string]string, len(stepData))
+		for k, v := range stepData {
+			output[k] = string(v)
+		}
+		outputs[step.Name] = output
+		maps.Copy(data, stepData)
+	}
+
+	if len(state.Steps) == 0 {
+		completed = true
+		return data, nil, nil
+	}
+	rawState, err := json.Marshal(state)
+	if err != nil {
+		return fail(err)
+	}
+	completed = true
+	return data, &apiextensions.JSON{Raw: rawState}, nil
+}
+
+func (g *Generator) runStep(
+	ctx context.Context,
+	step genv1alpha1.GeneratorPipelineStep,
+	outputs map[string]map[string]string,
+	previous genv1alpha1.GeneratorProviderState,
+	kube client.Client,
+	namespace string,
+) (map[string][]byte, *genv1alpha1.GeneratorPipelineStepState, error) {
+	impl, resource, err := resolvers.GeneratorRef(ctx, kube, kube.Scheme(), namespace, &esv1.GeneratorRef{
+		APIVersion: step.GeneratorRef.APIVersion,
+		Kind:       step.GeneratorRef.Kind,
+		Name:       step.GeneratorRef.Name,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf(errResolveStep, step.Name, err)
+	}
+	// the ClusterGenerator is resolved to the generator kind it wraps.
+	if _, ok := impl.(*Generator); ok {
+		return nil, nil, fmt.Errorf(errNestedPipe, step.Name)
+	}
+	kind, err := resourceKind(resource)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errResolveStep, step.Name, err)
+	}
+	if kind == "" {
+		kind = step.GeneratorRef.Kind
+	}
+	// the state keeps the manifest with the static values of the step spec, so that
+	// cleanup targets the same resources. Templated values are left out, as they
+	// may render values generated by previous steps.
+	manifest, err := renderResource(resource, staticSpec(step.Spec), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errRenderStep, step.Name, err)
+	}
+	resource, err = renderResource(resource, step.Spec, outputs)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errRenderStep, step.Name, err)
+	}
+
+	var (
+		data  map[string][]byte
+		state genv1alpha1.GeneratorProviderState
+	)
+	if statefulGen, ok := impl.(genv1alpha1.StatefulGenerator); ok {
+		data, state, err = statefulGen.GenerateWithState(ctx, resource, previous, kube, namespace)
+	} else {
+		data, state, err = impl.Generate(ctx, resource, kube, namespace)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf(errGenerateStep, step.Name, err)
+	}
+	return data, &genv1alpha1.GeneratorPipelineStepState{
+		Name:     step.Name,
+		Kind:     kind,
+		Resource: manifest,
+		State:    state,
+	}, nil
+}
+
+// renderResource renders the step spec with the outputs of the previous steps
+// and merges it into the spec of the generator resource.
+func renderResource(resource, stepSpec *apiextensions.JSON, outputs map[string]map[string]string) (*apiextensions.JSON, error) {
+	if stepSpec == nil || len(stepSpec.Raw) == 0 {
+		return resource, nil
+	}
+	var override any
+	if err := json.Unmarshal(stepSpec.Raw, &override); err != nil {
+		return nil, err
+	}
+	override, err := render(override, outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(resource.Raw, &obj); err != nil {
+		return nil, err
+	}
+	spec, ok := obj["spec"].(map[string]any)
+	if !ok && obj["spec"] != nil {
+		return nil, errors.New(errStepSpecField)
+	}
+	overrideSpec, ok := override.(map[string]any)
+	if !ok {
+		return nil, errors.New(errStepSpecField)
+	}
+	obj["spec"] = merge(spec, overrideSpec)
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &apiextensions.JSON{Raw: raw}, nil
+}
+
+// staticSpec returns the step spec without the string values that are templates.
+func staticSpec(stepSpec *apiextensions.JSON) *apiextensions.JSON {
+	if stepSpec == nil || len(stepSpec.Raw) == 0 {
+		return nil
+	}
+	var spec any
+	if err := json.Unmarshal(stepSpec.Raw, &spec); err != nil {
+		// the step spec is rendered afterwards, which reports the error.
+		return nil
+	}
+	static, ok := staticValue(spec)
+	if !ok {
+		return nil
+	}
+	raw, err := json.Marshal(static)
+	if err != nil {
+		return nil
+	}
+	return &apiextensions.JSON{Raw: raw}
+}
+
+// staticValue removes the templated values of objects. It returns false if the value
+// is a template or a list that contains one, as lists are replaced as a whole.
+func staticValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, !strings.Contains(v, "{{")
+	case map[string]any:
+		for k, val := range v {
+			if _, ok := staticValue(val); !ok {
+				delete(v, k)
+			}
+		}
+		return v, true
+	case []any:
+		for _, val := range v {
+			if _, ok := staticValue(val); !ok {
+				return nil, false
+			}
+		}
+		return v, true
+	default:
+		return v, true
+	}
+}
+
+// render executes all string values of the given JSON value as templates.
+func render(value any, outputs map[string]map[string]string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		t, err := tpl.New("step").
+			Funcs(estemplate.FuncMap()).
+			Option("missingkey=error").
+			Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, outputs); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	case map[string]any:
+		for k, val := range v {
+			rendered, err := render(val, outputs)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = rendered
+		}
+		return v, nil
+	case []any:
+		for i, val := range v {
+			rendered, err := render(val, outputs)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// merge merges src into dst. Nested objects are merged, all other values are replaced.
+func merge(dst, src map[string]any) map[string]any {
+	if dst == nil {
+		dst = make(map[string]any, len(src))
+	}
+	for k, v := range src {
+		srcMap, srcOk := v.(map[string]any)
+		dstMap, dstOk := dst[k].(map[string]any)
+		if srcOk && dstOk {
+			dst[k] = merge(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+// Cleanup cleans up the resources of all steps in reverse order.
+func (g *Generator) Cleanup(ctx context.Context, _ *apiextensions.JSON, status genv1alpha1.GeneratorProviderState, kube client.Client, namespace string) error {
+	state, err := parseState(status)
+	if err != nil {
+		return err
+	}
+	return cleanup(ctx, state, kube, namespace)
+}
+
+func cleanup(ctx context.Context, state *genv1alpha1.GeneratorPipelineState, kube client.Client, namespace string) error {
+	var errs []error
+	for i := len(state.Steps) - 1; i >= 0; i-- {
+		step := state.Steps[i]
+		impl, ok := genv1alpha1.GetGeneratorByName(step.Kind)
+		if !ok {
+			errs = append(errs, fmt.Errorf(errCleanupStep, step.Name, fmt.Errorf(errUnknownKind, step.Kind)))
+			continue
+		}
+		err := impl.Cleanup(ctx, step.Resource, step.State, kube, namespace)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(errCleanupStep, step.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resourceKind returns the kind of the resolved generator manifest.
+// The ClusterGenerator is resolved to a manifest of the kind it wraps.
+func resourceKind(resource *apiextensions.JSON) (string, error) {
+	var obj struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(resource.Raw, &obj); err != nil {
+		return "", err
+	}
+	return obj.Kind, nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.GeneratorPipeline, error) {
+	var spec genv1alpha1.GeneratorPipeline
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func parseState(state genv1alpha1.GeneratorProviderState) (*genv1alpha1.GeneratorPipelineState, error) {
+	var parsed genv1alpha1.GeneratorPipelineState
+	if state == nil || len(state.Raw) == 0 {
+		return &parsed, nil
+	}
+	if err := json.Unmarshal(state.Raw, &parsed); err != nil {
+		return nil, fmt.Errorf(errParseState, err)
+	}
+	return &parsed, nil
+}
+
+// NewGenerator creates a new Generator instance.
+func NewGenerator() genv1alpha1.Generator {
+	return &Generator{}
+}
+
+// Kind returns the generator kind.
+func Kind() string {
+	return string(genv1alpha1.GeneratorKindGeneratorPipeline)
+}
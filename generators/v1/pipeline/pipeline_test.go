@@ -0,0 +1,254 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/runtime/statemanager"
+)
+
+const testNamespace = "default"
+
+// testGenerator returns the data of its Fake spec and records cleanups.
+// It fails if the data contains the key "fail".
+type testGenerator struct {
+	cleanups  []string
+	resources []string
+	previous  []string
+}
+
+func (g *testGenerator) Generate(ctx context.Context, obj *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	return g.GenerateWithState(ctx, obj, nil, kube, namespace)
+}
+
+func (g *testGenerator) GenerateWithState(_ context.Context, obj *apiextensions.JSON, previous genv1alpha1.GeneratorProviderState, _ client.Client, _ string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	var res genv1alpha1.Fake
+	if err := json.Unmarshal(obj.Raw, &res); err != nil {
+		return nil, nil, err
+	}
+	if _, ok := res.Spec.Data["fail"]; ok {
+		return nil, nil, errors.New("boom")
+	}
+	if previous != nil {
+		g.previous = append(g.previous, string(previous.Raw))
+	}
+	data := make(map[string][]byte, len(res.Spec.Data))
+	for k, v := range res.Spec.Data {
+		data[k] = []byte(v)
+	}
+	return data, &apiextensions.JSON{Raw: []byte(`"` + res.Name + `"`)}, nil
+}
+
+func (g *testGenerator) Cleanup(_ context.Context, obj *apiextensions.JSON, state genv1alpha1.GeneratorProviderState, _ client.Client, _ string) error {
+	g.cleanups = append(g.cleanups, string(state.Raw))
+	g.resources = append(g.resources, string(obj.Raw))
+	return nil
+}
+
+func newTestClient(t *testing.T, fakes ...*genv1alpha1.Fake) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, genv1alpha1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, f := range fakes {
+		builder = builder.WithObjects(f)
+	}
+	return builder.Build()
+}
+
+func newFake(name string, data map[string]string) *genv1alpha1.Fake {
+	return &genv1alpha1.Fake{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec:       genv1alpha1.FakeSpec{Data: data},
+	}
+}
+
+func pipelineSpec(t *testing.T, steps ...genv1alpha1.GeneratorPipelineStep) *apiextensions.JSON {
+	t.Helper()
+	raw, err := json.Marshal(genv1alpha1.GeneratorPipeline{
+		Spec: genv1alpha1.GeneratorPipelineSpec{Steps: steps},
+	})
+	require.NoError(t, err)
+	return &apiextensions.JSON{Raw: raw}
+}
+
+func step(name, fakeName, spec string) genv1alpha1.GeneratorPipelineStep {
+	s := genv1alpha1.GeneratorPipelineStep{
+		Name: name,
+		GeneratorRef: genv1alpha1.GeneratorPipelineStepRef{
+			APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+			Kind:       genv1alpha1.FakeKind,
+			Name:       fakeName,
+		},
+	}
+	if spec != "" {
+		s.Spec = &apiextensions.JSON{Raw: []byte(spec)}
+	}
+	return s
+}
+
+func TestGenerate(t *testing.T) {
+	testGen := &testGenerator{}
+	genv1alpha1.ForceRegister(genv1alpha1.FakeKind, testGen)
+	kube := newTestClient(t,
+		newFake("password", map[string]string{"password": "s3cr3t"}),
+		newFake("hash", map[string]string{"user": "admin"}),
+		newFake("broken", map[string]string{"fail": "true"}),
+	)
+	g := &Generator{}
+
+	t.Run("nil spec should return error", func(t *testing.T) {
+		_, _, err := g.Generate(context.Background(), nil, kube, testNamespace)
+		require.EqualError(t, err, errNoSpec)
+	})
+
+	t.Run("steps template previous outputs", func(t *testing.T) {
+		spec := pipelineSpec(t,
+			step("pw", "password", ""),
+			step("hash", "hash", `{"data":{"auth":"{{ .later.password }}"}}`),
+			step("later", "password", ""),
+		)
+		_, _, err := g.Generate(context.Background(), spec, kube, testNamespace)
+		require.Error(t, err, "referencing the output of a later step must fail")
+
+		spec = pipelineSpec(t,
+			step("pw", "password", ""),
+			step("hash", "hash", `{"data":{"auth":"{{ .pw.password | upper }}"}}`),
+		)
+		data, state, err := g.Generate(context.Background(), spec, kube, testNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{
+			"password": []byte("s3cr3t"),
+			"user":     []byte("admin"),
+			"auth":     []byte("S3CR3T"),
+		}, data)
+
+		parsed, err := parseState(state)
+		require.NoError(t, err)
+		require.Len(t, parsed.Steps, 2)
+		assert.Equal(t, "pw", parsed.Steps[0].Name)
+		assert.Equal(t, "hash", parsed.Steps[1].Name)
+		// the rendered spec of a step is not stored in the state.
+		assert.NotContains(t, string(parsed.Steps[1].Resource.Raw), "S3CR3T")
+
+		// the state of every step is handed to the step on the next run.
+		testGen.previous = nil
+		_, _, err = g.GenerateWithState(context.Background(), spec, state, kube, testNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`"password"`, `"hash"`}, testGen.previous)
+
+		// cleanup runs in reverse order.
+		testGen.cleanups = nil
+		require.NoError(t, g.Cleanup(context.Background(), spec, state, kube, testNamespace))
+		assert.Equal(t, []string{`"hash"`, `"password"`}, testGen.cleanups)
+	})
+
+	t.Run("cleanup uses the static values of the step spec", func(t *testing.T) {
+		spec := pipelineSpec(t,
+			step("pw", "password", ""),
+			step("hash", "hash", `{"data":{"url":"https://override.example.com","auth":"{{ .pw.password }}"}}`),
+		)
+		_, state, err := g.Generate(context.Background(), spec, kube, testNamespace)
+		require.NoError(t, err)
+
+		testGen.resources = nil
+		require.NoError(t, g.Cleanup(context.Background(), spec, state, kube, testNamespace))
+		require.Len(t, testGen.resources, 2)
+		var res genv1alpha1.Fake
+		require.NoError(t, json.Unmarshal([]byte(testGen.resources[0]), &res))
+		assert.Equal(t, map[string]string{"user": "admin", "url": "https://override.example.com"}, res.Spec.Data,
+			"the override is used for cleanup, the templated value is not stored")
+	})
+
+	t.Run("failing step rolls back previous steps", func(t *testing.T) {
+		testGen.cleanups = nil
+		spec := pipelineSpec(t,
+			step("pw", "password", ""),
+			step("hash", "hash", ""),
+			step("broken", "broken", ""),
+		)
+		_, _, err := g.Generate(context.Background(), spec, kube, testNamespace)
+		require.ErrorContains(t, err, `step "broken": boom`)
+		assert.Equal(t, []string{`"hash"`, `"password"`}, testGen.cleanups)
+	})
+
+	t.Run("failing step is rolled back with the shared transaction", func(t *testing.T) {
+		testGen.cleanups = nil
+		genState := statemanager.New(context.Background(), kube, kube.Scheme(), testNamespace, nil)
+		ctx := statemanager.WithManager(context.Background(), genState)
+		spec := pipelineSpec(t,
+			step("pw", "password", ""),
+			step("hash", "hash", ""),
+			step("broken", "broken", ""),
+		)
+		_, _, err := g.Generate(ctx, spec, kube, testNamespace)
+		require.ErrorContains(t, err, `step "broken": boom`)
+		assert.Empty(t, testGen.cleanups, "the steps must be rolled back by the transaction")
+		require.NoError(t, genState.Rollback())
+		assert.Equal(t, []string{`"hash"`, `"password"`}, testGen.cleanups)
+	})
+
+	t.Run("completed pipeline is rolled back by its caller", func(t *testing.T) {
+		testGen.cleanups = nil
+		genState := statemanager.New(context.Background(), kube, kube.Scheme(), testNamespace, nil)
+		ctx := statemanager.WithManager(context.Background(), genState)
+		spec := pipelineSpec(t, step("pw", "password", ""))
+		_, state, err := g.Generate(ctx, spec, kube, testNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, state)
+		require.NoError(t, genState.Rollback())
+		assert.Empty(t, testGen.cleanups)
+	})
+
+	t.Run("missing generator should return error", func(t *testing.T) {
+		spec := pipelineSpec(t, step("missing", "does-not-exist", ""))
+		_, _, err := g.Generate(context.Background(), spec, kube, testNamespace)
+		require.ErrorContains(t, err, `step "missing"`)
+	})
+}
+
+func TestRenderResource(t *testing.T) {
+	resource := &apiextensions.JSON{Raw: []byte(`{"kind":"Password","spec":{"length":12,"symbols":{"count":2,"chars":"-_"}}}`)}
+	outputs := map[string]map[string]string{"key": {"value": "abc"}}
+
+	rendered, err := renderResource(resource, &apiextensions.JSON{Raw: []byte(`{"symbols":{"chars":"{{ .key.value }}"},"noUpper":true}`)}, outputs)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"kind":"Password","spec":{"length":12,"noUpper":true,"symbols":{"count":2,"chars":"abc"}}}`, string(rendered.Raw))
+
+	_, err = renderResource(resource, &apiextensions.JSON{Raw: []byte(`{"symbols":"{{ .unknown.value }}"}`)}, outputs)
+	require.Error(t, err)
+}
+
+func TestStaticSpec(t *testing.T) {
+	static := staticSpec(&apiextensions.JSON{Raw: []byte(`{"url":"https://a","auth":"{{ .pw.password }}","nested":{"a":1,"b":"{{ .x.y }}"},"list":["a","{{ .x.y }}"],"keep":["a"]}`)})
+	require.NotNil(t, static)
+	assert.JSONEq(t, `{"url":"https://a","nested":{"a":1},"keep":["a"]}`, string(static.Raw))
+	assert.Nil(t, staticSpec(nil))
+}
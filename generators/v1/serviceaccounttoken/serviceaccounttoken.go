@@ -0,0 +1,218 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package serviceaccounttoken provides a generator for Kubernetes ServiceAccount tokens
+// using the TokenRequest API.
+package serviceaccounttoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	k8sprovider "github.com/external-secrets/external-secrets/providers/v1/kubernetes"
+)
+
+// Generator implements ServiceAccount token generation using the TokenRequest API.
+type Generator struct{}
+
+type localConfigFunc func() (*rest.Config, error)
+
+type clientsetFactory func(cfg *rest.Config) (kubernetes.Interface, error)
+
+const (
+	keyToken               = "token"
+	keyCACert              = "ca.crt"
+	keyKubeconfig          = "kubeconfig"
+	keyExpirationTimestamp = "expirationTimestamp"
+
+	kubeconfigName   = "default"
+	defaultNamespace = "default"
+
+	errNoSpec         = "no config spec provided"
+	errParseSpec      = "unable to parse spec: %w"
+	errLocalConfig    = "unable to get config of the local cluster: %w"
+	errRemoteConfig   = "unable to get config of the remote cluster: %w"
+	errCreateClient   = "unable to create kubernetes client: %w"
+	errCreateToken    = "unable to create token for ServiceAccount %s/%s: %w"
+	errLoadCA         = "unable to load CA certificate: %w"
+	errKubeconfig     = "unable to create kubeconfig: %w"
+	errNoTokenInReply = "TokenRequest did not return a token"
+)
+
+// Generate requests a new ServiceAccount token.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace, ctrlcfg.GetConfig, newClientset)
+}
+
+func (g *Generator) generate(
+	ctx context.Context,
+	jsonSpec *apiextensions.JSON,
+	kube client.Client,
+	namespace string,
+	localConfig localConfigFunc,
+	clientsetFor clientsetFactory,
+) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	if jsonSpec == nil {
+		return nil, nil, errors.New(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errParseSpec, err)
+	}
+	spec := res.Spec
+
+	cfg, err := localConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf(errLocalConfig, err)
+	}
+	clientset, err := clientsetFor(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errCreateClient, err)
+	}
+
+	// by default the token is requested for a ServiceAccount in the namespace of the ExternalSecret.
+	saNamespace := namespace
+	if spec.Provider != nil {
+		cfg, err = k8sprovider.RESTConfig(ctx, spec.Provider, kube, clientset, esv1.SecretStoreKind, namespace)
+		if err != nil {
+			return nil, nil, fmt.Errorf(errRemoteConfig, err)
+		}
+		clientset, err = clientsetFor(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf(errCreateClient, err)
+		}
+		saNamespace = spec.Provider.RemoteNamespace
+		if saNamespace == "" {
+			saNamespace = defaultNamespace
+		}
+	}
+
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         spec.Audiences,
+			ExpirationSeconds: spec.ExpirationSeconds,
+		},
+	}
+	if ref := spec.BoundObjectRef; ref != nil {
+		tokenRequest.Spec.BoundObjectRef = &authenticationv1.BoundObjectReference{
+			APIVersion: "v1",
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			UID:        types.UID(ref.UID),
+		}
+	}
+	tr, err := clientset.CoreV1().ServiceAccounts(saNamespace).CreateToken(ctx, spec.ServiceAccountName, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf(errCreateToken, saNamespace, spec.ServiceAccountName, err)
+	}
+	if tr.Status.Token == "" {
+		return nil, nil, errors.New(errNoTokenInReply)
+	}
+
+	ca, err := caData(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errLoadCA, err)
+	}
+	kubeconfig, err := buildKubeconfig(cfg.Host, ca, tr.Status.Token, saNamespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errKubeconfig, err)
+	}
+
+	return map[string][]byte{
+		keyToken:               []byte(tr.Status.Token),
+		keyCACert:              ca,
+		keyKubeconfig:          kubeconfig,
+		keyExpirationTimestamp: []byte(tr.Status.ExpirationTimestamp.UTC().Format(time.RFC3339)),
+	}, nil, nil
+}
+
+// ExpiresAt returns the expiration time of the generated token,
+// so the token is refreshed before it expires.
+func (g *Generator) ExpiresAt(data map[string][]byte) (time.Time, bool) {
+	exp, err := time.Parse(time.RFC3339, string(data[keyExpirationTimestamp]))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return exp, true
+}
+
+// Cleanup is a no-op, tokens expire on their own.
+func (g *Generator) Cleanup(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) error {
+	return nil
+}
+
+// caData returns the CA certificate of the cluster, loading it from file if needed.
+func caData(cfg *rest.Config) ([]byte, error) {
+	if len(cfg.CAData) > 0 || cfg.CAFile == "" {
+		return cfg.CAData, nil
+	}
+	return os.ReadFile(cfg.CAFile)
+}
+
+func buildKubeconfig(server string, ca []byte, token, namespace string) ([]byte, error) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters[kubeconfigName] = &clientcmdapi.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: ca,
+	}
+	config.AuthInfos[kubeconfigName] = &clientcmdapi.AuthInfo{
+		Token: token,
+	}
+	config.Contexts[kubeconfigName] = &clientcmdapi.Context{
+		Cluster:   kubeconfigName,
+		AuthInfo:  kubeconfigName,
+		Namespace: namespace,
+	}
+	config.CurrentContext = kubeconfigName
+	return clientcmd.Write(*config)
+}
+
+func newClientset(cfg *rest.Config) (kubernetes.Interface, error) {
+	return kubernetes.NewForConfig(cfg)
+}
+
+func parseSpec(data []byte) (*genv1alpha1.ServiceAccountToken, error) {
+	var spec genv1alpha1.ServiceAccountToken
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+// NewGenerator creates a new Generator instance.
+func NewGenerator() genv1alpha1.Generator {
+	return &Generator{}
+}
+
+// Kind returns the generator kind.
+func Kind() string {
+	return string(genv1alpha1.GeneratorKindServiceAccountToken)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccounttoken
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	localHost  = "https://local.example.com"
+	remoteHost = "https://remote.example.com"
+)
+
+// tokenRequests records the token requests per host.
+type tokenRequests map[string][]*authenticationv1.TokenRequest
+
+func fakeClientsets(requests tokenRequests, expiration time.Time) clientsetFactory {
+	return func(cfg *rest.Config) (kubernetes.Interface, error) {
+		cs := k8sfake.NewClientset()
+		cs.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			create := action.(k8stesting.CreateAction)
+			if create.GetSubresource() != "token" {
+				return false, nil, nil
+			}
+			tr := create.GetObject().(*authenticationv1.TokenRequest).DeepCopy()
+			if create.GetNamespace() == "missing" {
+				return true, nil, errors.New("serviceaccount not found")
+			}
+			requests[cfg.Host] = append(requests[cfg.Host], tr)
+			tr.Status = authenticationv1.TokenRequestStatus{
+				Token:               "token-" + create.GetNamespace() + "@" + cfg.Host,
+				ExpirationTimestamp: metav1.NewTime(expiration),
+			}
+			return true, tr, nil
+		})
+		return cs, nil
+	}
+}
+
+func localConfig() (*rest.Config, error) {
+	return &rest.Config{
+		Host: localHost,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: []byte("local-ca"),
+		},
+	}, nil
+}
+
+func TestGenerate(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).Truncate(time.Second)
+	remoteCA := selfSignedCert(t)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-auth", Namespace: "apps"},
+		Data:       map[string][]byte{"token": []byte("remote-bearer")},
+	}).Build()
+
+	tests := []struct {
+		name        string
+		spec        string
+		namespace   string
+		expectedErr string
+		validate    func(t *testing.T, data map[string][]byte, requests tokenRequests)
+	}{
+		{
+			name:      "local cluster",
+			namespace: "apps",
+			spec: `{"spec":{"serviceAccountName":"app","audiences":["vault"],"expirationSeconds":600,
+				"boundObjectRef":{"kind":"Secret","name":"bound"}}}`,
+			validate: func(t *testing.T, data map[string][]byte, requests tokenRequests) {
+				assert.Equal(t, "token-apps@"+localHost, string(data[keyToken]))
+				assert.Equal(t, "local-ca", string(data[keyCACert]))
+				assert.Equal(t, expiration.UTC().Format(time.RFC3339), string(data[keyExpirationTimestamp]))
+
+				require.Len(t, requests[localHost], 1)
+				req := requests[localHost][0]
+				assert.Equal(t, []string{"vault"}, req.Spec.Audiences)
+				assert.Equal(t, int64(600), *req.Spec.ExpirationSeconds)
+				require.NotNil(t, req.Spec.BoundObjectRef)
+				assert.Equal(t, "Secret", req.Spec.BoundObjectRef.Kind)
+				assert.Equal(t, "bound", req.Spec.BoundObjectRef.Name)
+
+				cfg, err := clientcmd.RESTConfigFromKubeConfig(data[keyKubeconfig])
+				require.NoError(t, err)
+				assert.Equal(t, localHost, cfg.Host)
+				assert.Equal(t, string(data[keyToken]), cfg.BearerToken)
+				assert.Equal(t, "local-ca", string(cfg.CAData))
+			},
+		},
+		{
+			name:      "remote cluster",
+			namespace: "apps",
+			spec: `{"spec":{"serviceAccountName":"app","provider":{
+				"server":{"url":"` + remoteHost + `","caBundle":"` + base64.StdEncoding.EncodeToString(remoteCA) + `"},
+				"auth":{"token":{"bearerToken":{"name":"remote-auth","key":"token"}}},
+				"remoteNamespace":"remote-apps"}}}`,
+			validate: func(t *testing.T, data map[string][]byte, requests tokenRequests) {
+				assert.Equal(t, "token-remote-apps@"+remoteHost, string(data[keyToken]))
+				assert.Equal(t, string(remoteCA), string(data[keyCACert]))
+				assert.Empty(t, requests[localHost])
+				require.Len(t, requests[remoteHost], 1)
+
+				cfg, err := clientcmd.Load(data[keyKubeconfig])
+				require.NoError(t, err)
+				assert.Equal(t, "remote-apps", cfg.Contexts[cfg.CurrentContext].Namespace)
+			},
+		},
+		{
+			name:        "missing remote auth secret",
+			namespace:   "other",
+			spec:        `{"spec":{"serviceAccountName":"app","provider":{"server":{"url":"` + remoteHost + `"},"auth":{"token":{"bearerToken":{"name":"remote-auth","key":"token"}}}}}}`,
+			expectedErr: "unable to get config of the remote cluster",
+		},
+		{
+			name:        "token request fails",
+			namespace:   "missing",
+			spec:        `{"spec":{"serviceAccountName":"app"}}`,
+			expectedErr: "unable to create token for ServiceAccount missing/app",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			requests := tokenRequests{}
+			data, state, err := g.generate(context.Background(), &apiextensions.JSON{Raw: []byte(tt.spec)}, kube, tt.namespace, localConfig, fakeClientsets(requests, expiration))
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, state)
+			tt.validate(t, data, requests)
+
+			expiresAt, ok := g.ExpiresAt(data)
+			require.True(t, ok)
+			assert.True(t, expiresAt.Equal(expiration))
+		})
+	}
+}
+
+func selfSignedCert(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "remote-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestGenerateNoSpec(t *testing.T) {
+	_, _, err := (&Generator{}).Generate(context.Background(), nil, nil, "")
+	require.EqualError(t, err, errNoSpec)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the access of the controllers to the secrets of the providers.
+// Every read, push and delete is recorded as a structured event, values are never recorded.
+package audit
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+// Operation is the call to the provider an event records.
+type Operation string
+
+const (
+	// OperationGetSecret records the read of a single secret.
+	OperationGetSecret Operation = "GetSecret"
+	// OperationGetSecretMap records the read of the key/value pairs of a secret.
+	OperationGetSecretMap Operation = "GetSecretMap"
+	// OperationGetAllSecrets records the read of all secrets that match a find.
+	OperationGetAllSecrets Operation = "GetAllSecrets"
+	// OperationPushSecret records the write of a secret.
+	OperationPushSecret Operation = "PushSecret"
+	// OperationDeleteSecret records the delete of a secret.
+	OperationDeleteSecret Operation = "DeleteSecret"
+)
+
+// Outcome is the result of the call to the provider.
+type Outcome string
+
+const (
+	// OutcomeSuccess means the call succeeded.
+	OutcomeSuccess Outcome = "Success"
+	// OutcomeNotFound means the secret does not exist.
+	OutcomeNotFound Outcome = "NotFound"
+	// OutcomeError means the call failed.
+	OutcomeError Outcome = "Error"
+)
+
+// Event is the record of a call to a provider.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Operation Operation `json:"operation"`
+
+	// Kind, Namespace and Name identify the ExternalSecret or PushSecret the call has been made for.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	StoreKind string `json:"storeKind"`
+	StoreName string `json:"storeName"`
+
+	RemoteKey string `json:"remoteKey,omitempty"`
+	Property  string `json:"property,omitempty"`
+	Version   string `json:"version,omitempty"`
+	// Keys are the keys a read returned, e.g. the names of the secrets found by GetAllSecrets.
+	Keys []string `json:"keys,omitempty"`
+
+	Outcome        Outcome `json:"outcome"`
+	Error          string  `json:"error,omitempty"`
+	LatencySeconds float64 `json:"latencySeconds"`
+}
+
+// Sink writes audit events to a destination.
+type Sink interface {
+	// Write writes a single event. It must not block for long, as it is called while reconciling.
+	Write(event Event) error
+	// Close flushes buffered events and releases the sink.
+	Close() error
+}
+
+// Auditor records events to all of its sinks.
+// A nil Auditor records nothing, so callers do not need to check whether auditing is enabled.
+type Auditor struct {
+	log   logr.Logger
+	sinks []Sink
+}
+
+// New creates an Auditor that writes to the given sinks.
+func New(log logr.Logger, sinks ...Sink) *Auditor {
+	return &Auditor{
+		log:   log,
+		sinks: sinks,
+	}
+}
+
+// Record completes an event with the time, latency and outcome of a call that started at start
+// and returned err, and writes it to all sinks. Errors of the sinks are logged, they never fail the call.
+func (a *Auditor) Record(event Event, start time.Time, err error) {
+	if a == nil {
+		return
+	}
+	now := time.Now()
+	event.Time = now.UTC()
+	event.LatencySeconds = now.Sub(start).Seconds()
+	switch {
+	case err == nil:
+		event.Outcome = OutcomeSuccess
+	case errors.Is(err, esv1.NoSecretErr):
+		event.Outcome = OutcomeNotFound
+	default:
+		event.Outcome = OutcomeError
+		event.Error = err.Error()
+	}
+	for _, sink := range a.sinks {
+		if err := sink.Write(event); err != nil {
+			a.log.Error(err, "unable to write audit event", "operation", event.Operation, "kind", event.Kind, "namespace", event.Namespace, "name", event.Name)
+		}
+	}
+}
+
+// Close closes all sinks.
+func (a *Auditor) Close() error {
+	if a == nil {
+		return nil
+	}
+	var errs error
+	for _, sink := range a.sinks {
+		errs = errors.Join(errs, sink.Close())
+	}
+	return errs
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+type recordingSink struct {
+	events []Event
+}
+
+func (s *recordingSink) Write(event Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		want      Outcome
+		wantError string
+	}{
+		{
+			name: "success",
+			want: OutcomeSuccess,
+		},
+		{
+			name: "not found",
+			err:  fmt.Errorf("wrapped: %w", esv1.NoSecretErr),
+			want: OutcomeNotFound,
+		},
+		{
+			name:      "error",
+			err:       errors.New("access denied"),
+			want:      OutcomeError,
+			wantError: "access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &recordingSink{}
+			auditor := New(logr.Discard(), sink)
+			auditor.Record(Event{Operation: OperationGetSecret, RemoteKey: "db"}, time.Now().Add(-time.Second), tt.err)
+
+			if len(sink.events) != 1 {
+				t.Fatalf("got %d events, want 1", len(sink.events))
+			}
+			event := sink.events[0]
+			if event.Outcome != tt.want || event.Error != tt.wantError {
+				t.Errorf("outcome = %s (%q), want %s (%q)", event.Outcome, event.Error, tt.want, tt.wantError)
+			}
+			if event.LatencySeconds < 1 || event.Time.IsZero() || event.RemoteKey != "db" {
+				t.Errorf("unexpected event %+v", event)
+			}
+		})
+	}
+}
+
+func TestNilAuditor(t *testing.T) {
+	var auditor *Auditor
+	auditor.Record(Event{}, time.Now(), nil)
+	if err := auditor.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// AuditSubsystem is the subsystem of the audit log metrics.
+	AuditSubsystem = "audit"
+	// DroppedEventsKey is the metric key for the number of audit events that have not been delivered.
+	DroppedEventsKey = "dropped_events_total"
+)
+
+const (
+	dropReasonBufferFull = "buffer_full"
+	dropReasonSendFailed = "send_failed"
+)
+
+var droppedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: AuditSubsystem,
+	Name:      DroppedEventsKey,
+	Help:      "Total number of audit events the webhook sink has dropped, by reason",
+}, []string{"reason"})
+
+// SetUpMetrics is called at the root to register the audit log metrics.
+func SetUpMetrics() {
+	metrics.Registry.MustRegister(droppedEvents)
+}
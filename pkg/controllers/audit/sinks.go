@@ -0,0 +1,293 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// webhookTimeout is the timeout of a single request of the webhook sink.
+	webhookTimeout = 10 * time.Second
+	// webhookBufferBatches is the number of batches the webhook sink buffers before it drops events.
+	webhookBufferBatches = 10
+)
+
+var errWebhookBufferFull = errors.New("audit webhook buffer is full, the event has been dropped")
+
+// writerSink writes events as JSON lines to a writer, e.g. stdout.
+type writerSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterSink creates a sink that writes events as JSON lines to w.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{enc: json.NewEncoder(w)}
+}
+
+func (s *writerSink) Write(event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(event)
+}
+
+func (s *writerSink) Close() error {
+	return nil
+}
+
+// fileSink writes events as JSON lines to a file, and rotates the file once it reaches its maximum size.
+type fileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewFileSink creates a sink that appends events as JSON lines to the file at path.
+// Once the file would exceed maxSize bytes, it is renamed to path.1, existing backups are
+// shifted to path.2 and so on, and backups beyond maxBackups are removed.
+// A maxSize of 0 disables the rotation.
+func NewFileSink(path string, maxSize int64, maxBackups int) (Sink, error) {
+	s := &fileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open audit log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("unable to open audit log file: %w", err)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) Write(event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return errors.New("audit log file is closed")
+	}
+	var rotateErr error
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		rotateErr = s.rotate()
+		if s.file == nil {
+			return rotateErr
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return errors.Join(rotateErr, err)
+}
+
+// rotate closes the file, shifts the backups and opens a new file.
+// The file is reopened even if the rotation fails, so the events are appended to the current file
+// instead of being lost, and the rotation is retried with the next event.
+func (s *fileSink) rotate() error {
+	err := s.file.Close()
+	s.file = nil
+	if err == nil {
+		err = s.shiftBackups()
+	}
+	if openErr := s.open(); openErr != nil {
+		err = errors.Join(err, openErr)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to rotate audit log file: %w", err)
+	}
+	return nil
+}
+
+func (s *fileSink) shiftBackups() error {
+	if s.maxBackups == 0 {
+		return os.Remove(s.path)
+	}
+	for i := s.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(backupPath(s.path, i), backupPath(s.path, i+1))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(s.path, backupPath(s.path, 1))
+}
+
+func backupPath(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// webhookSink sends events in batches to an HTTP endpoint.
+type webhookSink struct {
+	log           logr.Logger
+	url           string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	events chan Event
+	done   chan struct{}
+}
+
+// NewWebhookSink creates a sink that POSTs events as a JSON array to url.
+// A batch is sent once it has batchSize events or flushInterval has passed since the last batch.
+// Events are buffered in memory and dropped if the endpoint can not keep up.
+func NewWebhookSink(log logr.Logger, url string, batchSize int, flushInterval time.Duration) Sink {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	s := &webhookSink{
+		log:           log,
+		url:           url,
+		client:        &http.Client{Timeout: webhookTimeout},
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		events:        make(chan Event, batchSize*webhookBufferBatches),
+		done:          make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *webhookSink) Write(event Event) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errors.New("audit webhook is closed")
+	}
+	select {
+	case s.events <- event:
+		return nil
+	default:
+		droppedEvents.WithLabelValues(dropReasonBufferFull).Inc()
+		return errWebhookBufferFull
+	}
+}
+
+func (s *webhookSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	batch := make([]Event, 0, s.batchSize)
+	for {
+		select {
+		case event, ok := <-s.events:
+			if !ok {
+				s.flush(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= s.batchSize {
+				s.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			s.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (s *webhookSink) flush(batch []Event) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := s.send(batch); err != nil {
+		droppedEvents.WithLabelValues(dropReasonSendFailed).Add(float64(len(batch)))
+		s.log.Error(err, "unable to send audit events", "url", s.url, "events", len(batch))
+	}
+}
+
+func (s *webhookSink) send(batch []Event) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+	s.mu.Unlock()
+	<-s.done
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	if err := sink.Write(Event{Operation: OperationPushSecret, Name: "ps"}); err != nil {
+		t.Fatal(err)
+	}
+	var got Event
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Operation != OperationPushSecret || got.Name != "ps" {
+		t.Errorf("unexpected event %+v", got)
+	}
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	line, err := json.Marshal(Event{Name: "es"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every file holds two events
+	sink, err := NewFileSink(path, int64(2*(len(line)+1)), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 7 {
+		if err := sink.Write(Event{Name: "es"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for file, want := range map[string]int{path: 1, path + ".1": 2, path + ".2": 2} {
+		if got := countLines(t, file); got != want {
+			t.Errorf("%s has %d events, want %d", file, got, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected backups beyond maxBackups to be removed, got %v", err)
+	}
+}
+
+func TestFileSinkRotationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	// a non-empty directory in place of the backup makes the rename fail
+	if err := os.MkdirAll(filepath.Join(path+".1", "blocked"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	sink, err := NewFileSink(path, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(Event{Name: "es"}); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := sink.Write(Event{Name: "es"}); err == nil {
+			t.Error("expected the rotation to fail")
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// the events are appended to the current file although it could not be rotated
+	if got := countLines(t, path); got != 3 {
+		t.Errorf("%s has %d events, want 3", path, got)
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines
+}
+
+func TestWebhookSink(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []Event
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(logr.Discard(), server.URL, 2, time.Hour)
+	for _, name := range []string{"a", "b", "c"} {
+		if err := sink.Write(Event{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the last, incomplete batch is sent on close
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(Event{Name: "d"}); err == nil {
+		t.Error("expected an error writing to a closed sink")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 || batches[1][0].Name != "c" {
+		t.Errorf("unexpected batches %+v", batches)
+	}
+}
+
+func TestWebhookSinkDroppedEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	failed := droppedEvents.WithLabelValues(dropReasonSendFailed)
+	before := testutil.ToFloat64(failed)
+	sink := NewWebhookSink(logr.Discard(), server.URL, 2, time.Hour)
+	for _, name := range []string{"a", "b", "c"} {
+		if err := sink.Write(Event{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.ToFloat64(failed) - before; got != 3 {
+		t.Errorf("dropped %v events, want 3", got)
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/audit"
+)
+
+// auditEvent returns the audit event of a call to the store an ExternalSecret entry reads from.
+func auditEvent(externalSecret *esv1.ExternalSecret, operation audit.Operation, sourceRef *esv1.StoreGeneratorSourceRef) audit.Event {
+	var storeRef *esv1.SecretStoreRef
+	if sourceRef != nil {
+		storeRef = sourceRef.SecretStoreRef
+	}
+	store := storeSource("", externalSecret.Spec.SecretStoreRef, storeRef)
+	return audit.Event{
+		Operation: operation,
+		Kind:      esv1.ExtSecretKind,
+		Namespace: externalSecret.Namespace,
+		Name:      externalSecret.Name,
+		StoreKind: store.StoreKind,
+		StoreName: store.StoreName,
+	}
+}
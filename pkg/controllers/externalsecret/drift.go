@@ -0,0 +1,253 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/audit"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/runtime/esutils"
+)
+
+const (
+	msgDrifted               = "secret has been changed outside of the ExternalSecret, changed keys: %s"
+	msgDriftNotAdopted       = "secret has been changed outside of the ExternalSecret, changed keys: %s, keys that can not be pushed to the provider: %s"
+	eventDriftOverwritten    = "secret has been changed outside of the ExternalSecret, overwriting changed keys: %s"
+	eventDriftAdopted        = "pushed changed keys of the secret to the provider: %s"
+	msgDriftAdoptionDisabled = "secret has been changed outside of the ExternalSecret, changed keys: %s, driftPolicy=Adopt is not enabled in the controller"
+	errDriftStoreNotWritable = "store %s does not support pushing secrets"
+	errDriftStoreNotAllowed  = "store %s does not allow drift adoption, set spec.allowDriftAdoption of the store"
+	errDriftPush             = "could not push key %s to store %s: %w"
+)
+
+// isSecretDrifted reports whether the data of the target secret has been changed
+// since it was last written by the controller.
+func isSecretDrifted(existingSecret *v1.Secret, es *esv1.ExternalSecret) bool {
+	if existingSecret.UID == "" || es.Spec.Target.Immutable || es.Spec.Target.CreationPolicy == esv1.CreatePolicyNone {
+		return false
+	}
+	if existingSecret.Labels[esv1.LabelManaged] != esv1.LabelManagedValue {
+		return false
+	}
+	hash, ok := existingSecret.Annotations[esv1.AnnotationDataHash]
+	return ok && hash != esutils.ObjectHash(existingSecret.Data)
+}
+
+// driftedKeys returns the sorted keys whose values differ between the existing and the desired data.
+func driftedKeys(existing, desired map[string][]byte) []string {
+	var keys []string
+	for key, value := range existing {
+		if desiredValue, ok := desired[key]; !ok || !bytes.Equal(value, desiredValue) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range desired {
+		if _, ok := existing[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// handleDrift handles changes of the target secret that were made outside of the ExternalSecret
+// according to its drift policy. desired is the secret the ExternalSecret would write, dataMap is
+// the provider data it has been rendered from. Adopted keys are written back to dataMap.
+// It reports whether the secret must be kept as it is.
+func (r *Reconciler) handleDrift(ctx context.Context, log logr.Logger, es *esv1.ExternalSecret, existingSecret, desired *v1.Secret, dataMap map[string][]byte) (bool, error) {
+	keys := driftedKeys(existingSecret.Data, desired.Data)
+	if len(keys) == 0 {
+		// e.g. keys of a merged secret that are not managed by the ExternalSecret have been changed
+		removeExternalSecretCondition(es, esv1.ExternalSecretDrifted)
+		return false, nil
+	}
+
+	policy := es.Spec.Target.DriftPolicy
+	if policy == "" {
+		policy = esv1.DriftPolicyOverwrite
+	}
+	labels := maps.Clone(ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": es.Name, "namespace": es.Namespace}))
+	labels["policy"] = string(policy)
+	esmetrics.GetCounterVec(esmetrics.DriftDetectedKey).With(labels).Inc()
+	log.Info("secret has been changed outside of the ExternalSecret", "keys", keys, "driftPolicy", policy)
+
+	changed := strings.Join(keys, ", ")
+	switch policy {
+	case esv1.DriftPolicyReport:
+		r.recorder.Eventf(es, v1.EventTypeWarning, esv1.ReasonDrifted, msgDrifted, changed)
+		SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1.ExternalSecretDrifted, v1.ConditionTrue, esv1.ConditionReasonSecretDrifted, fmt.Sprintf(msgDrifted, changed)))
+		return true, nil
+	case esv1.DriftPolicyAdopt:
+		if !r.AllowDriftAdoption {
+			msg := fmt.Sprintf(msgDriftAdoptionDisabled, changed)
+			r.recorder.Event(es, v1.EventTypeWarning, esv1.ReasonDrifted, msg)
+			SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1.ExternalSecretDrifted, v1.ConditionTrue, esv1.ConditionReasonSecretDrifted, msg))
+			return true, nil
+		}
+		adoptable, notAdoptable := r.adoptableKeys(es, existingSecret, desired, dataMap, keys)
+		if len(notAdoptable) > 0 {
+			msg := fmt.Sprintf(msgDriftNotAdopted, changed, strings.Join(notAdoptable, ", "))
+			r.recorder.Event(es, v1.EventTypeWarning, esv1.ReasonDrifted, msg)
+			SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1.ExternalSecretDrifted, v1.ConditionTrue, esv1.ConditionReasonSecretDrifted, msg))
+			return true, nil
+		}
+		if err := r.adopt(ctx, es, existingSecret, adoptable); err != nil {
+			SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1.ExternalSecretDrifted, v1.ConditionTrue, esv1.ConditionReasonSecretAdoptError, fmt.Sprintf("%s: %v", msgErrorAdoptDrift, err)))
+			return true, err
+		}
+		// the secret is written with the adopted values
+		for _, data := range adoptable {
+			dataMap[data.SecretKey] = existingSecret.Data[data.SecretKey]
+		}
+		r.recorder.Eventf(es, v1.EventTypeNormal, esv1.ReasonAdopted, eventDriftAdopted, changed)
+	default:
+		r.recorder.Eventf(es, v1.EventTypeWarning, esv1.ReasonDrifted, eventDriftOverwritten, changed)
+	}
+	removeExternalSecretCondition(es, esv1.ExternalSecretDrifted)
+	return false, nil
+}
+
+// adoptableKeys splits the changed keys into the spec.data entries the changed values can be pushed to,
+// and the keys that can not be pushed. A key can be pushed if its value is the unmodified value of a
+// spec.data entry, e.g. it is neither decoded nor rendered by a template, and it has not been removed.
+func (r *Reconciler) adoptableKeys(es *esv1.ExternalSecret, existingSecret, desired *v1.Secret, dataMap map[string][]byte, keys []string) ([]esv1.ExternalSecretData, []string) {
+	var adoptable []esv1.ExternalSecretData
+	var notAdoptable []string
+	for _, key := range keys {
+		i := slices.IndexFunc(es.Spec.Data, func(data esv1.ExternalSecretData) bool { return data.SecretKey == key })
+		_, exists := existingSecret.Data[key]
+		if i < 0 || !exists || !isAdoptable(es.Spec.Data[i]) || !bytes.Equal(desired.Data[key], dataMap[key]) {
+			notAdoptable = append(notAdoptable, key)
+			continue
+		}
+		adoptable = append(adoptable, es.Spec.Data[i])
+	}
+	return adoptable, notAdoptable
+}
+
+// isAdoptable reports whether the value of a spec.data entry is the value of the remote secret as it is.
+func isAdoptable(data esv1.ExternalSecretData) bool {
+	ref := data.RemoteRef
+	return (data.SourceRef == nil || data.SourceRef.GeneratorRef == nil) &&
+		ref.Version == "" &&
+		ref.MetadataPolicy != esv1.ExternalSecretMetadataPolicyFetch &&
+		(ref.DecodingStrategy == "" || ref.DecodingStrategy == esv1.ExternalSecretDecodeNone) &&
+		(ref.ConversionStrategy == "" || ref.ConversionStrategy == esv1.ExternalSecretConversionDefault) &&
+		ref.Decryption == nil
+}
+
+// adopt pushes the values of the secret to the remote secrets of the spec.data entries.
+// The stores must allow drift adoption, as anyone who may edit the secret can write to
+// the provider with it. Failed pushes are returned, so that the reconcile is retried
+// with the backoff of the workqueue.
+func (r *Reconciler) adopt(ctx context.Context, es *esv1.ExternalSecret, existingSecret *v1.Secret, adoptable []esv1.ExternalSecretData) error {
+	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate).WithClientPool(r.ClientPool)
+	defer func() {
+		_ = mgr.Close(ctx)
+	}()
+
+	var errs error
+	for _, data := range adoptable {
+		sourceRef := toStoreGenSourceRef(data.SourceRef)
+		storeName := es.Spec.SecretStoreRef.Name
+		if sourceRef != nil {
+			storeName = sourceRef.SecretStoreRef.Name
+		}
+		store, err := mgr.GetStore(ctx, es.Spec.SecretStoreRef, es.Namespace, sourceRef)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if !store.GetSpec().AllowDriftAdoption {
+			errs = errors.Join(errs, fmt.Errorf(errDriftStoreNotAllowed, storeName))
+			continue
+		}
+		storeProvider, err := esv1.GetProvider(store)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if storeProvider.Capabilities() == esv1.SecretStoreReadOnly {
+			errs = errors.Join(errs, fmt.Errorf(errDriftStoreNotWritable, storeName))
+			continue
+		}
+		secretClient, err := mgr.Get(ctx, es.Spec.SecretStoreRef, es.Namespace, sourceRef)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		pushData := adoptData{secretKey: data.SecretKey, remoteKey: data.RemoteRef.Key, property: data.RemoteRef.Property}
+		event := auditEvent(es, audit.OperationPushSecret, sourceRef)
+		event.RemoteKey = data.RemoteRef.Key
+		event.Property = data.RemoteRef.Property
+		start := time.Now()
+		err = secretClient.PushSecret(ctx, existingSecret, pushData)
+		r.Auditor.Record(event, start, err)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf(errDriftPush, data.SecretKey, storeName, err))
+		}
+	}
+	return errs
+}
+
+// adoptData implements esv1.PushSecretData for a spec.data entry.
+type adoptData struct {
+	secretKey string
+	remoteKey string
+	property  string
+}
+
+func (d adoptData) GetMetadata() *apiextensionsv1.JSON {
+	return nil
+}
+
+func (d adoptData) GetSecretKey() string {
+	return d.secretKey
+}
+
+func (d adoptData) GetRemoteKey() string {
+	return d.remoteKey
+}
+
+func (d adoptData) GetProperty() string {
+	return d.property
+}
+
+// removeExternalSecretCondition removes the condition with the provided type.
+func removeExternalSecretCondition(es *esv1.ExternalSecret, condType esv1.ExternalSecretConditionType) {
+	currentCond := GetExternalSecretCondition(es.Status, condType)
+	if currentCond == nil {
+		return
+	}
+	es.Status.Conditions = filterOutCondition(es.Status.Conditions, condType)
+	esmetrics.UpdateExternalSecretCondition(es, currentCond, 0.0)
+}
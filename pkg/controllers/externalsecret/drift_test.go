@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils"
+)
+
+func TestIsSecretDrifted(t *testing.T) {
+	data := map[string][]byte{"password": []byte("secret")}
+	managedSecret := func(hash string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:         "uid",
+				Labels:      map[string]string{esv1.LabelManaged: esv1.LabelManagedValue},
+				Annotations: map[string]string{esv1.AnnotationDataHash: hash},
+			},
+			Data: data,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		target esv1.ExternalSecretTarget
+		want   bool
+	}{
+		{
+			name:   "unchanged secret",
+			secret: managedSecret(esutils.ObjectHash(data)),
+		},
+		{
+			name:   "changed secret",
+			secret: managedSecret("outdated"),
+			want:   true,
+		},
+		{
+			name:   "missing secret",
+			secret: &corev1.Secret{},
+		},
+		{
+			name:   "immutable target",
+			secret: managedSecret("outdated"),
+			target: esv1.ExternalSecretTarget{Immutable: true},
+		},
+		{
+			name:   "creationPolicy=None",
+			secret: managedSecret("outdated"),
+			target: esv1.ExternalSecretTarget{CreationPolicy: esv1.CreatePolicyNone},
+		},
+		{
+			name: "unmanaged secret",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{UID: "uid", Annotations: map[string]string{esv1.AnnotationDataHash: "outdated"}},
+				Data:       data,
+			},
+		},
+		{
+			name: "secret without hash",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{UID: "uid", Labels: map[string]string{esv1.LabelManaged: esv1.LabelManagedValue}},
+				Data:       data,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1.ExternalSecret{Spec: esv1.ExternalSecretSpec{Target: tt.target}}
+			if got := isSecretDrifted(tt.secret, es); got != tt.want {
+				t.Errorf("isSecretDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriftedKeys(t *testing.T) {
+	existing := map[string][]byte{
+		"unchanged": []byte("a"),
+		"changed":   []byte("b"),
+		"added":     []byte("c"),
+	}
+	desired := map[string][]byte{
+		"unchanged": []byte("a"),
+		"changed":   []byte("x"),
+		"removed":   []byte("d"),
+	}
+	if diff := cmp.Diff([]string{"added", "changed", "removed"}, driftedKeys(existing, desired)); diff != "" {
+		t.Errorf("driftedKeys() mismatch (-want +got):\n%s", diff)
+	}
+	if got := driftedKeys(existing, existing); len(got) != 0 {
+		t.Errorf("driftedKeys() of equal data = %v, want none", got)
+	}
+}
+
+func TestIsAdoptable(t *testing.T) {
+	tests := []struct {
+		name string
+		data esv1.ExternalSecretData
+		want bool
+	}{
+		{
+			name: "plain value",
+			data: esv1.ExternalSecretData{RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "db", Property: "password"}},
+			want: true,
+		},
+		{
+			name: "explicit default strategies",
+			data: esv1.ExternalSecretData{RemoteRef: esv1.ExternalSecretDataRemoteRef{
+				Key:                "db",
+				ConversionStrategy: esv1.ExternalSecretConversionDefault,
+				DecodingStrategy:   esv1.ExternalSecretDecodeNone,
+			}},
+			want: true,
+		},
+		{
+			name: "pinned version",
+			data: esv1.ExternalSecretData{RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "db", Version: "1"}},
+		},
+		{
+			name: "decoded value",
+			data: esv1.ExternalSecretData{RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "db", DecodingStrategy: esv1.ExternalSecretDecodeBase64}},
+		},
+		{
+			name: "metadata",
+			data: esv1.ExternalSecretData{RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "db", MetadataPolicy: esv1.ExternalSecretMetadataPolicyFetch}},
+		},
+		{
+			name: "generated value",
+			data: esv1.ExternalSecretData{
+				RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "db"},
+				SourceRef: &esv1.StoreSourceRef{GeneratorRef: &esv1.GeneratorRef{Name: "password"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAdoptable(tt.data); got != tt.want {
+				t.Errorf("isAdoptable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+const (
+	msgDryRun           = "dry run, the secret has not been written. Planned changes are listed in status.plan"
+	msgErrorPlanHashKey = "could not get the key to hash planned values"
+
+	// planHashKeyField is the key of the plan hash key in its Secret.
+	planHashKeyField = "key"
+	planHashKeySize  = 32
+
+	errPlanHashKeyUnset   = "no plan hash key is configured, dry runs are not supported"
+	errPlanHashKeyInvalid = "secret %s does not contain a %d byte %q"
+)
+
+// PlanHashKey provides the key of the HMAC of the values planned by a dry run,
+// so that plans are comparable without exposing low-entropy values to everyone
+// who can read the status of an ExternalSecret. The key is kept in a Secret
+// owned by the controller, which is created on first use.
+type PlanHashKey struct {
+	// Reader reads the Secret uncached, as the namespace of the controller may not be cached.
+	Reader    client.Reader
+	Client    client.Client
+	Name      string
+	Namespace string
+
+	mu  sync.Mutex
+	key []byte
+}
+
+// Get returns the key, creating its Secret if it doesn't exist yet.
+func (k *PlanHashKey) Get(ctx context.Context) ([]byte, error) {
+	if k == nil {
+		return nil, errors.New(errPlanHashKeyUnset)
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.key != nil {
+		return k.key, nil
+	}
+	key, err := k.read(ctx)
+	if apierrors.IsNotFound(err) {
+		key, err = k.create(ctx)
+		// another replica created the Secret in the meantime
+		if apierrors.IsAlreadyExists(err) {
+			key, err = k.read(ctx)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	k.key = key
+	return key, nil
+}
+
+func (k *PlanHashKey) read(ctx context.Context) ([]byte, error) {
+	var secret v1.Secret
+	if err := k.Reader.Get(ctx, types.NamespacedName{Name: k.Name, Namespace: k.Namespace}, &secret); err != nil {
+		return nil, err
+	}
+	key := secret.Data[planHashKeyField]
+	if len(key) != planHashKeySize {
+		return nil, fmt.Errorf(errPlanHashKeyInvalid, k.Name, planHashKeySize, planHashKeyField)
+	}
+	return key, nil
+}
+
+func (k *PlanHashKey) create(ctx context.Context) ([]byte, error) {
+	key := make([]byte, planHashKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k.Name,
+			Namespace: k.Namespace,
+		},
+		Immutable: ptr.To(true),
+		Data:      map[string][]byte{planHashKeyField: key},
+	}
+	if err := k.Client.Create(ctx, secret); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// planSecret lists the changes that writing the desired secret would make to the existing secret.
+// A nil desired secret plans the deletion of the existing secret. Planned values are hashed with hashKey.
+func planSecret(secretName string, existingSecret, desired *v1.Secret, hashKey []byte) *esv1.ExternalSecretPlan {
+	plan := &esv1.ExternalSecretPlan{
+		Target: secretName,
+		Create: existingSecret.UID == "" && desired != nil,
+	}
+	var desiredData map[string][]byte
+	if desired != nil {
+		desiredData = desired.Data
+	}
+	for _, key := range driftedKeys(existingSecret.Data, desiredData) {
+		value, ok := desiredData[key]
+		_, exists := existingSecret.Data[key]
+		switch {
+		case !ok:
+			plan.Keys = append(plan.Keys, esv1.ExternalSecretPlanKey{Key: key, Action: esv1.PlanActionRemove})
+		case !exists:
+			plan.Keys = append(plan.Keys, esv1.ExternalSecretPlanKey{Key: key, Action: esv1.PlanActionAdd, Hash: valueHash(hashKey, value)})
+		default:
+			plan.Keys = append(plan.Keys, esv1.ExternalSecretPlanKey{Key: key, Action: esv1.PlanActionChange, Hash: valueHash(hashKey, value)})
+		}
+	}
+	return plan
+}
+
+// valueHash returns the hex encoded HMAC-SHA256 of a value, so planned values can be compared without exposing them.
+func valueHash(key, value []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(value)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// markAsPlanned records the plan of a dry run.
+func (r *Reconciler) markAsPlanned(ctx context.Context, externalSecret *esv1.ExternalSecret, start time.Time, log logr.Logger, counter prometheus.Counter, secretName string, existingSecret, desired *v1.Secret) error {
+	key, err := r.PlanHashKey.Get(ctx)
+	if err != nil {
+		r.markAsFailed(msgErrorPlanHashKey, err, externalSecret, counter, esv1.ConditionReasonSecretSyncedError)
+		return err
+	}
+	r.markAsDone(externalSecret, start, log, esv1.ConditionReasonDryRun, msgDryRun)
+	externalSecret.Status.Plan = planSecret(secretName, existingSecret, desired, key)
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+var testPlanHashKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestPlanSecret(t *testing.T) {
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{UID: "uid"},
+		Data: map[string][]byte{
+			"unchanged": []byte("a"),
+			"changed":   []byte("b"),
+			"removed":   []byte("c"),
+		},
+	}
+	desired := &corev1.Secret{
+		Data: map[string][]byte{
+			"unchanged": []byte("a"),
+			"changed":   []byte("x"),
+			"added":     []byte("y"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		existing *corev1.Secret
+		desired  *corev1.Secret
+		want     *esv1.ExternalSecretPlan
+	}{
+		{
+			name:     "update",
+			existing: existing,
+			desired:  desired,
+			want: &esv1.ExternalSecretPlan{
+				Target: "db",
+				Keys: []esv1.ExternalSecretPlanKey{
+					{Key: "added", Action: esv1.PlanActionAdd, Hash: valueHash(testPlanHashKey, []byte("y"))},
+					{Key: "changed", Action: esv1.PlanActionChange, Hash: valueHash(testPlanHashKey, []byte("x"))},
+					{Key: "removed", Action: esv1.PlanActionRemove},
+				},
+			},
+		},
+		{
+			name:     "create",
+			existing: &corev1.Secret{},
+			desired:  &corev1.Secret{Data: map[string][]byte{"added": []byte("y")}},
+			want: &esv1.ExternalSecretPlan{
+				Target: "db",
+				Create: true,
+				Keys: []esv1.ExternalSecretPlanKey{
+					{Key: "added", Action: esv1.PlanActionAdd, Hash: valueHash(testPlanHashKey, []byte("y"))},
+				},
+			},
+		},
+		{
+			name:     "delete",
+			existing: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{UID: "uid"}, Data: map[string][]byte{"removed": []byte("c")}},
+			want: &esv1.ExternalSecretPlan{
+				Target: "db",
+				Keys: []esv1.ExternalSecretPlanKey{
+					{Key: "removed", Action: esv1.PlanActionRemove},
+				},
+			},
+		},
+		{
+			name:     "no changes",
+			existing: existing,
+			desired:  existing,
+			want:     &esv1.ExternalSecretPlan{Target: "db"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, planSecret("db", tt.existing, tt.desired, testPlanHashKey)); diff != "" {
+				t.Errorf("planSecret() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValueHash(t *testing.T) {
+	// HMAC-SHA256 of "secret" keyed with "key"
+	want := "25cf3c44c8f39313e8cbf7c23e22fe8b2ee8b288ee5206b0a6397583a1f7f0ef"
+	if got := valueHash([]byte("key"), []byte("secret")); got != want {
+		t.Errorf("valueHash() = %s, want %s", got, want)
+	}
+	if valueHash([]byte("other"), []byte("secret")) == want {
+		t.Error("valueHash() must depend on the key")
+	}
+}
+
+func TestPlanHashKey(t *testing.T) {
+	kube := fake.NewClientBuilder().Build()
+	newKey := func() *PlanHashKey {
+		return &PlanHashKey{Reader: kube, Client: kube, Name: "plan-hash-key", Namespace: "external-secrets"}
+	}
+
+	// the first replica creates the key, the others read it.
+	created, err := newKey().Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(created) != planHashKeySize {
+		t.Fatalf("Get() returned a %d byte key, want %d", len(created), planHashKeySize)
+	}
+	read, err := newKey().Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !bytes.Equal(created, read) {
+		t.Error("Get() of another replica returned a different key")
+	}
+
+	var unset *PlanHashKey
+	if _, err := unset.Get(context.Background()); err == nil {
+		t.Error("Get() without a configured key must fail")
+	}
+
+	invalid := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "plan-hash-key", Namespace: "external-secrets"},
+		Data:       map[string][]byte{planHashKeyField: []byte("short")},
+	}).Build()
+	if _, err := (&PlanHashKey{Reader: invalid, Client: invalid, Name: "plan-hash-key", Namespace: "external-secrets"}).Get(context.Background()); err == nil {
+		t.Error("Get() of a short key must fail")
+	}
+}
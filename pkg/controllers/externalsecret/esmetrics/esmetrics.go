@@ -18,8 +18,11 @@ limitations under the License.
 package esmetrics
 
 import (
+	"slices"
+
 	"github.com/prometheus/client_golang/prometheus"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
@@ -37,6 +40,10 @@ const (
 	ExternalSecretStatusConditionKey = "status_condition"
 	// ExternalSecretReconcileDurationKey is the metric key for the external secret reconcile duration.
 	ExternalSecretReconcileDurationKey = "reconcile_duration"
+	// DriftDetectedKey is the metric key for changes of the target secret outside of the external secret.
+	DriftDetectedKey = "drift_detected_total"
+	// SecretExpiryTimestampKey is the metric key for the earliest expiry of the remote secrets of the external secret.
+	SecretExpiryTimestampKey = "secret_expiry_timestamp_seconds"
 )
 
 var counterVecMetrics = map[string]*prometheus.CounterVec{}
@@ -71,16 +78,30 @@ func SetUpMetrics() {
 		Help:      "The duration time to reconcile the External Secret",
 	}, ctrlmetrics.NonConditionMetricLabelNames)
 
-	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition, externalSecretReconcileDuration)
+	driftDetected := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      DriftDetectedKey,
+		Help:      "Total number of changes of the target secret outside of the External Secret",
+	}, slices.Concat(ctrlmetrics.NonConditionMetricLabelNames, []string{"policy"}))
+
+	secretExpiryTimestamp := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      SecretExpiryTimestampKey,
+		Help:      "The earliest expiry of the remote secrets of a specific External Secret, as reported by the provider",
+	}, ctrlmetrics.NonConditionMetricLabelNames)
+
+	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition, externalSecretReconcileDuration, driftDetected, secretExpiryTimestamp)
 
 	counterVecMetrics = map[string]*prometheus.CounterVec{
 		SyncCallsKey:      syncCallsTotal,
 		SyncCallsErrorKey: syncCallsError,
+		DriftDetectedKey:  driftDetected,
 	}
 
 	gaugeVecMetrics = map[string]*prometheus.GaugeVec{
 		ExternalSecretStatusConditionKey:   externalSecretCondition,
 		ExternalSecretReconcileDurationKey: externalSecretReconcileDuration,
+		SecretExpiryTimestampKey:           secretExpiryTimestamp,
 	}
 }
 
@@ -172,6 +193,17 @@ func UpdateExternalSecretCondition(es *esv1.ExternalSecret, condition *esv1.Exte
 		})).Set(value)
 }
 
+// RemoveMetrics removes all metrics of an ExternalSecret, e.g. when another replica has taken it over.
+func RemoveMetrics(name types.NamespacedName) {
+	labels := prometheus.Labels{"name": name.Name, "namespace": name.Namespace}
+	for _, counterVec := range counterVecMetrics {
+		counterVec.DeletePartialMatch(labels)
+	}
+	for _, gaugeVec := range gaugeVecMetrics {
+		gaugeVec.DeletePartialMatch(labels)
+	}
+}
+
 // GetCounterVec returns the counter vec for the given key.
 func GetCounterVec(key string) *prometheus.CounterVec {
 	return counterVecMetrics[key]
@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+)
+
+const (
+	// defaultExpiringWindow is the expiring window of ExternalSecrets that do not set one.
+	defaultExpiringWindow = 7 * 24 * time.Hour
+	// expiryRefreshMargin is how long before the values expire the ExternalSecret is refreshed.
+	expiryRefreshMargin = time.Minute
+
+	msgSecretExpiring = "remote secret %s expires at %s"
+	msgSecretExpired  = "remote secret %s expired at %s"
+)
+
+// valueExpiry tracks the values of the target that expire first.
+type valueExpiry struct {
+	// expiresAt is the earliest expiry of all values, generated or fetched.
+	expiresAt time.Time
+	// secretKey and secretExpiresAt are the remote secret that expires first. Unlike
+	// generated values, remote secrets are not renewed by a refresh, so only they set
+	// the Expiring condition.
+	secretKey       string
+	secretExpiresAt time.Time
+}
+
+// observe records the expiry of a generated value, if it expires before the values observed so far.
+func (e *valueExpiry) observe(expiresAt time.Time) {
+	if expiresAt.IsZero() {
+		return
+	}
+	if e.expiresAt.IsZero() || expiresAt.Before(e.expiresAt) {
+		e.expiresAt = expiresAt
+	}
+}
+
+// observeSecret records the metadata of a remote secret, if it expires before the secrets observed so far.
+func (e *valueExpiry) observeSecret(key string, metadata *esv1.SecretMetadata) {
+	if metadata == nil || metadata.ExpiresAt.IsZero() {
+		return
+	}
+	e.observe(metadata.ExpiresAt)
+	if e.secretExpiresAt.IsZero() || metadata.ExpiresAt.Before(e.secretExpiresAt) {
+		e.secretKey = key
+		e.secretExpiresAt = metadata.ExpiresAt
+	}
+}
+
+// getSecret returns a single secret, together with its metadata if the provider supports it.
+func getSecret(ctx context.Context, client esv1.SecretsClient, ref esv1.ExternalSecretDataRemoteRef) ([]byte, *esv1.SecretMetadata, error) {
+	if getter, ok := client.(esv1.SecretMetadataGetter); ok {
+		return getter.GetSecretWithMetadata(ctx, ref)
+	}
+	data, err := client.GetSecret(ctx, ref)
+	return data, nil, err
+}
+
+func expiringWindow(es *esv1.ExternalSecret) time.Duration {
+	if es.Spec.ExpiringWindow != nil {
+		return es.Spec.ExpiringWindow.Duration
+	}
+	return defaultExpiringWindow
+}
+
+// setExpiry records the earliest expiry of the values in the status, and sets the
+// Expiring condition if a remote secret expires within the expiring window.
+func setExpiry(es *esv1.ExternalSecret, expiry valueExpiry, now time.Time) {
+	es.Status.ExpiresAt = nil
+	if !expiry.expiresAt.IsZero() {
+		es.Status.ExpiresAt = &metav1.Time{Time: expiry.expiresAt}
+	}
+
+	labels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": es.Name, "namespace": es.Namespace})
+	if expiry.secretExpiresAt.IsZero() {
+		removeExternalSecretCondition(es, esv1.ExternalSecretExpiring)
+		esmetrics.GetGaugeVec(esmetrics.SecretExpiryTimestampKey).Delete(labels)
+		return
+	}
+	esmetrics.GetGaugeVec(esmetrics.SecretExpiryTimestampKey).With(labels).Set(float64(expiry.secretExpiresAt.Unix()))
+
+	expiresAt := expiry.secretExpiresAt.UTC().Format(time.RFC3339)
+	switch {
+	case !now.Before(expiry.secretExpiresAt):
+		SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1.ExternalSecretExpiring, v1.ConditionTrue, esv1.ConditionReasonSecretExpired, fmt.Sprintf(msgSecretExpired, expiry.secretKey, expiresAt)))
+	case !now.Before(expiry.secretExpiresAt.Add(-expiringWindow(es))):
+		SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1.ExternalSecretExpiring, v1.ConditionTrue, esv1.ConditionReasonSecretExpiring, fmt.Sprintf(msgSecretExpiring, expiry.secretKey, expiresAt)))
+	default:
+		removeExternalSecretCondition(es, esv1.ExternalSecretExpiring)
+	}
+}
+
+// expiryRefreshTime returns the time at which the ExternalSecret should be refreshed because
+// its values are about to expire, regardless of its refreshInterval. It is refreshed when the
+// expiring window starts, after 80% of the remaining lifetime at the last refresh like the kubelet
+// does for projected tokens, right before the values expire, to pick up a rotated version, and once
+// they have expired, to update the Expiring condition.
+func expiryRefreshTime(es *esv1.ExternalSecret) (time.Time, bool) {
+	if es.Status.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	expiresAt := es.Status.ExpiresAt.Time
+	lastRefresh := es.Status.RefreshTime.Time
+	if lastRefresh.IsZero() {
+		return expiresAt, true
+	}
+	refreshes := []time.Time{
+		expiresAt.Add(-expiringWindow(es)),
+		expiresAt.Add(-expiryRefreshMargin),
+		expiresAt,
+	}
+	// values that are not renewed, like remote secrets, are refreshed right before they expire.
+	if lifetimeRefresh := lastRefresh.Add(expiresAt.Sub(lastRefresh) * 8 / 10); lifetimeRefresh.Before(expiresAt.Add(-expiryRefreshMargin)) {
+		refreshes = append(refreshes, lifetimeRefresh)
+	}
+	slices.SortFunc(refreshes, time.Time.Compare)
+	for _, refresh := range refreshes {
+		if lastRefresh.Before(refresh) {
+			return refresh, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+func TestValueExpiryObserve(t *testing.T) {
+	now := time.Now()
+	var expiry valueExpiry
+	expiry.observeSecret("late", &esv1.SecretMetadata{ExpiresAt: now.Add(time.Hour)})
+	expiry.observeSecret("early", &esv1.SecretMetadata{ExpiresAt: now.Add(time.Minute)})
+	expiry.observeSecret("unknown", &esv1.SecretMetadata{})
+	expiry.observeSecret("unsupported", nil)
+	if expiry.secretKey != "early" || !expiry.secretExpiresAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("observeSecret() = %+v, want the earliest expiry", expiry)
+	}
+
+	// generated values count for the expiry of the values, but not of the remote secrets.
+	expiry.observe(now.Add(time.Second))
+	expiry.observe(time.Time{})
+	if !expiry.expiresAt.Equal(now.Add(time.Second)) || !expiry.secretExpiresAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("observe() = %+v, want the earliest generated expiry", expiry)
+	}
+}
+
+func TestSetExpiry(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		expiresAt  time.Time
+		wantReason string
+	}{
+		{
+			name: "no expiry",
+		},
+		{
+			name:      "outside of the window",
+			expiresAt: now.Add(30 * 24 * time.Hour),
+		},
+		{
+			name:       "inside of the window",
+			expiresAt:  now.Add(24 * time.Hour),
+			wantReason: esv1.ConditionReasonSecretExpiring,
+		},
+		{
+			name:       "expired",
+			expiresAt:  now.Add(-time.Hour),
+			wantReason: esv1.ConditionReasonSecretExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"}}
+			SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1.ExternalSecretExpiring, v1.ConditionTrue, esv1.ConditionReasonSecretExpiring, "outdated"))
+			setExpiry(es, valueExpiry{expiresAt: tt.expiresAt, secretKey: "db", secretExpiresAt: tt.expiresAt}, now)
+
+			if tt.expiresAt.IsZero() != (es.Status.ExpiresAt == nil) {
+				t.Errorf("status.expiresAt = %v, want %v", es.Status.ExpiresAt, tt.expiresAt)
+			}
+			cond := GetExternalSecretCondition(es.Status, esv1.ExternalSecretExpiring)
+			switch {
+			case tt.wantReason == "" && cond != nil:
+				t.Errorf("unexpected Expiring condition %+v", cond)
+			case tt.wantReason != "" && (cond == nil || cond.Reason != tt.wantReason):
+				t.Errorf("Expiring condition = %+v, want reason %s", cond, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestGeneratedExpiryCondition(t *testing.T) {
+	// generated values are renewed by a refresh, so they never set the Expiring condition.
+	now := time.Now()
+	es := &esv1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"}}
+	setExpiry(es, valueExpiry{expiresAt: now.Add(time.Minute)}, now)
+	if es.Status.ExpiresAt == nil || !es.Status.ExpiresAt.Time.Equal(now.Add(time.Minute)) {
+		t.Errorf("status.expiresAt = %v, want %v", es.Status.ExpiresAt, now.Add(time.Minute))
+	}
+	if cond := GetExternalSecretCondition(es.Status, esv1.ExternalSecretExpiring); cond != nil {
+		t.Errorf("unexpected Expiring condition %+v", cond)
+	}
+}
+
+func TestExpiryRefreshTime(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 8, 0, 0, 0, 0, time.UTC)
+	windowStart := expiresAt.Add(-defaultExpiringWindow)
+	tests := []struct {
+		name        string
+		refreshTime time.Time
+		want        time.Time
+		wantOk      bool
+	}{
+		{
+			name:        "before the window",
+			refreshTime: windowStart.Add(-time.Hour),
+			want:        windowStart,
+			wantOk:      true,
+		},
+		{
+			name:        "inside of the window",
+			refreshTime: windowStart,
+			// 80% of the remaining lifetime
+			want:   windowStart.Add(defaultExpiringWindow * 8 / 10),
+			wantOk: true,
+		},
+		{
+			name:        "after 80% of the lifetime",
+			refreshTime: expiresAt.Add(-time.Hour),
+			want:        expiresAt.Add(-12 * time.Minute),
+			wantOk:      true,
+		},
+		{
+			name:        "within the margin",
+			refreshTime: expiresAt.Add(-2 * time.Minute),
+			want:        expiresAt.Add(-expiryRefreshMargin),
+			wantOk:      true,
+		},
+		{
+			name:        "right before the expiry",
+			refreshTime: expiresAt.Add(-expiryRefreshMargin),
+			want:        expiresAt,
+			wantOk:      true,
+		},
+		{
+			name:        "expired",
+			refreshTime: expiresAt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1.ExternalSecret{
+				Status: esv1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(tt.refreshTime),
+					ExpiresAt:   &metav1.Time{Time: expiresAt},
+				},
+			}
+			got, ok := expiryRefreshTime(es)
+			if ok != tt.wantOk || !got.Equal(tt.want) {
+				t.Errorf("expiryRefreshTime() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
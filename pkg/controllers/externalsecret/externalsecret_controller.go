@@ -52,9 +52,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/audit"
 	// Metrics.
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/schedule"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/controllers/sharding"
 	ctrlutil "github.com/external-secrets/external-secrets/pkg/controllers/util"
 	"github.com/external-secrets/external-secrets/runtime/esutils"
 	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
@@ -88,6 +92,7 @@ const (
 	msgErrorUpdateImmutable = "could not update secret, target is immutable"
 	msgErrorBecomeOwner     = "failed to take ownership of target secret"
 	msgErrorIsOwned         = "target is owned by another ExternalSecret"
+	msgErrorAdoptDrift      = "could not push the changed keys of the secret to the provider"
 
 	// log messages.
 	logErrorGetES                = "unable to get ExternalSecret"
@@ -101,8 +106,10 @@ const (
 	errConvert               = "error applying conversion strategy %s to keys: %w"
 	errRewrite               = "error applying rewrite to keys: %w"
 	errDecode                = "error applying decoding strategy %s to data: %w"
+	errDecrypt               = "error applying %s decryption to data: %w"
 	errGenerate              = "error using generator: %w"
 	errInvalidKeys           = "invalid secret keys (TIP: use rewrite or conversionStrategy to change keys): %w"
+	errPreviousValues        = "error reading previous generated values: %w"
 	errFetchTplFrom          = "error fetching templateFrom data: %w"
 	errApplyTemplate         = "could not apply template: %w"
 	errExecTpl               = "could not execute template: %w"
@@ -147,16 +154,35 @@ type Reconciler struct {
 	EnableFloodGate           bool
 	EnableGeneratorState      bool
 	AllowGenericTargets       bool
-	recorder                  record.EventRecorder
+	// PlanHashKey is the key of the HMAC of the values planned by a dry run.
+	PlanHashKey *PlanHashKey
+	// AllowDriftAdoption enables pushing drifted target secrets to stores that allow it.
+	AllowDriftAdoption bool
+	// WatchManager watches the stores whose providers support it, if set.
+	WatchManager *secretstore.WatchManager
+	// ClientPool keeps provider clients open across reconciles, if set.
+	ClientPool *secretstore.ClientPool
+	// Shard limits the reconciled ExternalSecrets to the shard of the replica, if set.
+	Shard *sharding.Sharder
+	// Auditor records the calls to the providers, if set.
+	Auditor  *audit.Auditor
+	recorder record.EventRecorder
 
 	// informerManager manages dynamic informers for generic targets
 	informerManager InformerManager
+
+	// refresh enqueues ExternalSecrets for RequestRefresh
+	refresh *refreshTrigger
 }
 
 // Reconcile implements the main reconciliation loop
 // for watched objects (ExternalSecret, ClusterSecretStore and SecretStore),
 // and updates/creates a Kubernetes secret based on them.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	// ExternalSecrets of another shard may still be enqueued after a rebalance, e.g. by a requeue
+	if !r.Shard.Owns(req.Namespace, req.Name) {
+		return ctrl.Result{}, nil
+	}
 	log := r.Log.WithValues("ExternalSecret", req.NamespacedName)
 
 	resourceLabels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": req.Name, "namespace": req.Namespace})
@@ -254,6 +280,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, nil
 	}
 
+	// the refresh schedule and sync windows are validated by the webhook,
+	// but the webhook may be disabled.
+	if err := schedule.Validate(externalSecret.Spec.RefreshSchedule, externalSecret.Spec.SyncWindows); err != nil {
+		r.markAsFailed(msgInvalidSchedule, err, externalSecret, syncCallsError.With(resourceLabels), esv1.ConditionReasonSecretSyncedError)
+		if updateErr := r.Status().Update(ctx, externalSecret); updateErr != nil {
+			log.Error(updateErr, logErrorUpdateESStatus)
+		}
+		return ctrl.Result{}, nil // don't requeue as this is a configuration error that is not recoverable
+	}
+
 	// if this is a generic target, use a different reconciliation path
 	if isGenericTarget(externalSecret) {
 		// update the status of the ExternalSecret when this function returns, if needed
@@ -275,6 +311,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			return ctrl.Result{}, nil // don't requeue as this is a configuration error that is not recoverable
 		}
 
+		if !externalSecret.Status.RefreshTime.IsZero() {
+			if next, deferred := schedule.Deferred(externalSecret.Spec.SyncWindows, time.Now()); deferred {
+				log.V(1).Info("deferring refresh, sync windows deny updates", "until", next)
+				r.setNextRefreshTime(externalSecret, next, !next.IsZero())
+				return schedule.RequeueAt(next, !next.IsZero()), nil
+			}
+		}
+
 		return r.reconcileGenericTarget(ctx, externalSecret, log, start, resourceLabels, syncCallsError)
 	}
 
@@ -343,19 +387,6 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, err
 	}
 
-	// refresh will be skipped if ALL the following conditions are met:
-	// 1. refresh interval is not 0
-	// 2. resource generation of the ExternalSecret has not changed
-	// 3. the last refresh time of the ExternalSecret is within the refresh interval
-	// 4. the target secret is valid:
-	//     - it exists
-	//     - it has the correct "managed" label
-	//     - it has the correct "data-hash" annotation
-	if !shouldRefresh(externalSecret) && isSecretValid(existingSecret, externalSecret) {
-		log.V(1).Info("skipping refresh")
-		return r.getRequeueResult(externalSecret), nil
-	}
-
 	// update status of the ExternalSecret when this function returns, if needed.
 	// NOTE: we use the ability of deferred functions to update named return values `result` and `err`
 	// NOTE: we dereference the DeepCopy of the status field because status fields are NOT pointers,
@@ -395,6 +426,37 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		}
 	}()
 
+	// refresh will be skipped if ALL the following conditions are met:
+	// 1. refresh interval is not 0
+	// 2. resource generation of the ExternalSecret has not changed
+	// 3. the last refresh time of the ExternalSecret is within the refresh interval
+	// 4. the target secret is valid:
+	//     - it exists
+	//     - it has the correct "managed" label
+	//     - it has the correct "data-hash" annotation
+	// 5. no refresh has been requested with RequestRefresh
+	// a dry run does not write the target secret, so it is not expected to be valid.
+	requested := r.refreshRequested(externalSecret)
+	if !requested && !shouldRefresh(externalSecret) && (externalSecret.Spec.DryRun || isSecretValid(existingSecret, externalSecret)) {
+		log.V(1).Info("skipping refresh")
+		next, ok := r.nextRefreshTime(externalSecret, time.Now())
+		r.setNextRefreshTime(externalSecret, next, ok)
+		return r.getRequeueResult(externalSecret), nil
+	}
+
+	// an existing target secret is only updated while the sync windows allow it,
+	// the refresh is deferred until they do.
+	if existingSecret.UID != "" && !externalSecret.Spec.DryRun {
+		if next, deferred := schedule.Deferred(externalSecret.Spec.SyncWindows, time.Now()); deferred {
+			log.V(1).Info("deferring refresh, sync windows deny updates", "until", next)
+			if requested {
+				r.refresh.restore(req.NamespacedName)
+			}
+			r.setNextRefreshTime(externalSecret, next, !next.IsZero())
+			return schedule.RequeueAt(next, !next.IsZero()), nil
+		}
+	}
+
 	// retrieve the provider secret data.
 	dataMap, err := r.GetProviderSecretData(ctx, externalSecret)
 	if err != nil {
@@ -417,6 +479,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 				return ctrl.Result{}, nil
 			}
 
+			if externalSecret.Spec.DryRun {
+				if err := r.markAsPlanned(ctx, externalSecret, start, log, syncCallsError.With(resourceLabels), secretName, existingSecret, nil); err != nil {
+					return ctrl.Result{}, err
+				}
+				return r.getRequeueResult(externalSecret), nil
+			}
+
 			// delete the secret, if it exists
 			if existingSecret.UID != "" {
 				err = r.Delete(ctx, existingSecret)
@@ -431,6 +500,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			return r.getRequeueResult(externalSecret), nil
 		// In case provider secrets don't exist the kubernetes secret will be kept as-is.
 		case esv1.DeletionPolicyRetain:
+			if externalSecret.Spec.DryRun {
+				if err := r.markAsPlanned(ctx, externalSecret, start, log, syncCallsError.With(resourceLabels), secretName, existingSecret, existingSecret); err != nil {
+					return ctrl.Result{}, err
+				}
+				return r.getRequeueResult(externalSecret), nil
+			}
 			r.markAsDone(externalSecret, start, log, esv1.ConditionReasonSecretSynced, msgSyncedRetain)
 			return r.getRequeueResult(externalSecret), nil
 		// noop, handled below
@@ -532,6 +607,47 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return nil
 	}
 
+	// plan the changes to the secret without writing it
+	if externalSecret.Spec.DryRun {
+		desiredSecret := existingSecret
+		// the secret is only written if the creation policy allows it
+		if !(externalSecret.Spec.Target.CreationPolicy == esv1.CreatePolicyNone ||
+			(externalSecret.Spec.Target.CreationPolicy == esv1.CreatePolicyMerge && existingSecret.UID == "")) {
+			desiredSecret = existingSecret.DeepCopy()
+			if existingSecret.UID == "" {
+				desiredSecret = &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: externalSecret.Namespace}}
+			}
+			if err := mutationFunc(desiredSecret); err != nil {
+				r.markAsFailed(msgErrorUpdateSecret, err, externalSecret, syncCallsError.With(resourceLabels), esv1.ConditionReasonSecretSyncedError)
+				return ctrl.Result{}, nil
+			}
+		}
+		if err := r.markAsPlanned(ctx, externalSecret, start, log, syncCallsError.With(resourceLabels), secretName, existingSecret, desiredSecret); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.getRequeueResult(externalSecret), nil
+	}
+
+	// handle changes of the secret that were made outside of the ExternalSecret
+	if isSecretDrifted(existingSecret, externalSecret) {
+		desiredSecret := existingSecret.DeepCopy()
+		// errors of the mutation function are reported when the secret is updated
+		if mutationFunc(desiredSecret) == nil {
+			keep, err := r.handleDrift(ctx, log, externalSecret, existingSecret, desiredSecret, dataMap)
+			if err != nil {
+				r.markAsFailed(msgErrorAdoptDrift, err, externalSecret, syncCallsError.With(resourceLabels), esv1.ConditionReasonSecretSyncedError)
+				return ctrl.Result{}, err
+			}
+			if keep {
+				next, ok := r.nextRefreshTime(externalSecret, start)
+				r.setNextRefreshTime(externalSecret, next, ok)
+				return r.getRequeueResult(externalSecret), nil
+			}
+		}
+	} else {
+		removeExternalSecretCondition(externalSecret, esv1.ExternalSecretDrifted)
+	}
+
 	switch externalSecret.Spec.Target.CreationPolicy {
 	case esv1.CreatePolicyNone:
 		log.V(1).Info("secret creation skipped due to CreationPolicy=None")
@@ -744,6 +860,23 @@ func (r *Reconciler) getRequeueResult(externalSecret *esv1.ExternalSecret) ctrl.
 		refreshInterval = externalSecret.Spec.RefreshInterval.Duration
 	}
 
+	// requeue at the next scheduled refresh that the sync windows allow
+	if usesSchedule(externalSecret) {
+		return schedule.RequeueAt(r.nextRefreshTime(externalSecret, time.Now()))
+	}
+
+	// if generated values or remote secrets expire, requeue before they expire
+	if expiryRefresh, ok := expiryRefreshTime(externalSecret); ok {
+		untilExpiryRefresh := time.Until(expiryRefresh)
+		if untilExpiryRefresh <= 0 {
+			return ctrl.Result{Requeue: true}
+		}
+		if refreshInterval <= 0 || externalSecret.Status.RefreshTime.IsZero() ||
+			untilExpiryRefresh < refreshInterval-time.Since(externalSecret.Status.RefreshTime.Time) {
+			return ctrl.Result{RequeueAfter: untilExpiryRefresh}
+		}
+	}
+
 	// if the refresh interval is <= 0, we should not requeue
 	if refreshInterval <= 0 {
 		return ctrl.Result{}
@@ -781,6 +914,9 @@ func (r *Reconciler) markAsDone(externalSecret *esv1.ExternalSecret, start time.
 
 	externalSecret.Status.RefreshTime = metav1.NewTime(start)
 	externalSecret.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(externalSecret.ObjectMeta)
+	externalSecret.Status.Plan = nil
+	next, ok := r.nextRefreshTime(externalSecret, start)
+	r.setNextRefreshTime(externalSecret, next, ok)
 
 	// if the status or reason has changed, log at the appropriate verbosity level
 	if oldReadyCondition == nil || oldReadyCondition.Status != newReadyCondition.Status || oldReadyCondition.Reason != newReadyCondition.Reason {
@@ -1124,8 +1260,13 @@ func shouldRefresh(es *esv1.ExternalSecret) bool {
 }
 
 func shouldRefreshPeriodic(es *esv1.ExternalSecret) bool {
+	// if generated values or remote secrets are about to expire, we should refresh
+	if expiryRefresh, ok := expiryRefreshTime(es); ok && !expiryRefresh.After(time.Now()) {
+		return true
+	}
+
 	// if the refresh interval is 0, and we have synced previously, we should not refresh
-	if es.Spec.RefreshInterval.Duration <= 0 && es.Status.SyncedResourceVersion != "" {
+	if es.Spec.RefreshSchedule == nil && es.Spec.RefreshInterval.Duration <= 0 && es.Status.SyncedResourceVersion != "" {
 		return false
 	}
 
@@ -1144,6 +1285,12 @@ func shouldRefreshPeriodic(es *esv1.ExternalSecret) bool {
 		return true
 	}
 
+	// if the refresh schedule is due, we should refresh
+	if es.Spec.RefreshSchedule != nil {
+		next, err := schedule.Next(es.Spec.RefreshSchedule, es.Status.RefreshTime.Time, string(es.UID))
+		return err == nil && !next.IsZero() && !next.After(time.Now())
+	}
+
 	// if the last refresh time + refresh interval is before now, we should refresh
 	return es.Status.RefreshTime.Add(es.Spec.RefreshInterval.Duration).Before(time.Now())
 }
@@ -1215,16 +1362,31 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		return err
 	}
 
+	// index ExternalSecrets based on the keys they read from a store,
+	// this lets us quickly find all ExternalSecrets to refresh when a key changes
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &esv1.ExternalSecret{}, indexESRemoteRefKeyField, func(obj client.Object) []string {
+		return remoteRefKeyIndexValues(obj.(*esv1.ExternalSecret))
+	}); err != nil {
+		return err
+	}
+	r.refresh = newRefreshTrigger()
+
 	// predicate function to ignore secret events unless they have the "managed" label
 	secretHasESLabel := predicate.NewPredicateFuncs(func(object client.Object) bool {
 		value, hasLabel := object.GetLabels()[esv1.LabelManaged]
 		return hasLabel && value == esv1.LabelManagedValue
 	})
 
+	// with sharding, only the ExternalSecrets of the shard are processed
+	var forOpts []builder.ForOption
+	if r.Shard != nil {
+		forOpts = append(forOpts, builder.WithPredicates(r.Shard.Predicate()))
+	}
+
 	// Build the controller
 	builder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
-		For(&esv1.ExternalSecret{}).
+		For(&esv1.ExternalSecret{}, forOpts...).
 		// we cant use Owns(), as we don't set ownerReferences when the creationPolicy is not Owner.
 		// we use WatchesMetadata() to reduce memory usage, as otherwise we have to process full secret objects.
 		WatchesMetadata(
@@ -1238,6 +1400,10 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	if r.AllowGenericTargets {
 		builder = builder.WatchesRawSource(r.informerManager.Source())
 	}
+	builder = builder.WatchesRawSource(r.refresh.Source())
+	if r.Shard != nil {
+		builder = builder.WatchesRawSource(r.Shard.Source(r.listExternalSecrets, esmetrics.RemoveMetrics))
+	}
 
 	return builder.Complete(r)
 }
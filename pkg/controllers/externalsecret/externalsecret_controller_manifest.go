@@ -50,6 +50,9 @@ func (r *Reconciler) validateGenericTarget(log logr.Logger, es *esv1.ExternalSec
 	if manifest.Kind == "" {
 		return fmt.Errorf("target.manifest.kind is required")
 	}
+	if es.Spec.DryRun {
+		return fmt.Errorf("dryRun is not supported with generic targets")
+	}
 
 	log.Info("Warning: Using generic target. Make sure access policies and encryption are properly configured.",
 		"apiVersion", manifest.APIVersion,
@@ -285,8 +288,14 @@ func (r *Reconciler) renderTemplatedManifest(ctx context.Context, es *esv1.Exter
 				}
 			}
 
-			// apply collected data to the target object
-			if err := execute(tempSecret.Data, dataMap, esv1.TemplateScopeValues, targetPath, obj); err != nil {
+			// apply collected data to the target object. The v2 engine renders it
+			// again, other engines would evaluate the rendered values as expressions.
+			if es.Spec.Target.Template.EngineVersion == esv1.TemplateEngineV2 {
+				err = execute(tempSecret.Data, dataMap, esv1.TemplateScopeValues, targetPath, obj)
+			} else {
+				err = template.ApplyRendered(tempSecret.Data, targetPath, obj)
+			}
+			if err != nil {
 				return nil, fmt.Errorf("failed to apply merged templates to path %s: %w", targetPath, err)
 			}
 		}
@@ -21,14 +21,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/audit"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/runtime/envelope"
 	"github.com/external-secrets/external-secrets/runtime/esutils"
 	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
 	"github.com/external-secrets/external-secrets/runtime/statemanager"
@@ -43,7 +50,7 @@ func (r *Reconciler) GetProviderSecretData(ctx context.Context, externalSecret *
 	// Clientmanager keeps track of the client instances
 	// that are created during the fetching process and closes clients
 	// if needed.
-	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate)
+	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate).WithWatchManager(r.WatchManager).WithClientPool(r.ClientPool)
 	defer func() {
 		_ = mgr.Close(ctx)
 	}()
@@ -59,7 +66,8 @@ func (r *Reconciler) GetProviderSecretData(ctx context.Context, externalSecret *
 			// A generator is expected to always generate a secret.
 			// If it doesn't, it should return an error.
 			// If the error is NoSecretErr, we should commit the generator state.
-			if err != nil && !errors.Is(err, esv1.NoSecretErr) {
+			// The values generated by a dry run are never used, so they are rolled back as well.
+			if (err != nil && !errors.Is(err, esv1.NoSecretErr)) || externalSecret.Spec.DryRun {
 				if rollBackErr := genState.Rollback(); rollBackErr != nil {
 					r.Log.Error(rollBackErr, "error rolling back generator state")
 				}
@@ -73,22 +81,25 @@ func (r *Reconciler) GetProviderSecretData(ctx context.Context, externalSecret *
 			}
 		}()
 	}
+	// expiry tracks the earliest expiration of the generated values and the remote secrets.
+	var expiry valueExpiry
+	prov := newProvenance(externalSecret)
 	providerData = make(map[string][]byte)
 	for i, remoteRef := range externalSecret.Spec.DataFrom {
 		var secretMap map[string][]byte
 
 		if remoteRef.Find != nil {
-			secretMap, err = r.handleFindAllSecrets(ctx, externalSecret, remoteRef, mgr, genState, i)
+			secretMap, err = r.handleFindAllSecrets(ctx, externalSecret, remoteRef, mgr, genState, i, prov)
 			if err != nil {
 				err = fmt.Errorf("error processing spec.dataFrom[%d].find, err: %w", i, err)
 			}
 		} else if remoteRef.Extract != nil {
-			secretMap, err = r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr, genState, i)
+			secretMap, err = r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr, genState, i, prov)
 			if err != nil {
 				err = fmt.Errorf("error processing spec.dataFrom[%d].extract, err: %w", i, err)
 			}
 		} else if remoteRef.SourceRef != nil && remoteRef.SourceRef.GeneratorRef != nil {
-			secretMap, err = r.handleGenerateSecrets(ctx, externalSecret.Namespace, remoteRef, i, genState)
+			secretMap, err = r.handleGenerateSecrets(ctx, externalSecret, remoteRef, i, genState, &expiry, prov)
 			if err != nil {
 				err = fmt.Errorf("error processing spec.dataFrom[%d].sourceRef.generatorRef, err: %w", i, err)
 			}
@@ -106,7 +117,7 @@ func (r *Reconciler) GetProviderSecretData(ctx context.Context, externalSecret *
 	}
 
 	for i, secretRef := range externalSecret.Spec.Data {
-		err := r.handleSecretData(ctx, externalSecret, secretRef, providerData, mgr)
+		err := r.handleSecretData(ctx, externalSecret, secretRef, providerData, mgr, &expiry)
 		if errors.Is(err, esv1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1.DeletionPolicyRetain {
 			r.recorder.Eventf(externalSecret, v1.EventTypeNormal, esv1.ReasonMissingProviderSecret, eventMissingProviderSecretKey, i, secretRef.RemoteRef.Key)
 			continue
@@ -114,22 +125,36 @@ func (r *Reconciler) GetProviderSecretData(ctx context.Context, externalSecret *
 		if err != nil {
 			return nil, fmt.Errorf("error processing spec.data[%d] (key: %s), err: %w", i, secretRef.RemoteRef.Key, err)
 		}
+		prov.set(secretRef.SecretKey, dataSource(externalSecret, i))
 	}
 
+	setExpiry(externalSecret, expiry, time.Now())
+	externalSecret.Status.Provenance = prov.status()
 	return providerData, nil
 }
 
-func (r *Reconciler) handleSecretData(ctx context.Context, externalSecret *esv1.ExternalSecret, secretRef esv1.ExternalSecretData, providerData map[string][]byte, cmgr *secretstore.Manager) error {
-	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, toStoreGenSourceRef(secretRef.SourceRef))
+func (r *Reconciler) handleSecretData(ctx context.Context, externalSecret *esv1.ExternalSecret, secretRef esv1.ExternalSecretData, providerData map[string][]byte, cmgr *secretstore.Manager, expiry *valueExpiry) error {
+	sourceRef := toStoreGenSourceRef(secretRef.SourceRef)
+	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, sourceRef)
 	if err != nil {
 		return err
 	}
 
 	// get a single secret from the store
-	secretData, err := client.GetSecret(ctx, secretRef.RemoteRef)
+	start := time.Now()
+	secretData, metadata, err := getSecret(ctx, client, secretRef.RemoteRef)
+	event := auditEvent(externalSecret, audit.OperationGetSecret, sourceRef)
+	event.RemoteKey = secretRef.RemoteRef.Key
+	event.Property = secretRef.RemoteRef.Property
+	event.Version = secretRef.RemoteRef.Version
+	if metadata != nil && metadata.Version != "" {
+		event.Version = metadata.Version
+	}
+	r.Auditor.Record(event, start, err)
 	if err != nil {
 		return err
 	}
+	expiry.observeSecret(secretRef.RemoteRef.Key, metadata)
 
 	// decode the secret if needed
 	secretData, err = esutils.Decode(secretRef.RemoteRef.DecodingStrategy, secretData)
@@ -137,12 +162,73 @@ func (r *Reconciler) handleSecretData(ctx context.Context, externalSecret *esv1.
 		return fmt.Errorf(errDecode, secretRef.RemoteRef.DecodingStrategy, err)
 	}
 
+	// decrypt the secret if needed
+	if decryption := secretRef.RemoteRef.Decryption; decryption != nil {
+		secretData, err = r.decrypt(ctx, externalSecret.Namespace, decryption, secretData)
+		if err != nil {
+			return fmt.Errorf(errDecrypt, decryption.Method, err)
+		}
+	}
+
 	// store the secret data
 	providerData[secretRef.SecretKey] = secretData
 
 	return nil
 }
 
+// decrypter returns a function that decrypts data with the key referenced by the decryption configuration.
+// Keys are always resolved from the namespace of the ExternalSecret.
+func (r *Reconciler) decrypter(ctx context.Context, namespace string, decryption *esv1.ExternalSecretDecryption) (func(data []byte) ([]byte, error), error) {
+	key, err := resolvers.SecretKeyRef(ctx, r.Client, resolvers.EmptyStoreKind, namespace, &decryption.KeySecretRef)
+	if err != nil {
+		return nil, err
+	}
+	switch decryption.Method {
+	case esv1.ExternalSecretDecryptionAge:
+		return func(data []byte) ([]byte, error) {
+			return envelope.AgeDecrypt(key, data)
+		}, nil
+	case esv1.ExternalSecretDecryptionPGP:
+		var passphrase string
+		if decryption.PassphraseSecretRef != nil {
+			passphrase, err = resolvers.SecretKeyRef(ctx, r.Client, resolvers.EmptyStoreKind, namespace, decryption.PassphraseSecretRef)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return func(data []byte) ([]byte, error) {
+			return envelope.PGPDecrypt(key, passphrase, data)
+		}, nil
+	default:
+		return nil, fmt.Errorf("decryption method %v is not supported", decryption.Method)
+	}
+}
+
+// decrypt decrypts the data with the key referenced by the decryption configuration.
+func (r *Reconciler) decrypt(ctx context.Context, namespace string, decryption *esv1.ExternalSecretDecryption, data []byte) ([]byte, error) {
+	decrypt, err := r.decrypter(ctx, namespace, decryption)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(data)
+}
+
+// decryptMap decrypts all values of the map with the key referenced by the decryption configuration.
+func (r *Reconciler) decryptMap(ctx context.Context, namespace string, decryption *esv1.ExternalSecretDecryption, in map[string][]byte) (map[string][]byte, error) {
+	decrypt, err := r.decrypter(ctx, namespace, decryption)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		out[k], err = decrypt(v)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k, err)
+		}
+	}
+	return out, nil
+}
+
 func toStoreGenSourceRef(ref *esv1.StoreSourceRef) *esv1.StoreGeneratorSourceRef {
 	if ref == nil {
 		return nil
@@ -154,11 +240,14 @@ func toStoreGenSourceRef(ref *esv1.StoreSourceRef) *esv1.StoreGeneratorSourceRef
 
 func (r *Reconciler) handleGenerateSecrets(
 	ctx context.Context,
-	namespace string,
+	externalSecret *esv1.ExternalSecret,
 	remoteRef esv1.ExternalSecretDataFromRemoteRef,
 	i int,
 	generatorState *statemanager.Manager,
+	expiry *valueExpiry,
+	prov provenance,
 ) (map[string][]byte, error) {
+	namespace := externalSecret.Namespace
 	impl, generatorResource, err := resolvers.GeneratorRef(ctx, r.Client, r.Scheme, namespace, remoteRef.SourceRef.GeneratorRef)
 	if err != nil {
 		return nil, err
@@ -170,20 +259,31 @@ func (r *Reconciler) handleGenerateSecrets(
 			return nil, fmt.Errorf("unable to get latest state: %w", err)
 		}
 	}
-	secretMap, newState, err := impl.Generate(ctx, generatorResource, r.Client, namespace)
+	secretMap, newState, err := statemanager.Generate(statemanager.WithManager(ctx, generatorState), impl, generatorResource, latestState, r.Client, namespace)
 	if err != nil {
 		return nil, fmt.Errorf(errGenerate, err)
 	}
 	if latestState != nil {
 		if generatorState != nil {
-			generatorState.EnqueueMoveStateToGC(generatorStateKey(i))
+			if policy := externalSecret.Spec.GeneratorRotationPolicy; policy != nil {
+				generatorState.EnqueueRotateState(generatorStateKey(i), rotationPolicy(externalSecret, policy))
+			} else {
+				generatorState.EnqueueMoveStateToGC(generatorStateKey(i))
+			}
 		}
 	}
 	if generatorState != nil {
 		generatorState.EnqueueSetLatest(ctx, generatorStateKey(i), namespace, generatorResource, impl, newState)
 	}
+	// keep track of short-lived values, so they are refreshed before they expire.
+	// this has to happen before the keys are rewritten.
+	if expiring, ok := impl.(genv1alpha1.ExpiringGenerator); ok {
+		if exp, ok := expiring.ExpiresAt(secretMap); ok {
+			expiry.observe(exp)
+		}
+	}
 	// rewrite the keys if needed
-	secretMap, err = esutils.RewriteMap(remoteRef.Rewrite, secretMap)
+	secretMap, origins, err := prov.rewrite(remoteRef.Rewrite, secretMap)
 	if err != nil {
 		return nil, fmt.Errorf(errRewrite, err)
 	}
@@ -194,9 +294,67 @@ func (r *Reconciler) handleGenerateSecrets(
 		return nil, fmt.Errorf(errInvalidKeys, err)
 	}
 
+	// expose the previous values if needed
+	secretMap, err = r.addPreviousGeneratedValues(ctx, externalSecret, secretMap)
+	if err != nil {
+		return nil, fmt.Errorf(errPreviousValues, err)
+	}
+	if policy := externalSecret.Spec.GeneratorRotationPolicy; prov != nil && policy != nil && policy.PreviousKeySuffix != "" {
+		for key := range secretMap {
+			if _, ok := origins[key]; !ok {
+				origins[key] = origins[strings.TrimSuffix(key, policy.PreviousKeySuffix)]
+			}
+		}
+	}
+	prov.setMap(secretMap, origins, generatorSource(externalSecret, i))
+
 	return secretMap, err
 }
 
+// rotationPolicy converts the rotation policy of the ExternalSecret.
+// The TTL defaults to the refresh interval of the ExternalSecret.
+func rotationPolicy(externalSecret *esv1.ExternalSecret, policy *esv1.GeneratorRotationPolicy) statemanager.RotationPolicy {
+	rp := statemanager.RotationPolicy{
+		Keep: policy.PreviousVersions,
+	}
+	if policy.TTL != nil {
+		rp.TTL = policy.TTL.Duration
+	} else if externalSecret.Spec.RefreshInterval != nil {
+		rp.TTL = externalSecret.Spec.RefreshInterval.Duration
+	}
+	return rp
+}
+
+// addPreviousGeneratedValues adds the values of the target Secret before this refresh
+// under the configured previous key suffix, so consumers can use both the new
+// and the previous credential during rotation. With a template, the values of
+// the target Secret are not the generated ones, so none are added.
+func (r *Reconciler) addPreviousGeneratedValues(ctx context.Context, externalSecret *esv1.ExternalSecret, secretMap map[string][]byte) (map[string][]byte, error) {
+	policy := externalSecret.Spec.GeneratorRotationPolicy
+	if policy == nil || policy.PreviousKeySuffix == "" || isGenericTarget(externalSecret) || externalSecret.Spec.Target.Template != nil {
+		return secretMap, nil
+	}
+	secretName := externalSecret.Spec.Target.Name
+	if secretName == "" {
+		secretName = externalSecret.Name
+	}
+	existingSecret := &v1.Secret{}
+	err := r.SecretClient.Get(ctx, client.ObjectKey{Name: secretName, Namespace: externalSecret.Namespace}, existingSecret)
+	if apierrors.IsNotFound(err) {
+		return secretMap, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	previous := make(map[string][]byte, len(secretMap))
+	for key := range secretMap {
+		if val, ok := existingSecret.Data[key]; ok {
+			previous[key+policy.PreviousKeySuffix] = val
+		}
+	}
+	return esutils.MergeByteMap(secretMap, previous), nil
+}
+
 // We're using the index of the generator as the key for the generator state
 // this is because we can have multiple generators in the same ExternalSecret
 // and we need to keep track of the state of each generator.
@@ -211,6 +369,7 @@ func (r *Reconciler) handleExtractSecrets(
 	cmgr *secretstore.Manager,
 	genState *statemanager.Manager,
 	i int,
+	prov provenance,
 ) (map[string][]byte, error) {
 	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, remoteRef.SourceRef)
 	if err != nil {
@@ -218,18 +377,24 @@ func (r *Reconciler) handleExtractSecrets(
 	}
 
 	// get multiple secrets from the store
+	start := time.Now()
 	secretMap, err := client.GetSecretMap(ctx, *remoteRef.Extract)
+	event := auditEvent(externalSecret, audit.OperationGetSecretMap, remoteRef.SourceRef)
+	event.RemoteKey = remoteRef.Extract.Key
+	event.Property = remoteRef.Extract.Property
+	event.Version = remoteRef.Extract.Version
+	r.Auditor.Record(event, start, err)
 	if err != nil {
 		return nil, err
 	}
 
 	// rewrite the keys if needed
-	secretMap, err = esutils.RewriteMap(remoteRef.Rewrite, secretMap)
+	secretMap, origins, err := prov.rewrite(remoteRef.Rewrite, secretMap)
 	if err != nil {
 		return nil, fmt.Errorf(errRewrite, err)
 	}
 	if len(remoteRef.Rewrite) == 0 {
-		secretMap, err = esutils.ConvertKeys(remoteRef.Extract.ConversionStrategy, secretMap)
+		secretMap, origins, err = prov.convertKeys(remoteRef.Extract.ConversionStrategy, secretMap)
 		if err != nil {
 			return nil, fmt.Errorf(errConvert, remoteRef.Extract.ConversionStrategy, err)
 		}
@@ -246,6 +411,15 @@ func (r *Reconciler) handleExtractSecrets(
 	if err != nil {
 		return nil, fmt.Errorf(errDecode, remoteRef.Extract.DecodingStrategy, err)
 	}
+
+	// decrypt the secrets if needed
+	if decryption := remoteRef.Extract.Decryption; decryption != nil {
+		secretMap, err = r.decryptMap(ctx, externalSecret.Namespace, decryption, secretMap)
+		if err != nil {
+			return nil, fmt.Errorf(errDecrypt, decryption.Method, err)
+		}
+	}
+	prov.setMap(secretMap, origins, extractSource(externalSecret, i))
 	if genState != nil {
 		genState.EnqueueFlagLatestStateForGC(generatorStateKey(i))
 	}
@@ -259,6 +433,7 @@ func (r *Reconciler) handleFindAllSecrets(
 	cmgr *secretstore.Manager,
 	genState *statemanager.Manager,
 	i int,
+	prov provenance,
 ) (map[string][]byte, error) {
 	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, remoteRef.SourceRef)
 	if err != nil {
@@ -266,18 +441,25 @@ func (r *Reconciler) handleFindAllSecrets(
 	}
 
 	// get all secrets from the store that match the selector
+	start := time.Now()
 	secretMap, err := client.GetAllSecrets(ctx, *remoteRef.Find)
+	event := auditEvent(externalSecret, audit.OperationGetAllSecrets, remoteRef.SourceRef)
+	for key := range secretMap {
+		event.Keys = append(event.Keys, key)
+	}
+	slices.Sort(event.Keys)
+	r.Auditor.Record(event, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("error getting all secrets: %w", err)
 	}
 
 	// rewrite the keys if needed
-	secretMap, err = esutils.RewriteMap(remoteRef.Rewrite, secretMap)
+	secretMap, origins, err := prov.rewrite(remoteRef.Rewrite, secretMap)
 	if err != nil {
 		return nil, fmt.Errorf(errRewrite, err)
 	}
 	if len(remoteRef.Rewrite) == 0 {
-		secretMap, err = esutils.ConvertKeys(remoteRef.Find.ConversionStrategy, secretMap)
+		secretMap, origins, err = prov.convertKeys(remoteRef.Find.ConversionStrategy, secretMap)
 		if err != nil {
 			return nil, fmt.Errorf(errConvert, remoteRef.Find.ConversionStrategy, err)
 		}
@@ -294,6 +476,7 @@ func (r *Reconciler) handleFindAllSecrets(
 	if err != nil {
 		return nil, fmt.Errorf(errDecode, remoteRef.Find.DecodingStrategy, err)
 	}
+	prov.setMap(secretMap, origins, findSource(externalSecret, i))
 	if genState != nil {
 		genState.EnqueueFlagLatestStateForGC(generatorStateKey(i))
 	}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/runtime/envelope"
+)
+
+func TestDecryptMap(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypt := func(value string) []byte {
+		ciphertext, err := envelope.AgeEncrypt(identity.Recipient().String(), []byte(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ciphertext
+	}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "age-identity", Namespace: "default"},
+			Data:       map[string][]byte{"keys.txt": []byte(identity.String())},
+		}).Build(),
+	}
+	decryption := &esv1.ExternalSecretDecryption{
+		Method:       esv1.ExternalSecretDecryptionAge,
+		KeySecretRef: esmeta.SecretKeySelector{Name: "age-identity", Key: "keys.txt"},
+	}
+
+	got, err := r.decryptMap(context.Background(), "default", decryption, map[string][]byte{
+		"username": encrypt("admin"),
+		"password": encrypt("secret"),
+	})
+	if err != nil {
+		t.Fatalf("decryptMap() error = %v", err)
+	}
+	want := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("secret"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("decryptMap() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := r.decryptMap(context.Background(), "default", decryption, map[string][]byte{"plain": []byte("secret")}); err == nil {
+		t.Error("decryptMap() of a plaintext value must fail")
+	}
+}
+
+func TestAddPreviousGeneratedValues(t *testing.T) {
+	kube := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("PREVIOUS")},
+	}).Build()
+	r := &Reconciler{SecretClient: kube}
+	newES := func(template *esv1.ExternalSecretTemplate) *esv1.ExternalSecret {
+		return &esv1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+			Spec: esv1.ExternalSecretSpec{
+				Target:                  esv1.ExternalSecretTarget{Name: "target", Template: template},
+				GeneratorRotationPolicy: &esv1.GeneratorRotationPolicy{PreviousKeySuffix: "-previous"},
+			},
+		}
+	}
+
+	got, err := r.addPreviousGeneratedValues(context.Background(), newES(nil), map[string][]byte{"password": []byte("current")})
+	if err != nil {
+		t.Fatalf("addPreviousGeneratedValues() error = %v", err)
+	}
+	want := map[string][]byte{"password": []byte("current"), "password-previous": []byte("PREVIOUS")}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("addPreviousGeneratedValues() mismatch (-want +got):\n%s", diff)
+	}
+
+	// the target Secret holds the rendered template, not the previous generated value.
+	template := &esv1.ExternalSecretTemplate{Data: map[string]string{"password": "{{ .password | upper }}"}}
+	got, err = r.addPreviousGeneratedValues(context.Background(), newES(template), map[string][]byte{"password": []byte("current")})
+	if err != nil {
+		t.Fatalf("addPreviousGeneratedValues() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string][]byte{"password": []byte("current")}, got); diff != "" {
+		t.Errorf("addPreviousGeneratedValues() with template mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -90,15 +90,19 @@ func (r *Reconciler) ApplyTemplate(ctx context.Context, es *esv1.ExternalSecret,
 		return fmt.Errorf(errExecTpl, err)
 	}
 
+	// labels and annotations are only templated by the v2 engine,
+	// other engines keep them as literal values.
+	if es.Spec.Target.Template.EngineVersion != esv1.TemplateEngineV2 {
+		return nil
+	}
+
 	// apply templates for labels
-	// NOTE: this only works for v2 templates
 	err = p.MergeMap(es.Spec.Target.Template.Metadata.Labels, esv1.TemplateTargetLabels)
 	if err != nil {
 		return fmt.Errorf(errExecTpl, err)
 	}
 
 	// apply template for annotations
-	// NOTE: this only works for v2 templates
 	err = p.MergeMap(es.Spec.Target.Template.Metadata.Annotations, esv1.TemplateTargetAnnotations)
 	if err != nil {
 		return fmt.Errorf(errExecTpl, err)
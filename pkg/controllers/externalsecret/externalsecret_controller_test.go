@@ -19,6 +19,7 @@ package externalsecret
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"strconv"
 	"time"
 
+	"filippo.io/age"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/onsi/gomega/format"
@@ -40,10 +42,12 @@ import (
 
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	ctest "github.com/external-secrets/external-secrets/pkg/controllers/commontest"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 	ctrlutil "github.com/external-secrets/external-secrets/pkg/controllers/util"
+	"github.com/external-secrets/external-secrets/runtime/envelope"
 	"github.com/external-secrets/external-secrets/runtime/esutils"
 	"github.com/external-secrets/external-secrets/runtime/testing/fake"
 
@@ -365,6 +369,72 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// values are decoded and then decrypted with the referenced age identity
+	syncWithDecryption := func(tc *testCase) {
+		identity, err := age.GenerateX25519Identity()
+		Expect(err).ToNot(HaveOccurred())
+		ciphertext, err := envelope.AgeEncrypt(identity.Recipient().String(), []byte(secretVal))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "age-identity",
+				Namespace: ExternalSecretNamespace,
+			},
+			Data: map[string][]byte{
+				"keys.txt": []byte(identity.String()),
+			},
+		})).To(Succeed())
+		fakeProvider.WithGetSecret([]byte(base64.StdEncoding.EncodeToString(ciphertext)), nil)
+		tc.externalSecret.Spec.Data[0].RemoteRef.DecodingStrategy = esv1.ExternalSecretDecodeBase64
+		tc.externalSecret.Spec.Data[0].RemoteRef.Decryption = &esv1.ExternalSecretDecryption{
+			Method: esv1.ExternalSecretDecryptionAge,
+			KeySecretRef: esmeta.SecretKeySelector{
+				Name: "age-identity",
+				Key:  "keys.txt",
+			},
+		}
+		tc.checkSecret = func(_ *esv1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+		}
+	}
+
+	// values extracted by dataFrom are decrypted as well
+	syncExtractWithDecryption := func(tc *testCase) {
+		identity, err := age.GenerateX25519Identity()
+		Expect(err).ToNot(HaveOccurred())
+		ciphertext, err := envelope.AgeEncrypt(identity.Recipient().String(), []byte(secretVal))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "age-identity-extract",
+				Namespace: ExternalSecretNamespace,
+			},
+			Data: map[string][]byte{
+				"keys.txt": []byte(identity.String()),
+			},
+		})).To(Succeed())
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"password": ciphertext,
+		}, nil)
+		tc.externalSecret.Spec.DataFrom = []esv1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1.ExternalSecretDataRemoteRef{
+					Key: "datamap",
+					Decryption: &esv1.ExternalSecretDecryption{
+						Method: esv1.ExternalSecretDecryptionAge,
+						KeySecretRef: esmeta.SecretKeySelector{
+							Name: "age-identity-extract",
+							Key:  "keys.txt",
+						},
+					},
+				},
+			},
+		}
+		tc.checkSecret = func(_ *esv1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data["password"])).To(Equal(secretVal))
+		}
+	}
+
 	// labels and annotations from the Kind=ExternalSecret
 	// should be copied over to the Kind=Secret
 	syncLabelsAnnotations := func(tc *testCase) {
@@ -2076,6 +2146,112 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// with driftPolicy=Report a changed secret is kept and reported with the Drifted condition
+	driftPolicyReport := func(tc *testCase) {
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Minute * 10}
+		tc.externalSecret.Spec.Target.DriftPolicy = esv1.DriftPolicyReport
+		tc.checkSecret = func(es *esv1.ExternalSecret, secret *v1.Secret) {
+			cleanSecret := secret.DeepCopy()
+			secret.Data[targetProp] = []byte("changed")
+			Expect(k8sClient.Patch(context.Background(), secret, client.MergeFrom(cleanSecret))).To(Succeed())
+
+			Eventually(func() bool {
+				var refreshedES esv1.ExternalSecret
+				if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &refreshedES); err != nil {
+					return false
+				}
+				cond := GetExternalSecretCondition(refreshedES.Status, esv1.ExternalSecretDrifted)
+				return cond != nil && cond.Status == v1.ConditionTrue && cond.Reason == esv1.ConditionReasonSecretDrifted
+			}, timeout, interval).Should(BeTrue())
+
+			Consistently(func() bool {
+				var refreshedSecret v1.Secret
+				if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &refreshedSecret); err != nil {
+					return false
+				}
+				return string(refreshedSecret.Data[targetProp]) == "changed"
+			}, time.Second*2, interval).Should(BeTrue())
+		}
+	}
+
+	// with driftPolicy=Adopt a changed value is pushed to the provider and kept in the secret
+	driftPolicyAdopt := func(tc *testCase) {
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		fakeProvider.WithSetSecret(nil)
+		fakeProvider.WithCapabilities(esv1.SecretStoreReadWrite)
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Minute * 10}
+		tc.externalSecret.Spec.Target.DriftPolicy = esv1.DriftPolicyAdopt
+		tc.secretStore.GetSpec().AllowDriftAdoption = true
+		tc.checkSecret = func(es *esv1.ExternalSecret, secret *v1.Secret) {
+			cleanSecret := secret.DeepCopy()
+			secret.Data[targetProp] = []byte("changed")
+			Expect(k8sClient.Patch(context.Background(), secret, client.MergeFrom(cleanSecret))).To(Succeed())
+
+			Eventually(func() bool {
+				pushed, ok := fakeProvider.GetPushSecretData()[remoteKey]
+				return ok && string(pushed.Value) == "changed"
+			}, timeout, interval).Should(BeTrue())
+
+			Eventually(func() bool {
+				var refreshedSecret v1.Secret
+				if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &refreshedSecret); err != nil {
+					return false
+				}
+				return string(refreshedSecret.Data[targetProp]) == "changed" &&
+					refreshedSecret.Annotations[esv1.AnnotationDataHash] == esutils.ObjectHash(refreshedSecret.Data)
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// with dryRun the secret is not created, the planned keys are recorded in the status
+	dryRunPlan := func(tc *testCase) {
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.externalSecret.Spec.DryRun = true
+		tc.checkSecret = nil
+		tc.checkCondition = func(es *esv1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1.ExternalSecretReady)
+			return cond != nil && cond.Status == v1.ConditionTrue && cond.Reason == esv1.ConditionReasonDryRun
+		}
+		tc.checkExternalSecret = func(es *esv1.ExternalSecret) {
+			key, err := planHashKey.Get(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(es.Status.Plan).To(Equal(&esv1.ExternalSecretPlan{
+				Target: ExternalSecretTargetSecretName,
+				Create: true,
+				Keys: []esv1.ExternalSecretPlanKey{
+					{Key: targetProp, Action: esv1.PlanActionAdd, Hash: valueHash(key, []byte(secretVal))},
+				},
+			}))
+			secretLookupKey := types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}
+			Consistently(func() bool {
+				err := k8sClient.Get(context.Background(), secretLookupKey, &v1.Secret{})
+				return apierrors.IsNotFound(err)
+			}, time.Second*2, interval).Should(BeTrue())
+		}
+	}
+
+	recordProvenance := func(tc *testCase) {
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.externalSecret.Spec.RecordProvenance = true
+		tc.checkExternalSecret = func(es *esv1.ExternalSecret) {
+			Expect(es.Status.Provenance).To(Equal([]esv1.ExternalSecretKeyProvenance{
+				{
+					Key: targetProp,
+					Sources: []esv1.ExternalSecretKeySource{
+						{
+							Ref:       "spec.data[0]",
+							StoreKind: esv1.SecretStoreKind,
+							StoreName: ExternalSecretStore,
+							RemoteKey: remoteKey,
+							Property:  remoteProperty,
+						},
+					},
+				},
+			}))
+		}
+	}
+
 	// When we update the template, remaining keys should not be preserved
 	templateShouldRewrite := func(tc *testCase) {
 		const secretVal = "someValue"
@@ -2375,6 +2551,10 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		Entry("should create proper hash annotation for the external secret with creationPolicy=Merge", checkMergeSecretDataHashAnnotation),
 		Entry("es deletes orphaned secrets", deleteOrphanedSecrets),
 		Entry("should refresh when the hash annotation doesn't correspond to secret data", checkSecretDataHashAnnotationChange),
+		Entry("should keep and report a changed secret with driftPolicy=Report", driftPolicyReport),
+		Entry("should push a changed secret to the provider with driftPolicy=Adopt", driftPolicyAdopt),
+		Entry("should plan the secret without creating it with dryRun", dryRunPlan),
+		Entry("should record the provenance of the keys with recordProvenance", recordProvenance),
 		Entry("should use external secret name if target secret name isn't defined", syncWithoutTargetName),
 		Entry("should sync to target secrets with naming bigger than 63 characters", syncBigNames),
 		Entry("should expose the secret as a provisioned service binding secret", syncBindingSecret),
@@ -2394,6 +2574,8 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		Entry("should not process generatorRef with mismatching controller field", ignoreMismatchControllerForGeneratorRef),
 		Entry("should sync with multiple secret stores via sourceRef", syncWithMultipleSecretStores),
 		Entry("should sync with template", syncWithTemplate),
+		Entry("should sync with decryption", syncWithDecryption),
+		Entry("should sync dataFrom.extract with decryption", syncExtractWithDecryption),
 		Entry("should sync with template engine v2", syncWithTemplateV2),
 		Entry("should sync template with correct value precedence", syncWithTemplatePrecedence),
 		Entry("should sync template from keys and values", syncTemplateFromKeysAndValues),
@@ -2596,6 +2778,142 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 			Expect(shouldRefresh(es)).To(BeTrue())
 		})
 
+		It("should refresh when generated values are about to expire", func() {
+			es := &esv1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Spec: esv1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				},
+				Status: esv1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(metav1.Now().Add(-time.Minute * 9)),
+					ExpiresAt:   &metav1.Time{Time: metav1.Now().Add(time.Minute)},
+				},
+			}
+			// resource version matches
+			es.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(es.ObjectMeta)
+			Expect(shouldRefresh(es)).To(BeTrue())
+		})
+
+		It("should refresh expiring values even if refreshInterval is 0", func() {
+			es := &esv1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Spec: esv1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: 0},
+				},
+				Status: esv1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(metav1.Now().Add(-time.Minute * 9)),
+					ExpiresAt:   &metav1.Time{Time: metav1.Now().Add(time.Minute)},
+				},
+			}
+			// resource version matches
+			es.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(es.ObjectMeta)
+			Expect(shouldRefresh(es)).To(BeTrue())
+		})
+
+		It("should not refresh when generated values are far from expiry", func() {
+			es := &esv1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Spec: esv1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				},
+				Status: esv1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(metav1.Now().Add(-time.Minute)),
+					ExpiresAt:   &metav1.Time{Time: metav1.Now().Add(time.Minute * 9)},
+				},
+			}
+			// resource version matches
+			es.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(es.ObjectMeta)
+			Expect(shouldRefresh(es)).To(BeFalse())
+		})
+
+		It("should requeue before generated values expire", func() {
+			r := &Reconciler{}
+			es := &esv1.ExternalSecret{
+				Spec: esv1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				},
+				Status: esv1.ExternalSecretStatus{
+					RefreshTime: metav1.Now(),
+					ExpiresAt:   &metav1.Time{Time: metav1.Now().Add(time.Minute * 10)},
+				},
+			}
+			res := r.getRequeueResult(es)
+			Expect(res.RequeueAfter).To(BeNumerically("<=", time.Minute*8))
+			Expect(res.RequeueAfter).To(BeNumerically(">", time.Minute*7))
+
+			// the refresh interval is used if it is shorter
+			es.Spec.RefreshInterval.Duration = time.Minute
+			res = r.getRequeueResult(es)
+			Expect(res.RequeueAfter).To(BeNumerically("<=", time.Minute))
+		})
+
+		It("should refresh at the scheduled time", func() {
+			es := &esv1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Spec: esv1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: 0},
+					RefreshSchedule: &esv1.RefreshSchedule{Cron: "0 * * * *"},
+				},
+				Status: esv1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(metav1.Now().Add(-2 * time.Hour)),
+				},
+			}
+			es.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(es.ObjectMeta)
+			// the schedule is used instead of the refresh interval of 0
+			Expect(shouldRefresh(es)).To(BeTrue())
+
+			es.Status.RefreshTime = metav1.Now()
+			Expect(shouldRefresh(es)).To(BeFalse())
+
+			r := &Reconciler{}
+			res := r.getRequeueResult(es)
+			Expect(res.RequeueAfter).To(BeNumerically("<=", time.Hour))
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+
+			next, ok := r.nextRefreshTime(es, time.Now())
+			Expect(ok).To(BeTrue())
+			Expect(next.Minute()).To(Equal(0))
+		})
+
+		It("should defer the refresh until the sync windows allow it", func() {
+			es := &esv1.ExternalSecret{
+				Spec: esv1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Minute},
+					SyncWindows: []esv1.SyncWindow{{
+						Kind:     esv1.SyncWindowDeny,
+						Schedule: "* * * * *",
+						Duration: metav1.Duration{Duration: 24 * time.Hour},
+					}},
+				},
+				Status: esv1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(metav1.Now().Add(-time.Hour)),
+				},
+			}
+			r := &Reconciler{}
+			_, ok := r.nextRefreshTime(es, time.Now())
+			Expect(ok).To(BeFalse())
+			Expect(r.getRequeueResult(es).RequeueAfter).To(BeZero())
+
+			es.Spec.SyncWindows = []esv1.SyncWindow{{
+				Kind:     esv1.SyncWindowAllow,
+				Schedule: "0 2 * * *",
+				Duration: metav1.Duration{Duration: time.Hour},
+			}}
+			next, ok := r.nextRefreshTime(es, time.Now())
+			Expect(ok).To(BeTrue())
+			Expect(next.UTC().Hour()).To(Equal(2))
+			r.setNextRefreshTime(es, next, ok)
+			Expect(es.Status.NextRefreshTime).NotTo(BeNil())
+		})
+
 	})
 	Context("objectmeta hash", func() {
 		It("should produce different hashes for different k/v pairs", func() {
@@ -0,0 +1,173 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils"
+)
+
+// provenance collects the sources of the keys of the provider data.
+// A nil provenance records nothing, so callers do not need to check whether recording is enabled.
+type provenance map[string][]esv1.ExternalSecretKeySource
+
+func newProvenance(externalSecret *esv1.ExternalSecret) provenance {
+	if !externalSecret.Spec.RecordProvenance {
+		return nil
+	}
+	return provenance{}
+}
+
+// set replaces the sources of a key, like a later entry of the ExternalSecret replaces the value of the key.
+func (p provenance) set(key string, sources ...esv1.ExternalSecretKeySource) {
+	if p == nil {
+		return
+	}
+	p[key] = sources
+}
+
+// setMap sets the sources of all keys of a secret map.
+// origins maps the keys to the remote keys they have been derived from, keys without origins are their own origin.
+func (p provenance) setMap(secretMap map[string][]byte, origins map[string][]string, source func(origin string) esv1.ExternalSecretKeySource) {
+	if p == nil {
+		return
+	}
+	for key := range secretMap {
+		keyOrigins, ok := origins[key]
+		if !ok {
+			keyOrigins = []string{key}
+		}
+		sources := make([]esv1.ExternalSecretKeySource, 0, len(keyOrigins))
+		for _, origin := range keyOrigins {
+			sources = append(sources, source(origin))
+		}
+		p.set(key, sources...)
+	}
+}
+
+// rewrite rewrites the keys of a secret map.
+// The origins of the keys are only traced if provenance is recorded, as tracing applies the rewrites once per key.
+func (p provenance) rewrite(operations []esv1.ExternalSecretRewrite, secretMap map[string][]byte) (map[string][]byte, map[string][]string, error) {
+	if p == nil {
+		out, err := esutils.RewriteMap(operations, secretMap)
+		return out, nil, err
+	}
+	return esutils.RewriteMapOrigins(operations, secretMap)
+}
+
+// convertKeys converts the keys of a secret map, tracing their origins only if provenance is recorded.
+func (p provenance) convertKeys(strategy esv1.ExternalSecretConversionStrategy, secretMap map[string][]byte) (map[string][]byte, map[string][]string, error) {
+	if p == nil {
+		out, err := esutils.ConvertKeys(strategy, secretMap)
+		return out, nil, err
+	}
+	return esutils.ConvertKeysOrigins(strategy, secretMap)
+}
+
+// status returns the provenance sorted by key, as it is stored in the status of the ExternalSecret.
+func (p provenance) status() []esv1.ExternalSecretKeyProvenance {
+	if p == nil {
+		return nil
+	}
+	out := make([]esv1.ExternalSecretKeyProvenance, 0, len(p))
+	for key, sources := range p {
+		out = append(out, esv1.ExternalSecretKeyProvenance{Key: key, Sources: sources})
+	}
+	slices.SortFunc(out, func(a, b esv1.ExternalSecretKeyProvenance) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return out
+}
+
+// storeSource returns a source of the store an entry of the ExternalSecret reads from.
+func storeSource(ref string, storeRef esv1.SecretStoreRef, sourceRef *esv1.SecretStoreRef) esv1.ExternalSecretKeySource {
+	if sourceRef != nil {
+		storeRef = *sourceRef
+	}
+	kind := storeRef.Kind
+	if kind == "" {
+		kind = esv1.SecretStoreKind
+	}
+	return esv1.ExternalSecretKeySource{
+		Ref:       ref,
+		StoreKind: kind,
+		StoreName: storeRef.Name,
+	}
+}
+
+func dataSource(externalSecret *esv1.ExternalSecret, i int) esv1.ExternalSecretKeySource {
+	data := externalSecret.Spec.Data[i]
+	var sourceRef *esv1.SecretStoreRef
+	if data.SourceRef != nil {
+		sourceRef = &data.SourceRef.SecretStoreRef
+	}
+	source := storeSource(fmt.Sprintf("spec.data[%d]", i), externalSecret.Spec.SecretStoreRef, sourceRef)
+	source.RemoteKey = data.RemoteRef.Key
+	source.Property = data.RemoteRef.Property
+	source.Version = data.RemoteRef.Version
+	return source
+}
+
+func dataFromStoreSource(externalSecret *esv1.ExternalSecret, i int, kind string) esv1.ExternalSecretKeySource {
+	remoteRef := externalSecret.Spec.DataFrom[i]
+	var sourceRef *esv1.SecretStoreRef
+	if remoteRef.SourceRef != nil {
+		sourceRef = remoteRef.SourceRef.SecretStoreRef
+	}
+	return storeSource(fmt.Sprintf("spec.dataFrom[%d].%s", i, kind), externalSecret.Spec.SecretStoreRef, sourceRef)
+}
+
+// extractSource returns the source of a key read by spec.dataFrom[i].extract.
+func extractSource(externalSecret *esv1.ExternalSecret, i int) func(string) esv1.ExternalSecretKeySource {
+	extract := externalSecret.Spec.DataFrom[i].Extract
+	return func(origin string) esv1.ExternalSecretKeySource {
+		source := dataFromStoreSource(externalSecret, i, "extract")
+		source.RemoteKey = extract.Key
+		source.Property = origin
+		if extract.Property != "" {
+			source.Property = extract.Property + "." + origin
+		}
+		source.Version = extract.Version
+		return source
+	}
+}
+
+// findSource returns the source of a key read by spec.dataFrom[i].find.
+func findSource(externalSecret *esv1.ExternalSecret, i int) func(string) esv1.ExternalSecretKeySource {
+	return func(origin string) esv1.ExternalSecretKeySource {
+		source := dataFromStoreSource(externalSecret, i, "find")
+		source.RemoteKey = origin
+		return source
+	}
+}
+
+// generatorSource returns the source of a key generated by spec.dataFrom[i].sourceRef.generatorRef.
+func generatorSource(externalSecret *esv1.ExternalSecret, i int) func(string) esv1.ExternalSecretKeySource {
+	generatorRef := externalSecret.Spec.DataFrom[i].SourceRef.GeneratorRef
+	return func(origin string) esv1.ExternalSecretKeySource {
+		return esv1.ExternalSecretKeySource{
+			Ref:           fmt.Sprintf("spec.dataFrom[%d].sourceRef.generatorRef", i),
+			GeneratorKind: generatorRef.Kind,
+			GeneratorName: generatorRef.Name,
+			Property:      origin,
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+func TestProvenance(t *testing.T) {
+	es := &esv1.ExternalSecret{
+		Spec: esv1.ExternalSecretSpec{
+			RecordProvenance: true,
+			SecretStoreRef:   esv1.SecretStoreRef{Name: "vault"},
+			DataFrom: []esv1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1.ExternalSecretDataRemoteRef{Key: "db", Property: "creds", Version: "2"}},
+				{
+					Find:      &esv1.ExternalSecretFind{Name: &esv1.FindName{RegExp: "app-.*"}},
+					SourceRef: &esv1.StoreGeneratorSourceRef{SecretStoreRef: &esv1.SecretStoreRef{Kind: esv1.ClusterSecretStoreKind, Name: "aws"}},
+				},
+				{SourceRef: &esv1.StoreGeneratorSourceRef{GeneratorRef: &esv1.GeneratorRef{Kind: "Password", Name: "pw"}}},
+			},
+			Data: []esv1.ExternalSecretData{
+				{SecretKey: "user", RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "db", Property: "user"}},
+			},
+		},
+	}
+
+	prov := newProvenance(es)
+	prov.setMap(map[string][]byte{"user": nil, "password": nil}, nil, extractSource(es, 0))
+	prov.setMap(map[string][]byte{"all": nil}, map[string][]string{"all": {"app-a", "app-b"}}, findSource(es, 1))
+	prov.setMap(map[string][]byte{"password": nil}, nil, generatorSource(es, 2))
+	prov.set("user", dataSource(es, 0))
+
+	want := []esv1.ExternalSecretKeyProvenance{
+		{
+			Key: "all",
+			Sources: []esv1.ExternalSecretKeySource{
+				{Ref: "spec.dataFrom[1].find", StoreKind: esv1.ClusterSecretStoreKind, StoreName: "aws", RemoteKey: "app-a"},
+				{Ref: "spec.dataFrom[1].find", StoreKind: esv1.ClusterSecretStoreKind, StoreName: "aws", RemoteKey: "app-b"},
+			},
+		},
+		{
+			Key: "password",
+			Sources: []esv1.ExternalSecretKeySource{
+				{Ref: "spec.dataFrom[2].sourceRef.generatorRef", GeneratorKind: "Password", GeneratorName: "pw", Property: "password"},
+			},
+		},
+		{
+			Key: "user",
+			Sources: []esv1.ExternalSecretKeySource{
+				{Ref: "spec.data[0]", StoreKind: esv1.SecretStoreKind, StoreName: "vault", RemoteKey: "db", Property: "user"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, prov.status()); diff != "" {
+		t.Errorf("provenance mismatch (-want +got):\n%s", diff)
+	}
+
+	es.Spec.RecordProvenance = false
+	disabled := newProvenance(es)
+	disabled.set("user", dataSource(es, 0))
+	if got := disabled.status(); got != nil {
+		t.Errorf("status() of disabled provenance = %v, want nil", got)
+	}
+}
+
+func TestProvenanceRewrite(t *testing.T) {
+	rewrite := []esv1.ExternalSecretRewrite{
+		{Regexp: &esv1.ExternalSecretRewriteRegexp{Source: "app-.*", Target: "all"}},
+	}
+	in := map[string][]byte{"app-a": []byte("a"), "app-b": []byte("a")}
+	want := map[string][]byte{"all": []byte("a")}
+
+	out, origins, err := provenance{}.rewrite(rewrite, in)
+	if err != nil {
+		t.Fatalf("rewrite() error = %v", err)
+	}
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("rewrite() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string][]string{"all": {"app-a", "app-b"}}, origins); diff != "" {
+		t.Errorf("rewrite() origins mismatch (-want +got):\n%s", diff)
+	}
+
+	var disabled provenance
+	out, origins, err = disabled.rewrite(rewrite, in)
+	if err != nil {
+		t.Fatalf("rewrite() error = %v", err)
+	}
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("rewrite() of disabled provenance mismatch (-want +got):\n%s", diff)
+	}
+	if origins != nil {
+		t.Errorf("rewrite() of disabled provenance traced origins %v, want nil", origins)
+	}
+}
+
+func TestExtractSource(t *testing.T) {
+	es := &esv1.ExternalSecret{
+		Spec: esv1.ExternalSecretSpec{
+			SecretStoreRef: esv1.SecretStoreRef{Name: "vault"},
+			DataFrom: []esv1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1.ExternalSecretDataRemoteRef{Key: "db", Property: "creds", Version: "2"}},
+			},
+		},
+	}
+	want := esv1.ExternalSecretKeySource{
+		Ref:       "spec.dataFrom[0].extract",
+		StoreKind: esv1.SecretStoreKind,
+		StoreName: "vault",
+		RemoteKey: "db",
+		Property:  "creds.user",
+		Version:   "2",
+	}
+	if diff := cmp.Diff(want, extractSource(es, 0)("user")); diff != "" {
+		t.Errorf("extractSource() mismatch (-want +got):\n%s", diff)
+	}
+}
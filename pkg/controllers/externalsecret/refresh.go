@@ -0,0 +1,239 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+const (
+	// indexESRemoteRefKeyField indexes ExternalSecrets by "<store kind>/<store name>/<key>"
+	// of every key they read from a store.
+	indexESRemoteRefKeyField = ".spec.data.remoteRef.key"
+
+	// anyKey is indexed for dataFrom.find, which may read any key of a store.
+	anyKey = "*"
+
+	errRefreshNotStarted = "the ExternalSecret controller has not been started"
+	errRefreshNamespace  = "the namespace of a SecretStore is required"
+)
+
+// remoteRefKeyIndexValues returns the index values of all keys the ExternalSecret reads.
+func remoteRefKeyIndexValues(es *esv1.ExternalSecret) []string {
+	values := make(map[string]struct{})
+	add := func(ref *esv1.SecretStoreRef, key string) {
+		if ref == nil || ref.Name == "" {
+			ref = &es.Spec.SecretStoreRef
+		}
+		if ref.Name == "" {
+			return
+		}
+		values[remoteRefKeyIndexValue(*ref, key)] = struct{}{}
+	}
+
+	for _, data := range es.Spec.Data {
+		var ref *esv1.SecretStoreRef
+		if data.SourceRef != nil {
+			if data.SourceRef.GeneratorRef != nil {
+				continue
+			}
+			ref = &data.SourceRef.SecretStoreRef
+		}
+		add(ref, data.RemoteRef.Key)
+	}
+	for _, dataFrom := range es.Spec.DataFrom {
+		var ref *esv1.SecretStoreRef
+		if dataFrom.SourceRef != nil {
+			if dataFrom.SourceRef.GeneratorRef != nil {
+				continue
+			}
+			ref = dataFrom.SourceRef.SecretStoreRef
+		}
+		if dataFrom.Extract != nil {
+			add(ref, dataFrom.Extract.Key)
+		}
+		if dataFrom.Find != nil {
+			add(ref, anyKey)
+		}
+	}
+
+	return slices.Sorted(maps.Keys(values))
+}
+
+func remoteRefKeyIndexValue(ref esv1.SecretStoreRef, key string) string {
+	kind := ref.Kind
+	if kind == "" {
+		kind = esv1.SecretStoreKind
+	}
+	return kind + "/" + ref.Name + "/" + key
+}
+
+// refreshTrigger enqueues ExternalSecrets and remembers that they have to
+// be refreshed on their next reconcile.
+type refreshTrigger struct {
+	mu      sync.Mutex
+	queue   workqueue.TypedRateLimitingInterface[ctrl.Request]
+	pending map[types.NamespacedName]struct{}
+}
+
+func newRefreshTrigger() *refreshTrigger {
+	return &refreshTrigger{pending: make(map[types.NamespacedName]struct{})}
+}
+
+// Source returns a source.TypedSource that binds the reconcile queue to the trigger.
+func (t *refreshTrigger) Source() source.TypedSource[reconcile.Request] {
+	return source.Func(func(_ context.Context, queue workqueue.TypedRateLimitingInterface[ctrl.Request]) error {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.queue = queue
+		return nil
+	})
+}
+
+func (t *refreshTrigger) trigger(names []types.NamespacedName) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.queue == nil {
+		return errors.New(errRefreshNotStarted)
+	}
+	for _, name := range names {
+		t.pending[name] = struct{}{}
+		t.queue.Add(ctrl.Request{NamespacedName: name})
+	}
+	return nil
+}
+
+// take reports whether a refresh was requested for the ExternalSecret and clears the request.
+// It is safe to call on a nil trigger.
+func (t *refreshTrigger) take(name types.NamespacedName) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.pending[name]
+	delete(t.pending, name)
+	return ok
+}
+
+// restore requests the refresh of the ExternalSecret again without enqueueing it,
+// e.g. when the refresh has been deferred by sync windows. It is safe to call on a nil trigger.
+func (t *refreshTrigger) restore(name types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[name] = struct{}{}
+}
+
+// RequestRefresh refreshes the ExternalSecrets that read one of the keys from the store,
+// or use dataFrom.find with it, even if their refreshInterval has not passed yet.
+// esv1.RefreshAnyKey refreshes all ExternalSecrets that read from the store.
+// The namespace is required for a SecretStore, for a ClusterSecretStore it limits the
+// refresh to a namespace if set.
+// It returns the number of ExternalSecrets that have been enqueued.
+func (r *Reconciler) RequestRefresh(ctx context.Context, store esv1.SecretStoreRef, namespace string, keys []string) (int, error) {
+	if r.refresh == nil {
+		return 0, errors.New(errRefreshNotStarted)
+	}
+	if store.Kind != esv1.ClusterSecretStoreKind && namespace == "" {
+		return 0, errors.New(errRefreshNamespace)
+	}
+
+	items, err := r.listByKeys(ctx, store, namespace, keys)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[types.NamespacedName]struct{})
+	var names []types.NamespacedName
+	for _, item := range items {
+		name := types.NamespacedName{Namespace: item.Namespace, Name: item.Name}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	if err := r.refresh.trigger(names); err != nil {
+		return 0, err
+	}
+	return len(names), nil
+}
+
+// listByKeys lists the ExternalSecrets that read one of the keys from the store, or use dataFrom.find with it.
+// An ExternalSecret that reads several of the keys is listed once per key.
+// If esv1.RefreshAnyKey is one of the keys, all ExternalSecrets that read from the store are listed.
+func (r *Reconciler) listByKeys(ctx context.Context, store esv1.SecretStoreRef, namespace string, keys []string) ([]esv1.ExternalSecret, error) {
+	if slices.Contains(keys, esv1.RefreshAnyKey) {
+		list := &esv1.ExternalSecretList{}
+		if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		prefix := remoteRefKeyIndexValue(store, "")
+		readsStore := func(value string) bool {
+			return strings.HasPrefix(value, prefix)
+		}
+		var items []esv1.ExternalSecret
+		for i := range list.Items {
+			if slices.ContainsFunc(remoteRefKeyIndexValues(&list.Items[i]), readsStore) {
+				items = append(items, list.Items[i])
+			}
+		}
+		return items, nil
+	}
+
+	var items []esv1.ExternalSecret
+	for _, key := range slices.Concat(keys, []string{anyKey}) {
+		list := &esv1.ExternalSecretList{}
+		err := r.List(ctx, list, client.InNamespace(namespace), client.MatchingFieldsSelector{
+			Selector: fields.OneTermEqualSelector(indexESRemoteRefKeyField, remoteRefKeyIndexValue(store, key)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+	}
+	return items, nil
+}
+
+// refreshRequested reports whether a refresh of the ExternalSecret was requested with RequestRefresh.
+// Requests are ignored for ExternalSecrets that are not refreshed periodically.
+func (r *Reconciler) refreshRequested(es *esv1.ExternalSecret) bool {
+	requested := r.refresh.take(types.NamespacedName{Namespace: es.Namespace, Name: es.Name})
+	switch es.Spec.RefreshPolicy {
+	case esv1.RefreshPolicyCreatedOnce, esv1.RefreshPolicyOnChange:
+		return false
+	default:
+		return requested
+	}
+}
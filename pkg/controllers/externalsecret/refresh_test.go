@@ -0,0 +1,208 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+func TestRemoteRefKeyIndexValues(t *testing.T) {
+	es := &esv1.ExternalSecret{
+		Spec: esv1.ExternalSecretSpec{
+			SecretStoreRef: esv1.SecretStoreRef{Name: "default"},
+			Data: []esv1.ExternalSecretData{
+				{RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "db"}},
+				{RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "db", Property: "password"}},
+				{
+					RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: "api"},
+					SourceRef: &esv1.StoreSourceRef{SecretStoreRef: esv1.SecretStoreRef{Name: "shared", Kind: esv1.ClusterSecretStoreKind}},
+				},
+			},
+			DataFrom: []esv1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1.ExternalSecretDataRemoteRef{Key: "config"}},
+				{
+					Find:      &esv1.ExternalSecretFind{Path: ptr.To("apps/")},
+					SourceRef: &esv1.StoreGeneratorSourceRef{SecretStoreRef: &esv1.SecretStoreRef{Name: "shared", Kind: esv1.ClusterSecretStoreKind}},
+				},
+				{SourceRef: &esv1.StoreGeneratorSourceRef{GeneratorRef: &esv1.GeneratorRef{Kind: "Password", Name: "pw"}}},
+			},
+		},
+	}
+	want := []string{
+		"ClusterSecretStore/shared/*",
+		"ClusterSecretStore/shared/api",
+		"SecretStore/default/config",
+		"SecretStore/default/db",
+	}
+	if diff := cmp.Diff(want, remoteRefKeyIndexValues(es)); diff != "" {
+		t.Errorf("remoteRefKeyIndexValues() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRequestRefresh(t *testing.T) {
+	newES := func(namespace, name string, store esv1.SecretStoreRef, key string) *esv1.ExternalSecret {
+		return &esv1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: esv1.ExternalSecretSpec{
+				SecretStoreRef: store,
+				Data:           []esv1.ExternalSecretData{{RemoteRef: esv1.ExternalSecretDataRemoteRef{Key: key}}},
+			},
+		}
+	}
+	cluster := esv1.SecretStoreRef{Name: "shared", Kind: esv1.ClusterSecretStoreKind}
+	local := esv1.SecretStoreRef{Name: "local"}
+	findAll := newES("b", "find", cluster, "")
+	findAll.Spec.Data = nil
+	findAll.Spec.DataFrom = []esv1.ExternalSecretDataFromRemoteRef{{Find: &esv1.ExternalSecretFind{Name: &esv1.FindName{RegExp: ".*"}}}}
+
+	scheme := runtime.NewScheme()
+	if err := esv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	kube := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&esv1.ExternalSecret{}, indexESRemoteRefKeyField, func(obj client.Object) []string {
+			return remoteRefKeyIndexValues(obj.(*esv1.ExternalSecret))
+		}).
+		WithObjects(
+			newES("a", "db", cluster, "db"),
+			newES("b", "db", cluster, "db"),
+			newES("a", "other", cluster, "other"),
+			newES("a", "local", local, "db"),
+			newES("b", "local", local, "db"),
+			findAll,
+		).
+		Build()
+
+	r := &Reconciler{Client: kube}
+	if _, err := r.RequestRefresh(context.Background(), cluster, "", []string{"db"}); err == nil {
+		t.Fatal("RequestRefresh() before the controller has been started should fail")
+	}
+
+	r.refresh = newRefreshTrigger()
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[ctrl.Request]())
+	defer queue.ShutDown()
+	r.refresh.queue = queue
+
+	tests := []struct {
+		name      string
+		store     esv1.SecretStoreRef
+		namespace string
+		keys      []string
+		want      []types.NamespacedName
+	}{
+		{
+			name:  "cluster store in all namespaces",
+			store: cluster,
+			keys:  []string{"db"},
+			want: []types.NamespacedName{
+				{Namespace: "a", Name: "db"},
+				{Namespace: "b", Name: "db"},
+				{Namespace: "b", Name: "find"},
+			},
+		},
+		{
+			name:      "cluster store in a namespace",
+			store:     cluster,
+			namespace: "b",
+			keys:      []string{"db"},
+			want: []types.NamespacedName{
+				{Namespace: "b", Name: "db"},
+				{Namespace: "b", Name: "find"},
+			},
+		},
+		{
+			name:      "secret store in its namespace",
+			store:     local,
+			namespace: "a",
+			keys:      []string{"db", "unknown"},
+			want:      []types.NamespacedName{{Namespace: "a", Name: "local"}},
+		},
+		{
+			name:      "any key of a cluster store",
+			store:     cluster,
+			namespace: "a",
+			keys:      []string{esv1.RefreshAnyKey},
+			want: []types.NamespacedName{
+				{Namespace: "a", Name: "db"},
+				{Namespace: "a", Name: "other"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := r.RequestRefresh(context.Background(), tt.store, tt.namespace, tt.keys)
+			if err != nil {
+				t.Fatalf("RequestRefresh() error = %v", err)
+			}
+			if n != len(tt.want) || queue.Len() != len(tt.want) {
+				t.Fatalf("RequestRefresh() = %d, queue length %d, want %d", n, queue.Len(), len(tt.want))
+			}
+			for range tt.want {
+				req, _ := queue.Get()
+				queue.Done(req)
+			}
+			for _, name := range tt.want {
+				if !r.refresh.take(name) {
+					t.Errorf("refresh of %s has not been requested", name)
+				}
+				if r.refresh.take(name) {
+					t.Errorf("refresh of %s has not been cleared", name)
+				}
+			}
+		})
+	}
+
+	if _, err := r.RequestRefresh(context.Background(), local, "", []string{"db"}); err == nil {
+		t.Error("RequestRefresh() of a SecretStore without namespace should fail")
+	}
+}
+
+func TestRefreshRequested(t *testing.T) {
+	r := &Reconciler{}
+	es := &esv1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "db"}}
+	if r.refreshRequested(es) {
+		t.Error("refreshRequested() without trigger = true")
+	}
+
+	r.refresh = newRefreshTrigger()
+	name := types.NamespacedName{Namespace: "a", Name: "db"}
+	for _, policy := range []esv1.ExternalSecretRefreshPolicy{"", esv1.RefreshPolicyPeriodic, esv1.RefreshPolicyOnChange, esv1.RefreshPolicyCreatedOnce} {
+		es.Spec.RefreshPolicy = policy
+		r.refresh.pending[name] = struct{}{}
+		want := policy == "" || policy == esv1.RefreshPolicyPeriodic
+		if got := r.refreshRequested(es); got != want {
+			t.Errorf("refreshRequested() with refreshPolicy %q = %v, want %v", policy, got, want)
+		}
+		if _, ok := r.refresh.pending[name]; ok {
+			t.Errorf("refreshRequested() with refreshPolicy %q has not cleared the request", policy)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/schedule"
+)
+
+const msgInvalidSchedule = "invalid refreshSchedule or syncWindows"
+
+// usesSchedule reports whether the ExternalSecret uses a refresh schedule or sync windows.
+func usesSchedule(es *esv1.ExternalSecret) bool {
+	return es.Spec.RefreshSchedule != nil || len(es.Spec.SyncWindows) > 0
+}
+
+// scheduledRefreshTime returns the time of the next refresh after the last refresh,
+// of the refresh schedule or the refresh interval, and false if the ExternalSecret is not refreshed periodically.
+// Values that are about to expire are refreshed earlier.
+func (r *Reconciler) scheduledRefreshTime(es *esv1.ExternalSecret) (time.Time, bool) {
+	var next time.Time
+	switch {
+	case es.Spec.RefreshPolicy == esv1.RefreshPolicyCreatedOnce || es.Spec.RefreshPolicy == esv1.RefreshPolicyOnChange:
+	case es.Spec.RefreshSchedule != nil:
+		next, _ = schedule.Next(es.Spec.RefreshSchedule, es.Status.RefreshTime.Time, string(es.UID))
+	default:
+		interval := r.RequeueInterval
+		if es.Spec.RefreshInterval != nil {
+			interval = es.Spec.RefreshInterval.Duration
+		}
+		if interval > 0 {
+			next = es.Status.RefreshTime.Add(interval)
+		}
+	}
+	if expiryRefresh, ok := expiryRefreshTime(es); ok && (next.IsZero() || expiryRefresh.Before(next)) {
+		next = expiryRefresh
+	}
+	return next, !next.IsZero()
+}
+
+// nextRefreshTime returns the time of the next refresh, deferred until the sync windows allow it,
+// and false if the ExternalSecret is not refreshed periodically.
+func (r *Reconciler) nextRefreshTime(es *esv1.ExternalSecret, now time.Time) (time.Time, bool) {
+	next, ok := r.scheduledRefreshTime(es)
+	if !ok {
+		return time.Time{}, false
+	}
+	if next.Before(now) {
+		next = now
+	}
+	next, ok, err := schedule.NextAllowed(es.Spec.SyncWindows, next)
+	return next, ok && err == nil
+}
+
+// setNextRefreshTime sets status.nextRefreshTime of ExternalSecrets that use a refresh schedule or sync windows.
+func (r *Reconciler) setNextRefreshTime(es *esv1.ExternalSecret, next time.Time, ok bool) {
+	if !usesSchedule(es) || !ok {
+		es.Status.NextRefreshTime = nil
+		return
+	}
+	es.Status.NextRefreshTime = &metav1.Time{Time: next.Truncate(time.Second)}
+}
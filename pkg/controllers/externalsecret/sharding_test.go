@@ -0,0 +1,73 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/sharding"
+)
+
+func TestListExternalSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := esv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&esv1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "db"}},
+		&esv1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Namespace: "b", Name: "api"}},
+	).Build()}
+	got, err := r.listExternalSecrets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.NamespacedName{{Namespace: "a", Name: "db"}, {Namespace: "b", Name: "api"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listExternalSecrets() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileOtherShard(t *testing.T) {
+	// the replica has not joined the ring yet, so every ExternalSecret belongs to another shard
+	shard, err := sharding.New(nil, nil, sharding.Options{
+		Namespace:     "external-secrets",
+		Group:         "external-secrets-default",
+		Identity:      "eso-0",
+		LeaseDuration: 15 * time.Second,
+		RenewInterval: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the reconciler has no client, the request must be dropped before the ExternalSecret is read
+	r := &Reconciler{Log: logr.Discard(), Shard: shard}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "a", Name: "db"}})
+	if err != nil || result != (ctrl.Result{}) {
+		t.Errorf("Reconcile() = %v, %v, want an empty result", result, err)
+	}
+}
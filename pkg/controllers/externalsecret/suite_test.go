@@ -49,6 +49,7 @@ var cfg *rest.Config
 var k8sClient client.Client
 var testEnv *envtest.Environment
 var cancel context.CancelFunc
+var planHashKey *PlanHashKey
 
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -109,6 +110,12 @@ var _ = BeforeSuite(func() {
 	secretClient, err := ctrlcommon.BuildManagedSecretClient(k8sManager, "")
 	Expect(err).ToNot(HaveOccurred())
 
+	planHashKey = &PlanHashKey{
+		Reader:    k8sManager.GetAPIReader(),
+		Client:    k8sManager.GetClient(),
+		Name:      "plan-hash-key",
+		Namespace: "default",
+	}
 	err = (&Reconciler{
 		Client:                    k8sManager.GetClient(),
 		SecretClient:              secretClient,
@@ -117,6 +124,8 @@ var _ = BeforeSuite(func() {
 		Log:                       ctrl.Log.WithName("controllers").WithName("ExternalSecrets"),
 		RequeueInterval:           time.Second,
 		ClusterSecretStoreEnabled: true,
+		AllowDriftAdoption:        true,
+		PlanHashKey:               planHashKey,
 	}).SetupWithManager(ctx, k8sManager, controller.Options{
 		MaxConcurrentReconciles: 1,
 		RateLimiter:             ctrlcommon.BuildRateLimiter(),
@@ -0,0 +1,78 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// planPush records the push of a remote secret in the plan of a dry run.
+// Whether the remote secret would be created or updated is decided with SecretExists,
+// providers that do not support it get CreateOrUpdate.
+func planPush(ctx context.Context, ps *esapi.PushSecret, storeKey string, secretClient esv1.SecretsClient, ref esapi.PushSecretRemoteRef) {
+	action := esapi.PlanActionCreate
+	exists, err := secretClient.SecretExists(ctx, ref)
+	switch {
+	case err != nil:
+		action = esapi.PlanActionCreateOrUpdate
+	case exists:
+		action = esapi.PlanActionUpdate
+	}
+	addPlanEntry(ps, esapi.PushSecretPlanEntry{Store: storeKey, PushSecretRemoteRef: ref, Action: action})
+}
+
+// planDeletions records the remote secrets that are no longer pushed in the plan of a dry run.
+// It mirrors DeleteSecretFromProviders.
+func planDeletions(ps *esapi.PushSecret, newMap esapi.SyncedPushSecretsMap) {
+	for storeKey, oldData := range ps.Status.SyncedPushSecrets {
+		newData := newMap[storeKey]
+		for oldEntry, oldRef := range oldData {
+			if _, ok := newData[oldEntry]; ok {
+				continue
+			}
+			addPlanEntry(ps, esapi.PushSecretPlanEntry{Store: storeKey, PushSecretRemoteRef: oldRef.Match.RemoteRef, Action: esapi.PlanActionDelete})
+		}
+	}
+}
+
+func addPlanEntry(ps *esapi.PushSecret, entry esapi.PushSecretPlanEntry) {
+	if ps.Status.Plan == nil {
+		ps.Status.Plan = &esapi.PushSecretPlan{}
+	}
+	ps.Status.Plan.Entries = append(ps.Status.Plan.Entries, entry)
+}
+
+// sortPlan sorts the entries of a plan and removes duplicates, so the status only changes with the plan.
+func sortPlan(plan *esapi.PushSecretPlan) {
+	if plan == nil {
+		return
+	}
+	slices.SortFunc(plan.Entries, func(a, b esapi.PushSecretPlanEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Store, b.Store),
+			cmp.Compare(a.RemoteKey, b.RemoteKey),
+			cmp.Compare(a.Property, b.Property),
+			cmp.Compare(a.Action, b.Action),
+		)
+	})
+	plan.Entries = slices.Compact(plan.Entries)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/runtime/testing/fake"
+)
+
+func TestPlan(t *testing.T) {
+	ctx := context.Background()
+	existing := esapi.PushSecretRemoteRef{RemoteKey: "existing"}
+	missing := esapi.PushSecretRemoteRef{RemoteKey: "missing", Property: "password"}
+	stale := esapi.PushSecretData{Match: esapi.PushSecretMatch{SecretKey: "stale", RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: "stale"}}}
+	kept := esapi.PushSecretData{Match: esapi.PushSecretMatch{SecretKey: "kept", RemoteRef: existing}}
+
+	secretClient := fake.New()
+	secretClient.SecretExistsFn = func(_ context.Context, ref esv1.PushSecretRemoteRef) (bool, error) {
+		return ref.GetRemoteKey() == existing.RemoteKey, nil
+	}
+	ps := &esapi.PushSecret{
+		Status: esapi.PushSecretStatus{
+			SyncedPushSecrets: esapi.SyncedPushSecretsMap{
+				"SecretStore/vault": {statusRef(stale): stale, statusRef(kept): kept},
+			},
+		},
+	}
+	planPush(ctx, ps, "SecretStore/vault", secretClient, missing)
+	planPush(ctx, ps, "SecretStore/vault", secretClient, existing)
+	planPush(ctx, ps, "SecretStore/vault", secretClient, existing)
+	planDeletions(ps, esapi.SyncedPushSecretsMap{"SecretStore/vault": {statusRef(kept): kept}})
+
+	secretClient.SecretExistsFn = func(context.Context, esv1.PushSecretRemoteRef) (bool, error) {
+		return false, errors.New("not implemented")
+	}
+	planPush(ctx, ps, "SecretStore/aws", secretClient, missing)
+	sortPlan(ps.Status.Plan)
+
+	want := &esapi.PushSecretPlan{
+		Entries: []esapi.PushSecretPlanEntry{
+			{Store: "SecretStore/aws", PushSecretRemoteRef: missing, Action: esapi.PlanActionCreateOrUpdate},
+			{Store: "SecretStore/vault", PushSecretRemoteRef: existing, Action: esapi.PlanActionUpdate},
+			{Store: "SecretStore/vault", PushSecretRemoteRef: missing, Action: esapi.PlanActionCreate},
+			{Store: "SecretStore/vault", PushSecretRemoteRef: stale.Match.RemoteRef, Action: esapi.PlanActionDelete},
+		},
+	}
+	if diff := cmp.Diff(want, ps.Status.Plan); diff != "" {
+		t.Errorf("plan mismatch (-want +got):\n%s", diff)
+	}
+}
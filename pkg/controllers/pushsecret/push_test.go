@@ -0,0 +1,147 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"testing"
+
+	"filippo.io/age"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/runtime/envelope"
+)
+
+// storeRecorder records the values pushed to each store.
+type storeRecorder struct {
+	esv1.SecretsClient
+	store  string
+	pushes map[string][]byte
+}
+
+func (r *storeRecorder) PushSecret(_ context.Context, secret *v1.Secret, data esv1.PushSecretData) error {
+	r.pushes[r.store+"/"+data.GetRemoteKey()] = secret.Data[data.GetSecretKey()]
+	return nil
+}
+
+// pushToStores pushes the data of secret with ps to the SecretStores a and b and returns the pushed values.
+func pushToStores(t *testing.T, ps *esapi.PushSecret, secret *v1.Secret, objs ...client.Object) map[string][]byte {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := esv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	stores := make(map[esapi.PushSecretStoreRef]esv1.GenericStore)
+	for _, name := range []string{"a", "b"} {
+		store := &esv1.SecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ps.Namespace},
+			Spec:       esv1.SecretStoreSpec{Provider: &esv1.SecretStoreProvider{Fake: &esv1.FakeProvider{}}},
+		}
+		stores[esapi.PushSecretStoreRef{Name: name, Kind: esv1.SecretStoreKind}] = store
+		objs = append(objs, store)
+	}
+	kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	pushes := make(map[string][]byte)
+	newFn := fakeProvider.NewFn
+	fakeProvider.NewFn = func(_ context.Context, store esv1.GenericStore, _ client.Client, _ string) (esv1.SecretsClient, error) {
+		return &storeRecorder{SecretsClient: fakeProvider, store: store.GetName(), pushes: pushes}, nil
+	}
+	t.Cleanup(func() {
+		fakeProvider.NewFn = newFn
+	})
+
+	r := &Reconciler{Client: kube}
+	mgr := secretstore.NewManager(kube, "", false)
+	defer func() {
+		_ = mgr.Close(context.Background())
+	}()
+	if _, err := r.PushSecretToProviders(context.Background(), stores, ps, secret, mgr); err != nil {
+		t.Fatalf("PushSecretToProviders() error = %v", err)
+	}
+	return pushes
+}
+
+func TestPushSecretToProvidersEncoding(t *testing.T) {
+	ps := &esapi.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+		Spec: esapi.PushSecretSpec{
+			Data: []esapi.PushSecretData{{
+				Match:            esapi.PushSecretMatch{SecretKey: "key", RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: "remote"}},
+				EncodingStrategy: esapi.PushSecretEncodeBase64,
+			}},
+		},
+	}
+	secret := &v1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+
+	pushes := pushToStores(t, ps, secret)
+	for _, store := range []string{"a", "b"} {
+		if got := string(pushes[store+"/remote"]); got != "dmFsdWU=" {
+			t.Errorf("store %s got %q, want the value encoded once", store, got)
+		}
+	}
+	if got := string(secret.Data["key"]); got != "value" {
+		t.Errorf("the source Secret has been modified to %q", got)
+	}
+}
+
+func TestPushSecretToProvidersEncryption(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipients := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "recipients", Namespace: "default"},
+		Data:       map[string][]byte{"recipients.txt": []byte(identity.Recipient().String())},
+	}
+	ps := &esapi.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+		Spec: esapi.PushSecretSpec{
+			Data: []esapi.PushSecretData{{
+				Match: esapi.PushSecretMatch{SecretKey: "key", RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: "remote"}},
+				Encryption: &esapi.PushSecretEncryption{
+					Method:              esapi.PushSecretEncryptionAge,
+					RecipientsSecretRef: esmeta.SecretKeySelector{Name: "recipients", Key: "recipients.txt"},
+				},
+			}},
+		},
+	}
+	secret := &v1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+
+	pushes := pushToStores(t, ps, secret, recipients)
+	for _, store := range []string{"a", "b"} {
+		got, err := envelope.AgeDecrypt(identity.String(), pushes[store+"/remote"])
+		if err != nil {
+			t.Fatalf("store %s: unable to decrypt: %v", store, err)
+		}
+		if string(got) != "value" {
+			t.Errorf("store %s got %q, want the value encrypted once", store, got)
+		}
+	}
+}
@@ -41,10 +41,13 @@ import (
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/audit"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/schedule"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	ctrlutil "github.com/external-secrets/external-secrets/pkg/controllers/util"
+	"github.com/external-secrets/external-secrets/runtime/envelope"
 	"github.com/external-secrets/external-secrets/runtime/esutils"
 	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
 	"github.com/external-secrets/external-secrets/runtime/statemanager"
@@ -62,8 +65,11 @@ const (
 	errSetSecretFailed         = "could not write remote ref %v to target secretstore %v: %v"
 	errFailedSetSecret         = "set secret failed: %v"
 	errConvert                 = "could not apply conversion strategy to keys: %v"
+	errEncode                  = "could not apply encoding strategy %v to values: %v"
+	errEncrypt                 = "could not apply %v encryption to values: %v"
 	pushSecretFinalizer        = "pushsecret.externalsecrets.io/finalizer"
 	errCloudNotUpdateFinalizer = "could not update finalizers: %w"
+	errInvalidSchedule         = "invalid refreshSchedule or syncWindows: %v"
 )
 
 // Reconciler is the controller for PushSecret resources.
@@ -77,6 +83,10 @@ type Reconciler struct {
 	RestConfig      *rest.Config
 	RequeueInterval time.Duration
 	ControllerClass string
+	// Auditor records the calls to the providers, if set.
+	Auditor *audit.Auditor
+	// ClientPool keeps provider clients open across reconciles, if set.
+	ClientPool *secretstore.ClientPool
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -133,7 +143,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	defer func() { pushSecretReconcileDuration.With(resourceLabels).Set(float64(time.Since(start))) }()
 
 	var ps esapi.PushSecret
-	mgr := secretstore.NewManager(r.Client, r.ControllerClass, false)
+	mgr := secretstore.NewManager(r.Client, r.ControllerClass, false).WithClientPool(r.ClientPool)
 	defer func() {
 		_ = mgr.Close(ctx)
 	}()
@@ -197,16 +207,36 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	default:
 	}
 
+	if err := schedule.Validate(ps.Spec.RefreshSchedule, ps.Spec.SyncWindows); err != nil {
+		r.markAsFailed(fmt.Sprintf(errInvalidSchedule, err), &ps, nil)
+		return ctrl.Result{}, nil
+	}
+
 	timeSinceLastRefresh := 0 * time.Second
 	if !ps.Status.RefreshTime.IsZero() {
 		timeSinceLastRefresh = time.Since(ps.Status.RefreshTime.Time)
 	}
 	if !shouldRefresh(ps) {
+		if usesSchedule(&ps) {
+			next, ok := nextRefreshTime(&ps, time.Now())
+			setNextRefreshTime(&ps, next, ok)
+			log.V(1).Info("skipping refresh", "rv", ctrlutil.GetResourceVersion(ps.ObjectMeta), "next", next)
+			return schedule.RequeueAt(next, ok), nil
+		}
 		refreshInt = (ps.Spec.RefreshInterval.Duration - timeSinceLastRefresh) + 5*time.Second
 		log.V(1).Info("skipping refresh", "rv", ctrlutil.GetResourceVersion(ps.ObjectMeta), "nr", refreshInt.Seconds())
 		return ctrl.Result{RequeueAfter: refreshInt}, nil
 	}
 
+	// secrets that have been pushed before are only updated while the sync windows allow it
+	if !ps.Status.RefreshTime.IsZero() && !ps.Spec.DryRun {
+		if next, deferred := schedule.Deferred(ps.Spec.SyncWindows, time.Now()); deferred {
+			log.V(1).Info("deferring push, sync windows deny updates", "until", next)
+			setNextRefreshTime(&ps, next, !next.IsZero())
+			return schedule.RequeueAt(next, !next.IsZero()), nil
+		}
+	}
+
 	secrets, err := r.resolveSecrets(ctx, &ps)
 	if err != nil {
 		r.markAsFailed(errFailedGetSecret, &ps, nil)
@@ -241,13 +271,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	// a dry run records the changes to the providers in the plan instead of making them
+	ps.Status.Plan = nil
+	if ps.Spec.DryRun {
+		ps.Status.Plan = &esapi.PushSecretPlan{}
+	}
 	allSyncedSecrets := make(esapi.SyncedPushSecretsMap)
 	for _, secret := range secrets {
 		if err := r.applyTemplate(ctx, &ps, &secret); err != nil {
 			return ctrl.Result{}, err
 		}
 
-		syncedSecrets, err := r.PushSecretToProviders(ctx, secretStores, ps, &secret, mgr)
+		syncedSecrets, err := r.PushSecretToProviders(ctx, secretStores, &ps, &secret, mgr)
 		if err != nil {
 			if errors.Is(err, locks.ErrConflict) {
 				log.Info("retry to acquire lock to update the secret later", "error", err)
@@ -262,6 +297,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 		switch ps.Spec.DeletionPolicy {
 		case esapi.PushSecretDeletionPolicyDelete:
+			if ps.Spec.DryRun {
+				planDeletions(&ps, syncedSecrets)
+				break
+			}
 			badSyncState, err := r.DeleteSecretFromProviders(ctx, &ps, syncedSecrets, mgr)
 			if err != nil {
 				msg := fmt.Sprintf("Failed to Delete Secrets from Provider: %v", err)
@@ -275,8 +314,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		allSyncedSecrets = mergeSecretState(allSyncedSecrets, syncedSecrets)
 	}
 
-	r.markAsDone(&ps, allSyncedSecrets, start)
+	if ps.Spec.DryRun {
+		r.markAsPlanned(&ps, start)
+	} else {
+		r.markAsDone(&ps, allSyncedSecrets, start)
+	}
 
+	if usesSchedule(&ps) {
+		return schedule.RequeueAt(nextRefreshTime(&ps, start)), nil
+	}
 	return ctrl.Result{RequeueAfter: refreshInt}, nil
 }
 
@@ -284,19 +330,24 @@ func shouldRefresh(ps esapi.PushSecret) bool {
 	if ps.Status.SyncedResourceVersion != ctrlutil.GetResourceVersion(ps.ObjectMeta) {
 		return true
 	}
-	if ps.Spec.RefreshInterval.Duration == 0 && ps.Status.SyncedResourceVersion != "" {
+	if ps.Spec.RefreshSchedule == nil && ps.Spec.RefreshInterval.Duration == 0 && ps.Status.SyncedResourceVersion != "" {
 		return false
 	}
 	if ps.Status.RefreshTime.IsZero() {
 		return true
 	}
+	if ps.Spec.RefreshSchedule != nil {
+		next, err := schedule.Next(ps.Spec.RefreshSchedule, ps.Status.RefreshTime.Time, string(ps.UID))
+		return err == nil && !next.IsZero() && !next.After(time.Now())
+	}
 	return ps.Status.RefreshTime.Add(ps.Spec.RefreshInterval.Duration).Before(time.Now())
 }
 
 func (r *Reconciler) markAsFailed(msg string, ps *esapi.PushSecret, syncState esapi.SyncedPushSecretsMap) {
 	cond := NewPushSecretCondition(esapi.PushSecretReady, v1.ConditionFalse, esapi.ReasonErrored, msg)
 	SetPushSecretCondition(ps, *cond)
-	if syncState != nil {
+	// a dry run has not pushed anything
+	if syncState != nil && !ps.Spec.DryRun {
 		r.setSecrets(ps, syncState)
 	}
 	r.recorder.Event(ps, v1.EventTypeWarning, esapi.ReasonErrored, msg)
@@ -312,9 +363,24 @@ func (r *Reconciler) markAsDone(ps *esapi.PushSecret, secrets esapi.SyncedPushSe
 	r.setSecrets(ps, secrets)
 	ps.Status.RefreshTime = metav1.NewTime(start)
 	ps.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(ps.ObjectMeta)
+	next, ok := nextRefreshTime(ps, start)
+	setNextRefreshTime(ps, next, ok)
 	r.recorder.Event(ps, v1.EventTypeNormal, esapi.ReasonSynced, msg)
 }
 
+// markAsPlanned records the plan of a dry run, the synced secrets are left as they are.
+func (r *Reconciler) markAsPlanned(ps *esapi.PushSecret, start time.Time) {
+	msg := "PushSecret planned successfully, nothing has been pushed. Planned changes are listed in status.plan"
+	cond := NewPushSecretCondition(esapi.PushSecretReady, v1.ConditionTrue, esapi.ReasonDryRun, msg)
+	SetPushSecretCondition(ps, *cond)
+	sortPlan(ps.Status.Plan)
+	ps.Status.RefreshTime = metav1.NewTime(start)
+	ps.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(ps.ObjectMeta)
+	next, ok := nextRefreshTime(ps, start)
+	setNextRefreshTime(ps, next, ok)
+	r.recorder.Event(ps, v1.EventTypeNormal, esapi.ReasonDryRun, msg)
+}
+
 func (r *Reconciler) setSecrets(ps *esapi.PushSecret, status esapi.SyncedPushSecretsMap) {
 	ps.Status.SyncedPushSecrets = status
 }
@@ -351,7 +417,7 @@ func (r *Reconciler) DeleteSecretFromProviders(ctx context.Context, ps *esapi.Pu
 		}
 		newData, ok := newMap[storeName]
 		if !ok {
-			err = r.DeleteAllSecretsFromStore(ctx, client, oldData)
+			err = r.DeleteAllSecretsFromStore(ctx, ps, storeRef, client, oldData)
 			if err != nil {
 				return out, err
 			}
@@ -361,7 +427,7 @@ func (r *Reconciler) DeleteSecretFromProviders(ctx context.Context, ps *esapi.Pu
 		for oldEntry, oldRef := range oldData {
 			_, ok := newData[oldEntry]
 			if !ok {
-				err = r.DeleteSecretFromStore(ctx, client, oldRef)
+				err = r.DeleteSecretFromStore(ctx, ps, storeRef, client, oldRef)
 				if err != nil {
 					return out, err
 				}
@@ -373,9 +439,9 @@ func (r *Reconciler) DeleteSecretFromProviders(ctx context.Context, ps *esapi.Pu
 }
 
 // DeleteAllSecretsFromStore removes all secrets from a given secret store.
-func (r *Reconciler) DeleteAllSecretsFromStore(ctx context.Context, client esv1.SecretsClient, data map[string]esapi.PushSecretData) error {
+func (r *Reconciler) DeleteAllSecretsFromStore(ctx context.Context, ps *esapi.PushSecret, storeRef esv1.SecretStoreRef, client esv1.SecretsClient, data map[string]esapi.PushSecretData) error {
 	for _, v := range data {
-		err := r.DeleteSecretFromStore(ctx, client, v)
+		err := r.DeleteSecretFromStore(ctx, ps, storeRef, client, v)
 		if err != nil {
 			return err
 		}
@@ -384,8 +450,25 @@ func (r *Reconciler) DeleteAllSecretsFromStore(ctx context.Context, client esv1.
 }
 
 // DeleteSecretFromStore removes a specific secret from a given secret store.
-func (r *Reconciler) DeleteSecretFromStore(ctx context.Context, client esv1.SecretsClient, data esapi.PushSecretData) error {
-	return client.DeleteSecret(ctx, data.Match.RemoteRef)
+func (r *Reconciler) DeleteSecretFromStore(ctx context.Context, ps *esapi.PushSecret, storeRef esv1.SecretStoreRef, client esv1.SecretsClient, data esapi.PushSecretData) error {
+	start := time.Now()
+	err := client.DeleteSecret(ctx, data.Match.RemoteRef)
+	r.Auditor.Record(auditEvent(ps, audit.OperationDeleteSecret, storeRef, data.Match.RemoteRef), start, err)
+	return err
+}
+
+// auditEvent returns the audit event of a call to a store a PushSecret pushes to.
+func auditEvent(ps *esapi.PushSecret, operation audit.Operation, storeRef esv1.SecretStoreRef, remoteRef esapi.PushSecretRemoteRef) audit.Event {
+	return audit.Event{
+		Operation: operation,
+		Kind:      esapi.PushSecretKind,
+		Namespace: ps.Namespace,
+		Name:      ps.Name,
+		StoreKind: storeRef.Kind,
+		StoreName: storeRef.Name,
+		RemoteKey: remoteRef.RemoteKey,
+		Property:  remoteRef.Property,
+	}
 }
 
 // PushSecretToProviders pushes the secret data to the specified secret stores.
@@ -394,13 +477,16 @@ func (r *Reconciler) DeleteSecretFromStore(ctx context.Context, client esv1.Secr
 func (r *Reconciler) PushSecretToProviders(
 	ctx context.Context,
 	stores map[esapi.PushSecretStoreRef]esv1.GenericStore,
-	ps esapi.PushSecret,
+	ps *esapi.PushSecret,
 	secret *v1.Secret,
 	mgr *secretstore.Manager,
 ) (esapi.SyncedPushSecretsMap, error) {
 	out := make(esapi.SyncedPushSecretsMap)
+	// every store and entry converts, encrypts and encodes its own copy of the source,
+	// so that they all start from the data of the Secret.
+	source := secret.DeepCopy()
 	for ref, store := range stores {
-		out, err := r.handlePushSecretDataForStore(ctx, ps, secret, out, mgr, store.GetName(), ref.Kind)
+		out, err := r.handlePushSecretDataForStore(ctx, ps, source, out, mgr, store.GetName(), ref.Kind)
 		if err != nil {
 			return out, err
 		}
@@ -410,8 +496,8 @@ func (r *Reconciler) PushSecretToProviders(
 
 func (r *Reconciler) handlePushSecretDataForStore(
 	ctx context.Context,
-	ps esapi.PushSecret,
-	secret *v1.Secret,
+	ps *esapi.PushSecret,
+	source *v1.Secret,
 	out esapi.SyncedPushSecretsMap,
 	mgr *secretstore.Manager,
 	storeName, refKind string,
@@ -422,21 +508,34 @@ func (r *Reconciler) handlePushSecretDataForStore(
 		Name: storeName,
 		Kind: refKind,
 	}
-	originalSecretData := secret.Data
 	secretClient, err := mgr.Get(ctx, storeRef, ps.GetNamespace(), nil)
 	if err != nil {
 		return out, fmt.Errorf("could not get secrets client for store %v: %w", storeName, err)
 	}
 	for _, data := range ps.Spec.Data {
-		secretData, err := esutils.ReverseKeys(data.ConversionStrategy, originalSecretData)
+		secret := source.DeepCopy()
+		secret.Data, err = esutils.ReverseKeys(data.ConversionStrategy, source.Data)
 		if err != nil {
 			return nil, fmt.Errorf(errConvert, err)
 		}
-		secret.Data = secretData
 		key := data.GetSecretKey()
 		if !secretKeyExists(key, secret) {
 			return out, fmt.Errorf("secret key %v does not exist", key)
 		}
+		if data.Encryption != nil {
+			secret.Data, err = r.encrypt(ctx, ps.Namespace, data.Encryption, secret.Data, key)
+			if err != nil {
+				return nil, fmt.Errorf(errEncrypt, data.Encryption.Method, err)
+			}
+		}
+		if key != "" {
+			secret.Data, err = esutils.EncodeMap(data.EncodingStrategy, secret.Data, key)
+		} else {
+			secret.Data, err = esutils.EncodeMap(data.EncodingStrategy, secret.Data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf(errEncode, data.EncodingStrategy, err)
+		}
 		switch ps.Spec.UpdatePolicy {
 		case esapi.PushSecretUpdatePolicyIfNotExists:
 			exists, err := secretClient.SecretExists(ctx, data.Match.RemoteRef)
@@ -449,7 +548,15 @@ func (r *Reconciler) handlePushSecretDataForStore(
 		case esapi.PushSecretUpdatePolicyReplace:
 		default:
 		}
-		if err := secretClient.PushSecret(ctx, secret, data); err != nil {
+		if ps.Spec.DryRun {
+			planPush(ctx, ps, storeKey, secretClient, data.Match.RemoteRef)
+			out[storeKey][statusRef(data)] = data
+			continue
+		}
+		start := time.Now()
+		err = secretClient.PushSecret(ctx, secret, data)
+		r.Auditor.Record(auditEvent(ps, audit.OperationPushSecret, storeRef, data.Match.RemoteRef), start, err)
+		if err != nil {
 			return out, fmt.Errorf(errSetSecretFailed, key, storeName, err)
 		}
 		out[storeKey][statusRef(data)] = data
@@ -457,6 +564,36 @@ func (r *Reconciler) handlePushSecretDataForStore(
 	return out, nil
 }
 
+// encrypt encrypts the value of the given key, or all values if no key is given,
+// to the recipients referenced by the encryption configuration.
+// Recipients are always resolved from the namespace of the PushSecret.
+func (r *Reconciler) encrypt(ctx context.Context, namespace string, encryption *esapi.PushSecretEncryption, in map[string][]byte, key string) (map[string][]byte, error) {
+	recipients, err := resolvers.SecretKeyRef(ctx, r.Client, resolvers.EmptyStoreKind, namespace, &encryption.RecipientsSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		if key != "" && k != key {
+			out[k] = v
+			continue
+		}
+		switch encryption.Method {
+		case esapi.PushSecretEncryptionAge:
+			v, err = envelope.AgeEncrypt(recipients, v)
+		case esapi.PushSecretEncryptionPGP:
+			v, err = envelope.PGPEncrypt(recipients, v)
+		default:
+			err = fmt.Errorf("encryption method %v is not supported", encryption.Method)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
 func secretKeyExists(key string, secret *v1.Secret) bool {
 	_, ok := secret.Data[key]
 	return key == "" || ok
@@ -468,7 +605,8 @@ func (r *Reconciler) resolveSecrets(ctx context.Context, ps *esapi.PushSecret) (
 	var err error
 	generatorState := statemanager.New(ctx, r.Client, r.Scheme, ps.Namespace, ps)
 	defer func() {
-		if err != nil {
+		// the values generated by a dry run are never pushed, so they are rolled back as well
+		if err != nil || ps.Spec.DryRun {
 			if err := generatorState.Rollback(); err != nil {
 				r.Log.Error(err, "error rolling back generator state")
 			}
@@ -527,7 +665,7 @@ func (r *Reconciler) resolveSecretFromGenerator(ctx context.Context, namespace s
 			return nil, fmt.Errorf("unable to get latest state: %w", err)
 		}
 	}
-	secretMap, newState, err := gen.Generate(ctx, genResource, r.Client, namespace)
+	secretMap, newState, err := statemanager.Generate(statemanager.WithManager(ctx, generatorState), gen, genResource, prevState, r.Client, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to generate: %w", err)
 	}
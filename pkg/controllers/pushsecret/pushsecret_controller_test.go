@@ -19,12 +19,14 @@ package pushsecret
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"time"
 
+	"filippo.io/age"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,8 +35,10 @@ import (
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	ctest "github.com/external-secrets/external-secrets/pkg/controllers/commontest"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
+	"github.com/external-secrets/external-secrets/runtime/envelope"
 	"github.com/external-secrets/external-secrets/runtime/testing/fake"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -245,6 +249,26 @@ var _ = Describe("PushSecret controller", func() {
 		}
 	}
 
+	// with dryRun nothing is pushed, the planned remote secrets are recorded in the status
+	dryRunPlan := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		tc.pushsecret.Spec.DryRun = true
+		tc.assert = func(ps *v1alpha1.PushSecret, _ *v1.Secret) bool {
+			cond := GetPushSecretCondition(ps.Status.Conditions, v1alpha1.PushSecretReady)
+			if cond == nil || cond.Reason != v1alpha1.ReasonDryRun || ps.Status.Plan == nil {
+				return false
+			}
+			Expect(ps.Status.Plan.Entries).To(HaveLen(1))
+			Expect(ps.Status.Plan.Entries[0].RemoteKey).To(Equal(ps.Spec.Data[0].Match.RemoteRef.RemoteKey))
+			Expect(ps.Status.Plan.Entries[0].Action).To(Equal(v1alpha1.PlanActionCreate))
+			Expect(ps.Status.SyncedPushSecrets).To(BeEmpty())
+			Expect(fakeProvider.GetPushSecretData()).To(BeEmpty())
+			return true
+		}
+	}
+
 	updateIfNotExists := func(tc *testCase) {
 		fakeProvider.SetSecretFn = func() error {
 			return nil
@@ -933,6 +957,51 @@ var _ = Describe("PushSecret controller", func() {
 			return true
 		}
 	}
+	// if encryption and encoding strategy are defined, the value is encrypted and then encoded.
+	syncSuccessfullyWithEncryption := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		identity, err := age.GenerateX25519Identity()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "age-recipients",
+				Namespace: PushSecretNamespace,
+			},
+			Data: map[string][]byte{
+				"recipients.txt": []byte(identity.Recipient().String()),
+			},
+		})).To(Succeed())
+		tc.pushsecret.Spec.Data[0].EncodingStrategy = v1alpha1.PushSecretEncodeBase64
+		tc.pushsecret.Spec.Data[0].Encryption = &v1alpha1.PushSecretEncryption{
+			Method: v1alpha1.PushSecretEncryptionAge,
+			RecipientsSecretRef: esmeta.SecretKeySelector{
+				Name: "age-recipients",
+				Key:  "recipients.txt",
+			},
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			Eventually(func() bool {
+				By("checking if Provider value got encrypted")
+				setSecretArgs := fakeProvider.GetPushSecretData()
+				providerValue, ok := setSecretArgs[ps.Spec.Data[0].Match.RemoteRef.RemoteKey]
+				if !ok {
+					return false
+				}
+				ciphertext, err := base64.StdEncoding.DecodeString(string(providerValue.Value))
+				if err != nil {
+					return false
+				}
+				got, err := envelope.AgeDecrypt(identity.String(), ciphertext)
+				if err != nil {
+					return false
+				}
+				return bytes.Equal(got, secret.Data[defaultKey])
+			}, time.Second*10, time.Second).Should(BeTrue())
+			return true
+		}
+	}
 	// if target Secret name is not specified it should use the ExternalSecret name.
 	syncMatchingLabels := func(tc *testCase) {
 		fakeProvider.SetSecretFn = func() error {
@@ -1346,6 +1415,7 @@ var _ = Describe("PushSecret controller", func() {
 			// this must be optional so we can test faulty es configuration
 		},
 		Entry("should sync", syncSuccessfully),
+		Entry("should plan without pushing with dryRun", dryRunPlan),
 		Entry("should not update existing secret if UpdatePolicy=IfNotExists", updateIfNotExists),
 		Entry("should only update parts of secret that don't already exist if UpdatePolicy=IfNotExists", updateIfNotExistsPartialSecrets),
 		Entry("should update the PushSecret status correctly if UpdatePolicy=IfNotExists", updateIfNotExistsSyncStatus),
@@ -1353,6 +1423,7 @@ var _ = Describe("PushSecret controller", func() {
 		Entry("should sync with template", syncSuccessfullyWithTemplate),
 		Entry("should sync with template reusing keys", syncSuccessfullyReusingKeys),
 		Entry("should sync with conversion strategy", syncSuccessfullyWithConversionStrategy),
+		Entry("should sync with encryption and encoding strategy", syncSuccessfullyWithEncryption),
 		Entry("should delete if DeletionPolicy=Delete", syncAndDeleteSuccessfully),
 		Entry("should delete secrets with properties and update status correctly", syncAndDeleteWithProperties),
 		Entry("should delete after DeletionPolicy changed from Delete to None", syncChangePolicyAndDeleteSuccessfully),
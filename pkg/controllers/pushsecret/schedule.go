@@ -0,0 +1,60 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/schedule"
+)
+
+// usesSchedule reports whether the PushSecret uses a refresh schedule or sync windows.
+func usesSchedule(ps *esapi.PushSecret) bool {
+	return ps.Spec.RefreshSchedule != nil || len(ps.Spec.SyncWindows) > 0
+}
+
+// nextRefreshTime returns the time of the next push after the last one, of the refresh schedule or
+// the refresh interval, deferred until the sync windows allow it, and false if the PushSecret is not pushed periodically.
+func nextRefreshTime(ps *esapi.PushSecret, now time.Time) (time.Time, bool) {
+	var next time.Time
+	switch {
+	case ps.Spec.RefreshSchedule != nil:
+		next, _ = schedule.Next(ps.Spec.RefreshSchedule, ps.Status.RefreshTime.Time, string(ps.UID))
+	case ps.Spec.RefreshInterval != nil && ps.Spec.RefreshInterval.Duration > 0:
+		next = ps.Status.RefreshTime.Add(ps.Spec.RefreshInterval.Duration)
+	}
+	if next.IsZero() {
+		return next, false
+	}
+	if next.Before(now) {
+		next = now
+	}
+	next, ok, err := schedule.NextAllowed(ps.Spec.SyncWindows, next)
+	return next, ok && err == nil
+}
+
+// setNextRefreshTime sets status.nextRefreshTime of PushSecrets that use a refresh schedule or sync windows.
+func setNextRefreshTime(ps *esapi.PushSecret, next time.Time, ok bool) {
+	if !usesSchedule(ps) || !ok {
+		ps.Status.NextRefreshTime = nil
+		return
+	}
+	ps.Status.NextRefreshTime = &metav1.Time{Time: next.Truncate(time.Second)}
+}
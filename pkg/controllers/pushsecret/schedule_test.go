@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	ctrlutil "github.com/external-secrets/external-secrets/pkg/controllers/util"
+)
+
+func TestShouldRefreshSchedule(t *testing.T) {
+	ps := esapi.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Spec: esapi.PushSecretSpec{
+			RefreshInterval: &metav1.Duration{},
+			RefreshSchedule: &esv1.RefreshSchedule{Cron: "0 * * * *"},
+		},
+		Status: esapi.PushSecretStatus{RefreshTime: metav1.NewTime(time.Now().Add(-2 * time.Hour))},
+	}
+	ps.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(ps.ObjectMeta)
+	if !shouldRefresh(ps) {
+		t.Error("shouldRefresh() after a scheduled time = false")
+	}
+	ps.Status.RefreshTime = metav1.Now()
+	if shouldRefresh(ps) {
+		t.Error("shouldRefresh() before the next scheduled time = true")
+	}
+
+	next, ok := nextRefreshTime(&ps, time.Now())
+	if !ok || next.Minute() != 0 || next.Sub(time.Now()) > time.Hour {
+		t.Errorf("nextRefreshTime() = %v, %v, want the next full hour", next, ok)
+	}
+	setNextRefreshTime(&ps, next, ok)
+	if ps.Status.NextRefreshTime == nil {
+		t.Error("setNextRefreshTime() has not set status.nextRefreshTime")
+	}
+}
+
+func TestNextRefreshTimeSyncWindows(t *testing.T) {
+	ps := esapi.PushSecret{
+		Spec: esapi.PushSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: time.Minute},
+			SyncWindows: []esv1.SyncWindow{{
+				Kind:     esv1.SyncWindowAllow,
+				Schedule: "0 2 * * *",
+				Duration: metav1.Duration{Duration: time.Hour},
+			}},
+		},
+		Status: esapi.PushSecretStatus{RefreshTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+	}
+	next, ok := nextRefreshTime(&ps, time.Now())
+	if !ok || next.UTC().Hour() != 2 {
+		t.Errorf("nextRefreshTime() = %v, %v, want inside the allow window", next, ok)
+	}
+
+	ps.Spec.SyncWindows = nil
+	ps.Spec.RefreshInterval = &metav1.Duration{}
+	if _, ok := nextRefreshTime(&ps, time.Now()); ok {
+		t.Error("nextRefreshTime() without refresh interval and schedule = true")
+	}
+	setNextRefreshTime(&ps, time.Now(), true)
+	if ps.Status.NextRefreshTime != nil {
+		t.Error("setNextRefreshTime() without schedule has set status.nextRefreshTime")
+	}
+}
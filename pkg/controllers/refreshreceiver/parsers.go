@@ -0,0 +1,210 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refreshreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Formats of the built-in parsers.
+const (
+	FormatGeneric   = "generic"
+	FormatSNS       = "sns"
+	FormatPubSub    = "pubsub"
+	FormatEventGrid = "eventgrid"
+)
+
+const (
+	eventGridValidationEvent = "Microsoft.EventGrid.SubscriptionValidationEvent"
+	keyVaultEventPrefix      = "Microsoft.KeyVault."
+
+	errGenericPayload = "expected {\"keys\": [...]}"
+)
+
+// Parser extracts the changed keys of a notification.
+type Parser interface {
+	Parse(ctx context.Context, header http.Header, body []byte) (*Result, error)
+}
+
+// Result of a parsed notification.
+type Result struct {
+	// Keys that have changed. Candidates can be returned for a single change,
+	// e.g. the name and the ARN of an AWS secret, keys that no ExternalSecret
+	// reads are ignored.
+	Keys []string
+	// Response is written as JSON instead of refreshing ExternalSecrets,
+	// e.g. to answer the subscription handshake of a notification service.
+	Response any
+}
+
+// DefaultParsers returns the built-in parsers. SNS notifications are only accepted from snsTopicARNs.
+func DefaultParsers(snsTopicARNs []string) map[string]Parser {
+	parsers := map[string]Parser{
+		FormatGeneric:   GenericParser{},
+		FormatPubSub:    PubSubParser{},
+		FormatEventGrid: EventGridParser{},
+	}
+	if len(snsTopicARNs) > 0 {
+		parsers[FormatSNS] = NewSNSParser(snsTopicARNs)
+	}
+	return parsers
+}
+
+// GenericParser parses notifications of the form {"keys": ["db-password"]}.
+type GenericParser struct{}
+
+type genericPayload struct {
+	Key  string   `json:"key,omitempty"`
+	Keys []string `json:"keys,omitempty"`
+}
+
+// Parse implements Parser.
+func (GenericParser) Parse(_ context.Context, _ http.Header, body []byte) (*Result, error) {
+	keys, err := parseGeneric(body)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Keys: keys}, nil
+}
+
+func parseGeneric(body []byte) ([]string, error) {
+	var payload genericPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	keys := payload.Keys
+	if payload.Key != "" {
+		keys = append(keys, payload.Key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New(errGenericPayload)
+	}
+	return keys, nil
+}
+
+// PubSubParser parses the messages of GCP Pub/Sub push subscriptions. Secret Manager
+// notifications are recognized by the secretId attribute, other messages must
+// contain a generic notification as data.
+type PubSubParser struct{}
+
+type pubSubPush struct {
+	Message struct {
+		Attributes map[string]string `json:"attributes"`
+		Data       string            `json:"data"`
+	} `json:"message"`
+}
+
+// Parse implements Parser.
+func (PubSubParser) Parse(_ context.Context, _ http.Header, body []byte) (*Result, error) {
+	var push pubSubPush
+	if err := json.Unmarshal(body, &push); err != nil {
+		return nil, err
+	}
+	if id := push.Message.Attributes["secretId"]; id != "" {
+		// projects/<project>/secrets/<name>
+		return &Result{Keys: uniqueKeys(id, id[strings.LastIndex(id, "/")+1:])}, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(push.Message.Data)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseGeneric(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Keys: keys}, nil
+}
+
+// EventGridParser parses Azure Event Grid events in the Event Grid or CloudEvents schema.
+// Key Vault events are recognized by their type, the subject of other events is used as key.
+type EventGridParser struct{}
+
+type eventGridEvent struct {
+	// EventType is set by the Event Grid schema, Type by the CloudEvents schema.
+	EventType string `json:"eventType"`
+	Type      string `json:"type"`
+	Subject   string `json:"subject"`
+	Data      struct {
+		ObjectName     string `json:"ObjectName"`
+		ObjectType     string `json:"ObjectType"`
+		ValidationCode string `json:"validationCode"`
+	} `json:"data"`
+}
+
+// Parse implements Parser.
+func (EventGridParser) Parse(_ context.Context, _ http.Header, body []byte) (*Result, error) {
+	var events []eventGridEvent
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &events); err != nil {
+			return nil, err
+		}
+	} else {
+		var event eventGridEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	var keys []string
+	for _, event := range events {
+		eventType := event.EventType
+		if eventType == "" {
+			eventType = event.Type
+		}
+		switch {
+		case eventType == eventGridValidationEvent:
+			return &Result{Response: map[string]string{"validationResponse": event.Data.ValidationCode}}, nil
+		case strings.HasPrefix(eventType, keyVaultEventPrefix):
+			name := event.Data.ObjectName
+			if name == "" {
+				name = event.Subject
+			}
+			keys = append(keys, name)
+			// the Azure Key Vault provider addresses keys and certificates with a prefix
+			switch event.Data.ObjectType {
+			case "Secret":
+				keys = append(keys, "secret/"+name)
+			case "Key":
+				keys = append(keys, "key/"+name)
+			case "Certificate":
+				keys = append(keys, "cert/"+name)
+			}
+		case event.Subject != "":
+			keys = append(keys, event.Subject)
+		}
+	}
+	return &Result{Keys: uniqueKeys(keys...)}, nil
+}
+
+// uniqueKeys removes empty and duplicate keys.
+func uniqueKeys(keys ...string) []string {
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != "" && !slices.Contains(out, key) {
+			out = append(out, key)
+		}
+	}
+	return out
+}
@@ -0,0 +1,208 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package refreshreceiver implements an HTTP server that receives change
+// notifications of providers and refreshes the ExternalSecrets that read the
+// changed keys, instead of waiting for their refreshInterval.
+package refreshreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+const (
+	maxBodySize       = 1 << 20
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 10 * time.Second
+
+	// CloudEvents webhook validation, used by Azure Event Grid with the CloudEvents schema.
+	headerRequestOrigin = "WebHook-Request-Origin"
+	headerAllowedOrigin = "WebHook-Allowed-Origin"
+
+	errUnknownFormat = "unknown notification format %q"
+	errUnverified    = "notification could not be verified"
+	errReadBody      = "unable to read notification: %w"
+	errParse         = "unable to parse notification: %w"
+	errRefresh       = "unable to refresh ExternalSecrets: %w"
+	errNoVerifier    = "at least one verifier is required"
+)
+
+// Options configure a Receiver.
+type Options struct {
+	// Addr is the address the receiver binds to.
+	Addr string
+	// Refresher refreshes the ExternalSecrets of a notification.
+	Refresher esv1.Refresher
+	// Verifiers authenticate notifications. A notification is accepted if one of them verifies it.
+	Verifiers []Verifier
+	// Parsers extract the changed keys of notifications by format, the first segment after /refresh/ of the URL path.
+	// Parsers that implement Verifier verify notifications of their format in addition to Verifiers.
+	Parsers map[string]Parser
+	Log     logr.Logger
+}
+
+// Receiver is a manager.Runnable that serves the notification endpoints:
+//
+//	POST /refresh/{format}/clustersecretstore/{name}
+//	POST /refresh/{format}/secretstore/{namespace}/{name}
+type Receiver struct {
+	opts Options
+}
+
+// New creates a Receiver.
+func New(opts Options) (*Receiver, error) {
+	if len(opts.Verifiers) == 0 {
+		hasVerifier := false
+		for _, p := range opts.Parsers {
+			_, ok := p.(Verifier)
+			hasVerifier = hasVerifier || ok
+		}
+		if !hasVerifier {
+			return nil, errors.New(errNoVerifier)
+		}
+	}
+	return &Receiver{opts: opts}, nil
+}
+
+// NeedLeaderElection makes sure that only the leader, which runs the
+// ExternalSecret controller, receives notifications.
+func (r *Receiver) NeedLeaderElection() bool {
+	return true
+}
+
+// Start serves the notification endpoints until ctx is done.
+func (r *Receiver) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              r.opts.Addr,
+		Handler:           r.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+		BaseContext:       func(_ net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	r.opts.Log.Info("starting refresh receiver", "addr", r.opts.Addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Handler returns the handler of the notification endpoints.
+func (r *Receiver) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /refresh/{format}/clustersecretstore/{name}", func(w http.ResponseWriter, req *http.Request) {
+		r.serve(w, req, esv1.SecretStoreRef{Kind: esv1.ClusterSecretStoreKind, Name: req.PathValue("name")}, "")
+	})
+	mux.HandleFunc("POST /refresh/{format}/secretstore/{namespace}/{name}", func(w http.ResponseWriter, req *http.Request) {
+		r.serve(w, req, esv1.SecretStoreRef{Kind: esv1.SecretStoreKind, Name: req.PathValue("name")}, req.PathValue("namespace"))
+	})
+	mux.HandleFunc("OPTIONS /refresh/", validateWebhook)
+	return mux
+}
+
+func (r *Receiver) serve(w http.ResponseWriter, req *http.Request, store esv1.SecretStoreRef, namespace string) {
+	log := r.opts.Log.WithValues("kind", store.Kind, "name", store.Name, "namespace", namespace)
+	format := req.PathValue("format")
+	parser, ok := r.opts.Parsers[format]
+	if !ok {
+		http.Error(w, fmt.Sprintf(errUnknownFormat, format), http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, fmt.Errorf(errReadBody, err).Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.verify(req.Context(), parser, req.Header, body); err != nil {
+		log.Info(errUnverified, "format", format, "error", err.Error())
+		http.Error(w, errUnverified, http.StatusUnauthorized)
+		return
+	}
+	result, err := parser.Parse(req.Context(), req.Header, body)
+	if err != nil {
+		http.Error(w, fmt.Errorf(errParse, err).Error(), http.StatusBadRequest)
+		return
+	}
+	if result.Response != nil {
+		writeJSON(w, http.StatusOK, result.Response)
+		return
+	}
+
+	refreshed := 0
+	if len(result.Keys) > 0 {
+		refreshed, err = r.opts.Refresher.RequestRefresh(req.Context(), store, namespace, result.Keys)
+		if err != nil {
+			log.Error(err, "unable to refresh ExternalSecrets")
+			http.Error(w, fmt.Errorf(errRefresh, err).Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	log.V(1).Info("received change notification", "format", format, "keys", result.Keys, "refreshed", refreshed)
+	writeJSON(w, http.StatusAccepted, map[string]int{"refreshed": refreshed})
+}
+
+// verify accepts the notification if one of the verifiers or the parser itself verifies it.
+func (r *Receiver) verify(ctx context.Context, parser Parser, header http.Header, body []byte) error {
+	verifiers := r.opts.Verifiers
+	if v, ok := parser.(Verifier); ok {
+		verifiers = append([]Verifier{v}, verifiers...)
+	}
+	errs := make([]error, 0, len(verifiers))
+	for _, v := range verifiers {
+		err := v.Verify(ctx, header, body)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return errors.New(errNoVerifier)
+	}
+	return errors.Join(errs...)
+}
+
+// validateWebhook answers the abuse protection handshake of the CloudEvents webhook spec.
+func validateWebhook(w http.ResponseWriter, req *http.Request) {
+	origin := req.Header.Get(headerRequestOrigin)
+	if origin == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set(headerAllowedOrigin, origin)
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
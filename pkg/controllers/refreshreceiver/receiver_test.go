@@ -0,0 +1,406 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refreshreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+type refreshCall struct {
+	Store     esv1.SecretStoreRef
+	Namespace string
+	Keys      []string
+}
+
+type fakeRefresher struct {
+	calls []refreshCall
+}
+
+func (f *fakeRefresher) RequestRefresh(_ context.Context, store esv1.SecretStoreRef, namespace string, keys []string) (int, error) {
+	f.calls = append(f.calls, refreshCall{Store: store, Namespace: namespace, Keys: keys})
+	return len(keys), nil
+}
+
+func newTestReceiver(t *testing.T, verifiers ...Verifier) (*Receiver, *fakeRefresher) {
+	t.Helper()
+	refresher := &fakeRefresher{}
+	r, err := New(Options{
+		Refresher: refresher,
+		Verifiers: verifiers,
+		Parsers:   DefaultParsers(nil),
+		Log:       logr.Discard(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, refresher
+}
+
+func post(r *Receiver, path string, body []byte, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewRequiresVerifier(t *testing.T) {
+	if _, err := New(Options{Parsers: DefaultParsers(nil)}); err == nil {
+		t.Error("New() without verifiers should fail")
+	}
+	if _, err := New(Options{Parsers: DefaultParsers([]string{"arn:aws:sns:eu-west-1:123456789012:eso"})}); err != nil {
+		t.Errorf("New() with SNS parser error = %v", err)
+	}
+}
+
+func TestHMAC(t *testing.T) {
+	hmacVerifier := &HMACVerifier{Secret: []byte("s3cr3t")}
+	r, refresher := newTestReceiver(t, hmacVerifier)
+	body := []byte(`{"keys": ["db", "api"]}`)
+	signed := http.Header{HMACSignatureHeader: {hmacVerifier.Sign(body)}}
+
+	tests := []struct {
+		name   string
+		path   string
+		header http.Header
+		status int
+		want   []refreshCall
+	}{
+		{
+			name:   "cluster secret store",
+			path:   "/refresh/generic/clustersecretstore/shared",
+			header: signed,
+			status: http.StatusAccepted,
+			want: []refreshCall{{
+				Store: esv1.SecretStoreRef{Kind: esv1.ClusterSecretStoreKind, Name: "shared"},
+				Keys:  []string{"db", "api"},
+			}},
+		},
+		{
+			name:   "secret store",
+			path:   "/refresh/generic/secretstore/team-a/local",
+			header: signed,
+			status: http.StatusAccepted,
+			want: []refreshCall{{
+				Store:     esv1.SecretStoreRef{Kind: esv1.SecretStoreKind, Name: "local"},
+				Namespace: "team-a",
+				Keys:      []string{"db", "api"},
+			}},
+		},
+		{
+			name:   "missing signature",
+			path:   "/refresh/generic/clustersecretstore/shared",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "invalid signature",
+			path:   "/refresh/generic/clustersecretstore/shared",
+			header: http.Header{HMACSignatureHeader: {(&HMACVerifier{Secret: []byte("other")}).Sign(body)}},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "unknown format",
+			path:   "/refresh/other/clustersecretstore/shared",
+			header: signed,
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refresher.calls = nil
+			rec := post(r, tt.path, body, tt.header)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body.String())
+			}
+			if diff := cmp.Diff(tt.want, refresher.calls); diff != "" {
+				t.Errorf("refresh calls mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := jwk.FromRaw(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = private.Set(jwk.KeyIDKey, "test")
+	public, err := private.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := jwk.NewSet()
+	_ = keys.AddKey(public)
+
+	verifier := newJWTVerifier(keys, JWTOptions{
+		Issuer:   "https://accounts.google.com",
+		Audience: "https://eso.example.com",
+		Claims:   map[string]string{"email": "pubsub@project.iam.gserviceaccount.com"},
+	})
+	r, refresher := newTestReceiver(t, verifier)
+
+	sign := func(audience, email string) http.Header {
+		token, err := jwt.NewBuilder().
+			Issuer("https://accounts.google.com").
+			Audience([]string{audience}).
+			IssuedAt(time.Now()).
+			Expiration(time.Now().Add(time.Hour)).
+			Claim("email", email).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, private))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return http.Header{"Authorization": {"Bearer " + string(signed)}}
+	}
+
+	body := []byte(`{"message": {"attributes": {"eventType": "SECRET_VERSION_ADD", "secretId": "projects/p/secrets/db"}}}`)
+	rec := post(r, "/refresh/pubsub/clustersecretstore/gcp", body, sign("https://eso.example.com", "pubsub@project.iam.gserviceaccount.com"))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusAccepted, rec.Body.String())
+	}
+	want := []refreshCall{{
+		Store: esv1.SecretStoreRef{Kind: esv1.ClusterSecretStoreKind, Name: "gcp"},
+		Keys:  []string{"projects/p/secrets/db", "db"},
+	}}
+	if diff := cmp.Diff(want, refresher.calls); diff != "" {
+		t.Errorf("refresh calls mismatch (-want +got):\n%s", diff)
+	}
+
+	for name, header := range map[string]http.Header{
+		"wrong audience": sign("https://other.example.com", "pubsub@project.iam.gserviceaccount.com"),
+		"wrong claim":    sign("https://eso.example.com", "other@project.iam.gserviceaccount.com"),
+		"no token":       nil,
+	} {
+		if rec := post(r, "/refresh/pubsub/clustersecretstore/gcp", body, header); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want %d", name, rec.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestParsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		parser   Parser
+		body     string
+		want     []string
+		response any
+	}{
+		{
+			name:   "generic key",
+			parser: GenericParser{},
+			body:   `{"key": "db"}`,
+			want:   []string{"db"},
+		},
+		{
+			name:   "pubsub generic data",
+			parser: PubSubParser{},
+			body:   `{"message": {"data": "` + base64.StdEncoding.EncodeToString([]byte(`{"keys": ["db"]}`)) + `"}}`,
+			want:   []string{"db"},
+		},
+		{
+			name:     "eventgrid validation",
+			parser:   EventGridParser{},
+			body:     `[{"eventType": "Microsoft.EventGrid.SubscriptionValidationEvent", "data": {"validationCode": "512d38b6"}}]`,
+			response: map[string]string{"validationResponse": "512d38b6"},
+		},
+		{
+			name:   "eventgrid key vault events",
+			parser: EventGridParser{},
+			body: `[
+				{"eventType": "Microsoft.KeyVault.SecretNewVersionCreated", "subject": "db", "data": {"ObjectName": "db", "ObjectType": "Secret"}},
+				{"eventType": "Microsoft.KeyVault.CertificateNewVersionCreated", "subject": "tls", "data": {"ObjectName": "tls", "ObjectType": "Certificate"}}
+			]`,
+			want: []string{"db", "secret/db", "tls", "cert/tls"},
+		},
+		{
+			name:   "eventgrid cloudevent",
+			parser: EventGridParser{},
+			body:   `{"specversion": "1.0", "type": "Microsoft.KeyVault.KeyNewVersionCreated", "subject": "signing", "data": {"ObjectType": "Key"}}`,
+			want:   []string{"signing", "key/signing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.parser.Parse(context.Background(), nil, []byte(tt.body))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, result.Keys); diff != "" {
+				t.Errorf("Parse() keys mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.response, result.Response); diff != "" {
+				t.Errorf("Parse() response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := (GenericParser{}).Parse(context.Background(), nil, []byte(`{}`)); err == nil {
+		t.Error("Parse() of a generic notification without keys should fail")
+	}
+}
+
+func TestValidateWebhook(t *testing.T) {
+	r, _ := newTestReceiver(t, &HMACVerifier{Secret: []byte("s3cr3t")})
+	req := httptest.NewRequest(http.MethodOptions, "/refresh/eventgrid/clustersecretstore/azure", http.NoBody)
+	req.Header.Set(headerRequestOrigin, "eventgrid.azure.net")
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get(headerAllowedOrigin) != "eventgrid.azure.net" {
+		t.Errorf("status = %d, %s = %q", rec.Code, headerAllowedOrigin, rec.Header().Get(headerAllowedOrigin))
+	}
+}
+
+func TestSNS(t *testing.T) {
+	const (
+		topic      = "arn:aws:sns:eu-west-1:123456789012:eso"
+		certURL    = "https://sns.eu-west-1.amazonaws.com/SimpleNotificationService-test.pem"
+		confirmURL = "https://sns.eu-west-1.amazonaws.com/?Action=ConfirmSubscription&Token=t"
+	)
+	key, certPEM := newSigningCert(t)
+	parser := NewSNSParser([]string{topic})
+	var fetched []string
+	parser.fetch = func(_ context.Context, u string) ([]byte, error) {
+		fetched = append(fetched, u)
+		return certPEM, nil
+	}
+	refresher := &fakeRefresher{}
+	r, err := New(Options{
+		Refresher: refresher,
+		Parsers:   map[string]Parser{FormatSNS: parser},
+		Log:       logr.Discard(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(msg snsMessage) []byte {
+		msg.SignatureVersion = "2"
+		msg.SigningCertURL = certURL
+		digest := sha256.Sum256([]byte(msg.stringToSign()))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg.Signature = base64.StdEncoding.EncodeToString(signature)
+		body, _ := json.Marshal(msg)
+		return body
+	}
+
+	event := `{"detail-type": "AWS API Call via CloudTrail", "source": "aws.secretsmanager",
+		"detail": {"eventName": "PutSecretValue", "requestParameters": {"secretId": "arn:aws:secretsmanager:eu-west-1:123456789012:secret:db-AbCdEf"}}}`
+	notification := snsMessage{Type: snsTypeNotification, MessageID: "1", TopicArn: topic, Message: event, Timestamp: "2025-01-01T00:00:00.000Z"}
+	rec := post(r, "/refresh/sns/clustersecretstore/aws", sign(notification), nil)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusAccepted, rec.Body.String())
+	}
+	want := []refreshCall{{
+		Store: esv1.SecretStoreRef{Kind: esv1.ClusterSecretStoreKind, Name: "aws"},
+		Keys:  []string{"arn:aws:secretsmanager:eu-west-1:123456789012:secret:db-AbCdEf", "db"},
+	}}
+	if diff := cmp.Diff(want, refresher.calls); diff != "" {
+		t.Errorf("refresh calls mismatch (-want +got):\n%s", diff)
+	}
+
+	tampered := sign(notification)
+	tampered = bytes.Replace(tampered, []byte("PutSecretValue"), []byte("GetSecretValue"), 1)
+	if rec := post(r, "/refresh/sns/clustersecretstore/aws", tampered, nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("tampered message: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	other := notification
+	other.TopicArn = "arn:aws:sns:eu-west-1:210987654321:other"
+	if rec := post(r, "/refresh/sns/clustersecretstore/aws", sign(other), nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("other topic: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := post(r, "/refresh/generic/clustersecretstore/aws", []byte(`{"key": "db"}`), nil); rec.Code != http.StatusNotFound {
+		t.Errorf("generic without verifier: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	fetched = nil
+	confirmation := snsMessage{Type: snsTypeSubscriptionConfirmation, MessageID: "2", TopicArn: topic, Token: "t", SubscribeURL: confirmURL, Timestamp: "2025-01-01T00:00:00.000Z"}
+	if rec := post(r, "/refresh/sns/clustersecretstore/aws", sign(confirmation), nil); rec.Code != http.StatusOK {
+		t.Fatalf("subscription confirmation: status = %d: %s", rec.Code, rec.Body.String())
+	}
+	// the signing certificate is cached
+	if diff := cmp.Diff([]string{confirmURL}, fetched); diff != "" {
+		t.Errorf("fetched URLs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNamesFromARN(t *testing.T) {
+	tests := map[string][]string{
+		"arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/db-AbCdEf": {"prod/db"},
+		"arn:aws:ssm:eu-west-1:123456789012:parameter/prod/db":                {"prod/db", "/prod/db"},
+		"prod/db": nil,
+	}
+	for arn, want := range tests {
+		if diff := cmp.Diff(want, namesFromARN(arn)); diff != "" {
+			t.Errorf("namesFromARN(%q) mismatch (-want +got):\n%s", arn, diff)
+		}
+	}
+}
+
+func newSigningCert(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,292 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refreshreceiver
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec // SNS signature version 1 uses SHA1
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	snsTypeNotification             = "Notification"
+	snsTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	snsTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+
+	snsFetchTimeout = 10 * time.Second
+	snsMaxFetchSize = 64 << 10
+
+	errSNSTopic            = "topic %q is not allowed"
+	errSNSURL              = "untrusted SNS URL %q"
+	errSNSSignatureVersion = "unsupported SNS signature version %q"
+	errSNSSignature        = "invalid SNS signature: %w"
+	errSNSCertificate      = "invalid SNS signing certificate"
+	errSNSFetch            = "unable to fetch %s: %s"
+	errSNSType             = "unsupported SNS message type %q"
+)
+
+// snsHost matches the hosts of SNS signing certificates and subscription URLs.
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// SNSParser parses and verifies AWS SNS messages, e.g. of an EventBridge rule with an SNS target.
+// Messages are verified with the SNS signature and must be sent by one of the allowed topics.
+// Subscriptions are confirmed automatically.
+type SNSParser struct {
+	topicARNs []string
+	// fetch downloads signing certificates and confirms subscriptions.
+	fetch func(ctx context.Context, u string) ([]byte, error)
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewSNSParser creates a SNSParser that accepts messages of the topics.
+func NewSNSParser(topicARNs []string) *SNSParser {
+	client := &http.Client{Timeout: snsFetchTimeout}
+	return &SNSParser{
+		topicARNs: topicARNs,
+		certs:     make(map[string]*x509.Certificate),
+		fetch: func(ctx context.Context, u string) ([]byte, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf(errSNSFetch, u, resp.Status)
+			}
+			return io.ReadAll(io.LimitReader(resp.Body, snsMaxFetchSize))
+		},
+	}
+}
+
+type snsMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// stringToSign returns the canonical form of the message that SNS signs.
+func (m *snsMessage) stringToSign() string {
+	fields := [][2]string{{"Message", m.Message}, {"MessageId", m.MessageID}}
+	if m.Type == snsTypeNotification {
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields, [2]string{"Timestamp", m.Timestamp})
+	} else {
+		fields = append(fields,
+			[2]string{"SubscribeURL", m.SubscribeURL},
+			[2]string{"Timestamp", m.Timestamp},
+			[2]string{"Token", m.Token})
+	}
+	fields = append(fields, [2]string{"TopicArn", m.TopicArn}, [2]string{"Type", m.Type})
+
+	var sb strings.Builder
+	for _, f := range fields {
+		sb.WriteString(f[0] + "\n" + f[1] + "\n")
+	}
+	return sb.String()
+}
+
+// Verify implements Verifier.
+func (p *SNSParser) Verify(ctx context.Context, _ http.Header, body []byte) error {
+	var msg snsMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return err
+	}
+	if !slices.Contains(p.topicARNs, msg.TopicArn) {
+		return fmt.Errorf(errSNSTopic, msg.TopicArn)
+	}
+
+	var hash crypto.Hash
+	var digest []byte
+	switch msg.SignatureVersion {
+	case "1":
+		sum := sha1.Sum([]byte(msg.stringToSign())) //nolint:gosec // SNS signature version 1 uses SHA1
+		hash, digest = crypto.SHA1, sum[:]
+	case "2":
+		sum := sha256.Sum256([]byte(msg.stringToSign()))
+		hash, digest = crypto.SHA256, sum[:]
+	default:
+		return fmt.Errorf(errSNSSignatureVersion, msg.SignatureVersion)
+	}
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return fmt.Errorf(errSNSSignature, err)
+	}
+	cert, err := p.certificate(ctx, msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New(errSNSCertificate)
+	}
+	if err := rsa.VerifyPKCS1v15(key, hash, digest, signature); err != nil {
+		return fmt.Errorf(errSNSSignature, err)
+	}
+	return nil
+}
+
+// certificate returns the signing certificate, which is cached by URL.
+func (p *SNSParser) certificate(ctx context.Context, u string) (*x509.Certificate, error) {
+	if err := validateSNSURL(u); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	cert, ok := p.certs[u]
+	p.mu.Unlock()
+	if ok {
+		return cert, nil
+	}
+
+	data, err := p.fetch(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(errSNSCertificate)
+	}
+	cert, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.certs[u] = cert
+	p.mu.Unlock()
+	return cert, nil
+}
+
+// Parse implements Parser.
+func (p *SNSParser) Parse(ctx context.Context, _ http.Header, body []byte) (*Result, error) {
+	var msg snsMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+	switch msg.Type {
+	case snsTypeSubscriptionConfirmation:
+		if err := validateSNSURL(msg.SubscribeURL); err != nil {
+			return nil, err
+		}
+		if _, err := p.fetch(ctx, msg.SubscribeURL); err != nil {
+			return nil, err
+		}
+		return &Result{Response: map[string]string{"subscription": "confirmed"}}, nil
+	case snsTypeUnsubscribeConfirmation:
+		return &Result{Response: map[string]string{"subscription": "unsubscribed"}}, nil
+	case snsTypeNotification:
+		return &Result{Keys: parseEventBridgeEvent([]byte(msg.Message))}, nil
+	default:
+		return nil, fmt.Errorf(errSNSType, msg.Type)
+	}
+}
+
+type eventBridgeEvent struct {
+	Resources []string `json:"resources"`
+	Detail    struct {
+		// Parameter Store Change events
+		Name string `json:"name"`
+		// AWS API Call via CloudTrail events
+		RequestParameters struct {
+			SecretID string `json:"secretId"`
+			Name     string `json:"name"`
+		} `json:"requestParameters"`
+	} `json:"detail"`
+	// generic notifications
+	Key  string   `json:"key"`
+	Keys []string `json:"keys"`
+}
+
+// parseEventBridgeEvent returns the keys of Secrets Manager and Parameter Store events,
+// or of a generic notification.
+func parseEventBridgeEvent(message []byte) []string {
+	var event eventBridgeEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return nil
+	}
+	keys := slices.Concat(event.Keys, []string{
+		event.Key,
+		event.Detail.Name,
+		event.Detail.RequestParameters.Name,
+		event.Detail.RequestParameters.SecretID,
+	})
+	keys = append(keys, namesFromARN(event.Detail.RequestParameters.SecretID)...)
+	for _, resource := range event.Resources {
+		keys = append(keys, resource)
+		keys = append(keys, namesFromARN(resource)...)
+	}
+	return uniqueKeys(keys...)
+}
+
+// namesFromARN returns the names a Secrets Manager secret or SSM parameter ARN can be referenced with.
+func namesFromARN(arn string) []string {
+	if !strings.HasPrefix(arn, "arn:") {
+		return nil
+	}
+	// arn:aws:secretsmanager:<region>:<account>:secret:<name>-<6 random characters>
+	if _, name, ok := strings.Cut(arn, ":secret:"); ok {
+		if i := strings.LastIndex(name, "-"); i > 0 && len(name)-i == 7 {
+			name = name[:i]
+		}
+		return []string{name}
+	}
+	// arn:aws:ssm:<region>:<account>:parameter/<name>, the leading slash of hierarchical names is omitted
+	if _, name, ok := strings.Cut(arn, ":parameter/"); ok {
+		return []string{name, "/" + name}
+	}
+	return nil
+}
+
+func validateSNSURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Scheme != "https" || !snsHost.MatchString(parsed.Hostname()) {
+		return fmt.Errorf(errSNSURL, u)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refreshreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+const (
+	// HMACSignatureHeader is the default header of HMAC signatures.
+	HMACSignatureHeader = "X-Signature-256"
+	hmacSignaturePrefix = "sha256="
+
+	jwksMinRefreshInterval = 15 * time.Minute
+	jwtAcceptableSkew      = time.Minute
+
+	errMissingSignature = "missing %s header"
+	errInvalidSignature = "invalid HMAC signature"
+	errMissingToken     = "missing bearer token"
+	errInvalidToken     = "invalid bearer token: %w"
+	errJWKS             = "unable to register JWKS %s: %w"
+)
+
+// Verifier authenticates a notification.
+type Verifier interface {
+	Verify(ctx context.Context, header http.Header, body []byte) error
+}
+
+// HMACVerifier verifies the HMAC-SHA256 signature of the body, which is
+// sent hex encoded with the prefix "sha256=" in a header.
+type HMACVerifier struct {
+	// Header of the signature, defaults to X-Signature-256.
+	Header string
+	Secret []byte
+}
+
+// Verify implements Verifier.
+func (v *HMACVerifier) Verify(_ context.Context, header http.Header, body []byte) error {
+	name := v.Header
+	if name == "" {
+		name = HMACSignatureHeader
+	}
+	value := header.Get(name)
+	if value == "" {
+		return fmt.Errorf(errMissingSignature, name)
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(value, hmacSignaturePrefix))
+	if err != nil {
+		return errors.New(errInvalidSignature)
+	}
+	mac := hmac.New(sha256.New, v.Secret)
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errors.New(errInvalidSignature)
+	}
+	return nil
+}
+
+// Sign returns the value of the signature header for the body.
+func (v *HMACVerifier) Sign(body []byte) string {
+	mac := hmac.New(sha256.New, v.Secret)
+	mac.Write(body)
+	return hmacSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// JWTOptions configure a JWTVerifier.
+type JWTOptions struct {
+	// JWKSURL is the URL of the keys that sign the tokens.
+	JWKSURL string
+	// Issuer of the tokens.
+	Issuer string
+	// Audience the tokens must be issued for.
+	Audience string
+	// Claims that the tokens must contain with the given values, e.g. the email of a service account.
+	Claims map[string]string
+}
+
+// JWTVerifier verifies the bearer token of the Authorization header,
+// e.g. the OIDC tokens of GCP Pub/Sub push subscriptions or the
+// Microsoft Entra tokens of Azure Event Grid.
+type JWTVerifier struct {
+	keys jwk.Set
+	opts []jwt.ParseOption
+}
+
+// NewJWTVerifier creates a JWTVerifier that fetches the keys of opts.JWKSURL
+// and refreshes them in the background until ctx is done.
+func NewJWTVerifier(ctx context.Context, opts JWTOptions) (*JWTVerifier, error) {
+	cache := jwk.NewCache(ctx)
+	if err := cache.Register(opts.JWKSURL, jwk.WithMinRefreshInterval(jwksMinRefreshInterval)); err != nil {
+		return nil, fmt.Errorf(errJWKS, opts.JWKSURL, err)
+	}
+	return newJWTVerifier(jwk.NewCachedSet(cache, opts.JWKSURL), opts), nil
+}
+
+func newJWTVerifier(keys jwk.Set, opts JWTOptions) *JWTVerifier {
+	parseOpts := []jwt.ParseOption{
+		// Microsoft Entra publishes keys without algorithm.
+		jwt.WithKeySet(keys, jws.WithInferAlgorithmFromKey(true)),
+		jwt.WithValidate(true),
+		jwt.WithAcceptableSkew(jwtAcceptableSkew),
+	}
+	if opts.Issuer != "" {
+		parseOpts = append(parseOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parseOpts = append(parseOpts, jwt.WithAudience(opts.Audience))
+	}
+	for name, value := range opts.Claims {
+		parseOpts = append(parseOpts, jwt.WithClaimValue(name, value))
+	}
+	return &JWTVerifier{keys: keys, opts: parseOpts}
+}
+
+// Verify implements Verifier.
+func (v *JWTVerifier) Verify(_ context.Context, header http.Header, _ []byte) error {
+	token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return errors.New(errMissingToken)
+	}
+	if _, err := jwt.ParseString(token, v.opts...); err != nil {
+		return fmt.Errorf(errInvalidToken, err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedule evaluates the refresh schedules and sync windows of ExternalSecrets and PushSecrets.
+package schedule
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+// searchLimit and searchSteps bound the search for the next time sync windows allow updates.
+const (
+	searchLimit = 366 * 24 * time.Hour
+	searchSteps = 10000
+)
+
+// Validate checks the refresh schedule and the sync windows.
+func Validate(refresh *esv1.RefreshSchedule, windows []esv1.SyncWindow) error {
+	return esv1.ValidateSchedules(refresh, windows)
+}
+
+// Next returns the time of the first scheduled refresh after the time, delayed by the jitter.
+// The jitter of a scheduled time is derived from the seed, e.g. the UID of the resource,
+// so that it is stable across reconciles.
+func Next(refresh *esv1.RefreshSchedule, after time.Time, seed string) (time.Time, error) {
+	sched, loc, err := esv1.ParseCronSchedule(refresh.Cron, refresh.TimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := sched.Next(after.In(loc))
+	if next.IsZero() {
+		return next, nil
+	}
+	if refresh.Jitter != nil && refresh.Jitter.Duration > 0 {
+		next = next.Add(jitter(seed, next, refresh.Jitter.Duration))
+	}
+	return next, nil
+}
+
+// jitter returns a duration in [0, maxJitter) derived from the seed and the scheduled time.
+func jitter(seed string, scheduled time.Time, maxJitter time.Duration) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(seed))
+	_ = binary.Write(h, binary.LittleEndian, scheduled.Unix())
+	return time.Duration(h.Sum64() % uint64(maxJitter))
+}
+
+type window struct {
+	kind     esv1.SyncWindowKind
+	schedule cron.Schedule
+	loc      *time.Location
+	duration time.Duration
+}
+
+func parseWindows(windows []esv1.SyncWindow) ([]window, error) {
+	parsed := make([]window, 0, len(windows))
+	for _, w := range windows {
+		sched, loc, err := esv1.ParseSyncWindow(w)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, window{kind: w.Kind, schedule: sched, loc: loc, duration: w.Duration.Duration})
+	}
+	return parsed, nil
+}
+
+// start returns the start of the window if it is open at t.
+func (w window) start(t time.Time) (time.Time, bool) {
+	// the first start after t-duration is the only start of a window that can be open at t
+	start := w.schedule.Next(t.Add(-w.duration).In(w.loc))
+	if start.IsZero() || start.After(t) {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// allowed reports whether the windows allow updates at t: no Deny window is open,
+// and an Allow window is open if there are any.
+func allowed(windows []window, t time.Time) bool {
+	hasAllow, inAllow := false, false
+	for _, w := range windows {
+		_, open := w.start(t)
+		switch w.kind {
+		case esv1.SyncWindowDeny:
+			if open {
+				return false
+			}
+		case esv1.SyncWindowAllow:
+			hasAllow = true
+			inAllow = inAllow || open
+		}
+	}
+	return !hasAllow || inAllow
+}
+
+// Allowed reports whether the sync windows allow updates at t.
+func Allowed(windows []esv1.SyncWindow, t time.Time) (bool, error) {
+	parsed, err := parseWindows(windows)
+	if err != nil {
+		return false, err
+	}
+	return allowed(parsed, t), nil
+}
+
+// NextAllowed returns the first time at or after t at which the sync windows allow updates.
+// It returns false if the windows do not allow updates within about a year.
+func NextAllowed(windows []esv1.SyncWindow, t time.Time) (time.Time, bool, error) {
+	parsed, err := parseWindows(windows)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	limit := t.Add(searchLimit)
+	for i := 0; i < searchSteps && !t.After(limit); i++ {
+		if allowed(parsed, t) {
+			return t, true, nil
+		}
+		// updates can only become allowed when an Allow window opens or an open Deny window closes
+		var next time.Time
+		for _, w := range parsed {
+			var boundary time.Time
+			switch w.kind {
+			case esv1.SyncWindowAllow:
+				boundary = w.schedule.Next(t.In(w.loc))
+			case esv1.SyncWindowDeny:
+				if start, open := w.start(t); open {
+					boundary = start.Add(w.duration)
+				}
+			}
+			if !boundary.IsZero() && (next.IsZero() || boundary.Before(next)) {
+				next = boundary
+			}
+		}
+		if next.IsZero() {
+			break
+		}
+		t = next
+	}
+	return time.Time{}, false, nil
+}
+
+// Deferred reports whether the sync windows deny updates at now, and returns when they allow them again.
+// The time is zero if the windows do not allow updates within a year.
+func Deferred(windows []esv1.SyncWindow, now time.Time) (time.Time, bool) {
+	if len(windows) == 0 {
+		return time.Time{}, false
+	}
+	next, ok, err := NextAllowed(windows, now)
+	if err != nil || (ok && !next.After(now)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// RequeueAt returns a result that requeues at next, or does not requeue if ok is false.
+func RequeueAt(next time.Time, ok bool) ctrl.Result {
+	if !ok {
+		return ctrl.Result{}
+	}
+	if d := time.Until(next); d > 0 {
+		return ctrl.Result{RequeueAfter: d}
+	}
+	return ctrl.Result{Requeue: true}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+func mustParse(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		refresh *esv1.RefreshSchedule
+		windows []esv1.SyncWindow
+		wantErr bool
+	}{
+		{name: "empty"},
+		{
+			name:    "valid",
+			refresh: &esv1.RefreshSchedule{Cron: "0 2 * * *", TimeZone: "Europe/Berlin", Jitter: &metav1.Duration{Duration: time.Minute}},
+			windows: []esv1.SyncWindow{{Kind: esv1.SyncWindowDeny, Schedule: "0 8 * * 1-5", Duration: metav1.Duration{Duration: 10 * time.Hour}}},
+		},
+		{name: "invalid cron", refresh: &esv1.RefreshSchedule{Cron: "every night"}, wantErr: true},
+		{name: "invalid time zone", refresh: &esv1.RefreshSchedule{Cron: "0 2 * * *", TimeZone: "Mars/Olympus"}, wantErr: true},
+		{name: "negative jitter", refresh: &esv1.RefreshSchedule{Cron: "0 2 * * *", Jitter: &metav1.Duration{Duration: -time.Minute}}, wantErr: true},
+		{name: "invalid window kind", windows: []esv1.SyncWindow{{Kind: "Maybe", Schedule: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}}}, wantErr: true},
+		{name: "window without duration", windows: []esv1.SyncWindow{{Kind: esv1.SyncWindowAllow, Schedule: "0 8 * * *"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.refresh, tt.windows); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	after := mustParse(t, "2025-03-10T12:00:00Z")
+	next, err := Next(&esv1.RefreshSchedule{Cron: "0 2 * * *"}, after, "uid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := mustParse(t, "2025-03-11T02:00:00Z"); !next.Equal(want) {
+		t.Errorf("Next() = %v, want %v", next, want)
+	}
+
+	next, err = Next(&esv1.RefreshSchedule{Cron: "0 2 * * *", TimeZone: "Europe/Berlin"}, after, "uid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := mustParse(t, "2025-03-11T01:00:00Z"); !next.Equal(want) {
+		t.Errorf("Next() in Europe/Berlin = %v, want %v", next, want)
+	}
+
+	refresh := &esv1.RefreshSchedule{Cron: "0 2 * * *", Jitter: &metav1.Duration{Duration: 30 * time.Minute}}
+	first, err := Next(refresh, after, "uid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduled := mustParse(t, "2025-03-11T02:00:00Z")
+	if first.Before(scheduled) || !first.Before(scheduled.Add(30*time.Minute)) {
+		t.Errorf("Next() with jitter = %v, want within 30m after %v", first, scheduled)
+	}
+	if again, _ := Next(refresh, after, "uid"); !again.Equal(first) {
+		t.Errorf("Next() with jitter is not stable: %v != %v", again, first)
+	}
+}
+
+func TestNextAllowed(t *testing.T) {
+	// business hours are 08:00 to 18:00 from Monday to Friday
+	businessHours := esv1.SyncWindow{Kind: esv1.SyncWindowDeny, Schedule: "0 8 * * 1-5", Duration: metav1.Duration{Duration: 10 * time.Hour}}
+	nightly := esv1.SyncWindow{Kind: esv1.SyncWindowAllow, Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}}
+	tests := []struct {
+		name    string
+		windows []esv1.SyncWindow
+		at      string
+		want    string
+	}{
+		{name: "no windows", at: "2025-03-10T12:00:00Z", want: "2025-03-10T12:00:00Z"},
+		{name: "outside of deny window", windows: []esv1.SyncWindow{businessHours}, at: "2025-03-10T19:00:00Z", want: "2025-03-10T19:00:00Z"},
+		{name: "inside of deny window", windows: []esv1.SyncWindow{businessHours}, at: "2025-03-10T12:00:00Z", want: "2025-03-10T18:00:00Z"},
+		{name: "deny window does not open on weekends", windows: []esv1.SyncWindow{businessHours}, at: "2025-03-08T12:00:00Z", want: "2025-03-08T12:00:00Z"},
+		{name: "inside of allow window", windows: []esv1.SyncWindow{nightly}, at: "2025-03-10T02:30:00Z", want: "2025-03-10T02:30:00Z"},
+		{name: "outside of allow window", windows: []esv1.SyncWindow{nightly}, at: "2025-03-10T12:00:00Z", want: "2025-03-11T02:00:00Z"},
+		{
+			name: "deny takes precedence over allow",
+			windows: []esv1.SyncWindow{
+				{Kind: esv1.SyncWindowAllow, Schedule: "0 7 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				businessHours,
+			},
+			at:   "2025-03-10T08:30:00Z",
+			want: "2025-03-11T07:00:00Z",
+		},
+		{
+			name:    "time zone",
+			windows: []esv1.SyncWindow{{Kind: esv1.SyncWindowAllow, Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Europe/Berlin"}},
+			at:      "2025-03-10T12:00:00Z",
+			want:    "2025-03-11T01:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := mustParse(t, tt.at)
+			got, ok, err := NextAllowed(tt.windows, at)
+			if err != nil || !ok {
+				t.Fatalf("NextAllowed() = %v, %v, %v", got, ok, err)
+			}
+			if want := mustParse(t, tt.want); !got.Equal(want) {
+				t.Errorf("NextAllowed() = %v, want %v", got, want)
+			}
+			allowed, err := Allowed(tt.windows, at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if allowed != got.Equal(at) {
+				t.Errorf("Allowed() = %v, want %v", allowed, got.Equal(at))
+			}
+		})
+	}
+
+	always := []esv1.SyncWindow{{Kind: esv1.SyncWindowDeny, Schedule: "0 * * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}}}
+	if _, ok, err := NextAllowed(always, mustParse(t, "2025-03-10T12:00:00Z")); ok || err != nil {
+		t.Errorf("NextAllowed() of windows that always deny = %v, %v, want false", ok, err)
+	}
+}
+
+func TestDeferred(t *testing.T) {
+	businessHours := []esv1.SyncWindow{{Kind: esv1.SyncWindowDeny, Schedule: "0 8 * * 1-5", Duration: metav1.Duration{Duration: 10 * time.Hour}}}
+	if _, deferred := Deferred(nil, mustParse(t, "2025-03-10T12:00:00Z")); deferred {
+		t.Error("Deferred() without windows = true")
+	}
+	if _, deferred := Deferred(businessHours, mustParse(t, "2025-03-10T19:00:00Z")); deferred {
+		t.Error("Deferred() outside of deny window = true")
+	}
+	until, deferred := Deferred(businessHours, mustParse(t, "2025-03-10T12:00:00Z"))
+	if want := mustParse(t, "2025-03-10T18:00:00Z"); !deferred || !until.Equal(want) {
+		t.Errorf("Deferred() = %v, %v, want %v, true", until, deferred, want)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+const errRemoteKeyDenied = "access to remote key %q is denied for namespace %q by spec.accessPolicies of ClusterSecretStore %q"
+
+// policyClient enforces the access policies of a ClusterSecretStore on the remote keys
+// a namespace reads, pushes and deletes.
+type policyClient struct {
+	esv1.SecretsClient
+	policy    *esv1.RemoteKeyPolicy
+	store     string
+	namespace string
+}
+
+// check returns an error if the namespace must not access the remote key.
+func (c *policyClient) check(key string) error {
+	if !c.policy.Allows(key) {
+		return fmt.Errorf(errRemoteKeyDenied, key, c.namespace, c.store)
+	}
+	return nil
+}
+
+func (c *policyClient) GetSecret(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if err := c.check(ref.Key); err != nil {
+		return nil, err
+	}
+	return c.SecretsClient.GetSecret(ctx, ref)
+}
+
+// GetSecretWithMetadata implements esv1.SecretMetadataGetter if the wrapped client does.
+func (c *policyClient) GetSecretWithMetadata(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, *esv1.SecretMetadata, error) {
+	if err := c.check(ref.Key); err != nil {
+		return nil, nil, err
+	}
+	if getter, ok := c.SecretsClient.(esv1.SecretMetadataGetter); ok {
+		return getter.GetSecretWithMetadata(ctx, ref)
+	}
+	data, err := c.SecretsClient.GetSecret(ctx, ref)
+	return data, nil, err
+}
+
+func (c *policyClient) GetSecretMap(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if err := c.check(ref.Key); err != nil {
+		return nil, err
+	}
+	return c.SecretsClient.GetSecretMap(ctx, ref)
+}
+
+// GetAllSecrets drops the secrets the namespace must not access, instead of failing the find.
+func (c *policyClient) GetAllSecrets(ctx context.Context, ref esv1.ExternalSecretFind) (map[string][]byte, error) {
+	secrets, err := c.SecretsClient.GetAllSecrets(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	for key := range secrets {
+		if !c.policy.Allows(key) {
+			delete(secrets, key)
+		}
+	}
+	return secrets, nil
+}
+
+func (c *policyClient) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1.PushSecretData) error {
+	if err := c.check(data.GetRemoteKey()); err != nil {
+		return err
+	}
+	return c.SecretsClient.PushSecret(ctx, secret, data)
+}
+
+func (c *policyClient) DeleteSecret(ctx context.Context, remoteRef esv1.PushSecretRemoteRef) error {
+	if err := c.check(remoteRef.GetRemoteKey()); err != nil {
+		return err
+	}
+	return c.SecretsClient.DeleteSecret(ctx, remoteRef)
+}
+
+func (c *policyClient) SecretExists(ctx context.Context, remoteRef esv1.PushSecretRemoteRef) (bool, error) {
+	if err := c.check(remoteRef.GetRemoteKey()); err != nil {
+		return false, err
+	}
+	return c.SecretsClient.SecretExists(ctx, remoteRef)
+}
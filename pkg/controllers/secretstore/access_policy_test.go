@@ -0,0 +1,108 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	testingfake "github.com/external-secrets/external-secrets/runtime/testing/fake"
+)
+
+type findClient struct {
+	MockFakeClient
+	secrets map[string][]byte
+}
+
+func (c *findClient) GetAllSecrets(_ context.Context, _ esv1.ExternalSecretFind) (map[string][]byte, error) {
+	return c.secrets, nil
+}
+
+func TestManagerAccessPolicies(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(esv1.AddToScheme(scheme))
+
+	store := &esv1.ClusterSecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1.ClusterSecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec: esv1.SecretStoreSpec{
+			Provider: &esv1.SecretStoreProvider{AWS: &esv1.AWSProvider{}},
+			AccessPolicies: []esv1.ClusterSecretStoreAccessPolicy{{
+				ClusterSecretStoreCondition: esv1.ClusterSecretStoreCondition{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+				},
+				AllowedKeys: []string{`{{ .Namespace }}/.*`},
+			}},
+		},
+	}
+	tenant := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Labels: map[string]string{"tenant": "true"}}}
+	platform := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}}
+	kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(store, tenant, platform).Build()
+
+	provided := &findClient{secrets: map[string][]byte{"tenant/db": nil, "platform/db": nil, "tenant/../platform/db": nil}}
+	esv1.ForceRegister(&WrapProvider{newClientFunc: func(context.Context, esv1.GenericStore, client.Client, string) (esv1.SecretsClient, error) {
+		return provided, nil
+	}}, &esv1.SecretStoreProvider{AWS: &esv1.AWSProvider{}}, esv1.MaintenanceStatusMaintained)
+	storeRef := esv1.SecretStoreRef{Name: "shared", Kind: esv1.ClusterSecretStoreKind}
+
+	mgr := NewManager(kube, "", false)
+	defer func() {
+		_ = mgr.Close(ctx)
+	}()
+	secretClient, err := mgr.Get(ctx, storeRef, "tenant", nil)
+	require.NoError(t, err)
+
+	_, err = secretClient.GetSecret(ctx, esv1.ExternalSecretDataRemoteRef{Key: "tenant/db"})
+	require.NoError(t, err)
+	_, err = secretClient.GetSecretMap(ctx, esv1.ExternalSecretDataRemoteRef{Key: "platform/db"})
+	require.EqualError(t, err, `access to remote key "platform/db" is denied for namespace "tenant" by spec.accessPolicies of ClusterSecretStore "shared"`)
+	err = secretClient.PushSecret(ctx, &corev1.Secret{}, testingfake.PushSecretData{RemoteKey: "platform/db"})
+	require.Error(t, err)
+	err = secretClient.DeleteSecret(ctx, testingfake.PushSecretData{RemoteKey: "tenant/db"})
+	require.NoError(t, err)
+
+	// keys that resolve to another key by the provider can not bypass the policy
+	for _, key := range []string{"tenant/../platform/db", "tenant/./db", "./tenant/db"} {
+		_, err = secretClient.GetSecret(ctx, esv1.ExternalSecretDataRemoteRef{Key: key})
+		assert.Error(t, err, "key %q should be denied", key)
+		err = secretClient.PushSecret(ctx, &corev1.Secret{}, testingfake.PushSecretData{RemoteKey: key})
+		assert.Error(t, err, "push to key %q should be denied", key)
+	}
+	_, err = secretClient.GetSecret(ctx, esv1.ExternalSecretDataRemoteRef{Key: "/tenant//db"})
+	require.NoError(t, err)
+
+	found, err := secretClient.GetAllSecrets(ctx, esv1.ExternalSecretFind{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"tenant/db": nil}, found, "keys the namespace must not access are filtered")
+
+	unrestricted, err := mgr.Get(ctx, storeRef, "platform", nil)
+	require.NoError(t, err)
+	assert.Same(t, provided, unrestricted, "the client is not wrapped if no policy applies")
+}
@@ -21,13 +21,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,9 +49,13 @@ type Manager struct {
 	client          client.Client
 	controllerClass string
 	enableFloodgate bool
+	watches         *WatchManager
+	pool            *ClientPool
 
 	// store clients by provider type
 	clientMap map[clientKey]*clientVal
+	// releases return the clients borrowed from the pool
+	releases []func(context.Context)
 }
 
 type clientKey struct {
@@ -78,6 +79,20 @@ func NewManager(ctrlClient client.Client, controllerClass string, enableFloodgat
 	}
 }
 
+// WithWatchManager makes the manager start a watch of every store
+// whose client implements esv1.SecretsWatcher.
+func (m *Manager) WithWatchManager(watches *WatchManager) *Manager {
+	m.watches = watches
+	return m
+}
+
+// WithClientPool makes the manager borrow clients from the pool instead of creating
+// a client per reconcile, for the providers whose clients may be pooled.
+func (m *Manager) WithClientPool(pool *ClientPool) *Manager {
+	m.pool = pool
+	return m
+}
+
 // GetFromStore returns a provider client from the given store.
 // Do not close the client returned from this func, instead close
 // the manager once you're done with reconciling the external secret.
@@ -86,6 +101,9 @@ func (m *Manager) GetFromStore(ctx context.Context, store esv1.GenericStore, nam
 	if err != nil {
 		return nil, err
 	}
+	if m.pool != nil && poolableClients(storeProvider) {
+		return m.borrow(ctx, storeProvider, store, namespace)
+	}
 	secretClient := m.getStoredClient(ctx, storeProvider, store)
 	if secretClient != nil {
 		return secretClient, nil
@@ -104,6 +122,27 @@ func (m *Manager) GetFromStore(ctx context.Context, store esv1.GenericStore, nam
 		client: secretClient,
 		store:  store,
 	}
+	if _, ok := secretClient.(esv1.SecretsWatcher); ok {
+		m.watches.Ensure(store, namespace)
+	}
+	return secretClient, nil
+}
+
+// borrow returns a client of the store from the pool, which is released when the manager is closed.
+func (m *Manager) borrow(ctx context.Context, storeProvider esv1.Provider, store esv1.GenericStore, namespace string) (esv1.SecretsClient, error) {
+	secretClient, release, err := m.pool.Borrow(ctx, store, namespace, m.client, func(kube client.Client) (esv1.SecretsClient, error) {
+		m.log.V(1).Info("creating new pooled client",
+			"provider", fmt.Sprintf("%T", storeProvider),
+			"store", fmt.Sprintf("%s/%s", store.GetNamespace(), store.GetName()))
+		return storeProvider.NewClient(esv1.WithPooledClient(ctx), store, kube, namespace)
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.releases = append(m.releases, release)
+	if _, ok := secretClient.(esv1.SecretsWatcher); ok {
+		m.watches.Ensure(store, namespace)
+	}
 	return secretClient, nil
 }
 
@@ -132,13 +171,36 @@ func (m *Manager) Get(ctx context.Context, storeRef esv1.SecretStoreRef, namespa
 		return nil, fmt.Errorf(errClusterStoreMismatch, store.GetName(), namespace)
 	}
 
+	policy, err := m.remoteKeyPolicy(ctx, store, namespace)
+	if err != nil {
+		return nil, err
+	}
+
 	if m.enableFloodgate {
 		err := assertStoreIsUsable(store)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return m.GetFromStore(ctx, store, namespace)
+	secretClient, err := m.GetFromStore(ctx, store, namespace)
+	if err != nil || policy == nil {
+		return secretClient, err
+	}
+	return &policyClient{
+		SecretsClient: secretClient,
+		policy:        policy,
+		store:         store.GetName(),
+		namespace:     namespace,
+	}, nil
+}
+
+// GetStore returns the store referenced by storeRef or sourceRef.secretStoreRef,
+// while sourceRef.SecretStoreRef takes precedence over storeRef.
+func (m *Manager) GetStore(ctx context.Context, storeRef esv1.SecretStoreRef, namespace string, sourceRef *esv1.StoreGeneratorSourceRef) (esv1.GenericStore, error) {
+	if sourceRef != nil && sourceRef.SecretStoreRef != nil {
+		storeRef = *sourceRef.SecretStoreRef
+	}
+	return m.getStore(ctx, &storeRef, namespace)
 }
 
 // returns a previously stored client from the cache if store and store-version match
@@ -208,8 +270,12 @@ func (m *Manager) getStore(ctx context.Context, storeRef *esv1.SecretStoreRef, n
 	return &store, nil
 }
 
-// Close cleans up all clients.
+// Close cleans up all clients and releases the clients borrowed from the pool.
 func (m *Manager) Close(ctx context.Context) error {
+	for _, release := range m.releases {
+		release(ctx)
+	}
+	m.releases = nil
 	var errs []string
 	for key, val := range m.clientMap {
 		err := val.client.Close(ctx)
@@ -233,49 +299,42 @@ func (m *Manager) shouldProcessSecret(store esv1.GenericStore, ns string) (bool,
 		return true, nil
 	}
 
-	namespace := v1.Namespace{}
-	if err := m.client.Get(context.Background(), client.ObjectKey{Name: ns}, &namespace); err != nil {
-		return false, fmt.Errorf("failed to get a namespace %q: %w", ns, err)
+	nsLabels, err := m.namespaceLabels(context.Background(), ns)
+	if err != nil {
+		return false, err
 	}
-
-	nsLabels := labels.Set(namespace.GetLabels())
 	for _, condition := range store.GetSpec().Conditions {
-		var labelSelectors []*metav1.LabelSelector
-		if condition.NamespaceSelector != nil {
-			labelSelectors = append(labelSelectors, condition.NamespaceSelector)
+		match, err := condition.Matches(ns, nsLabels)
+		if err != nil {
+			return false, err
 		}
-		for _, n := range condition.Namespaces {
-			labelSelectors = append(labelSelectors, &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"kubernetes.io/metadata.name": n,
-				},
-			})
-		}
-
-		for _, ls := range labelSelectors {
-			selector, err := metav1.LabelSelectorAsSelector(ls)
-			if err != nil {
-				return false, fmt.Errorf("failed to convert label selector into selector %v: %w", ls, err)
-			}
-			if selector.Matches(nsLabels) {
-				return true, nil
-			}
+		if match {
+			return true, nil
 		}
+	}
 
-		for _, reg := range condition.NamespaceRegexes {
-			match, err := regexp.MatchString(reg, ns)
-			if err != nil {
-				// Should not happen since store validation already verified the regexes.
-				return false, fmt.Errorf("failed to compile regex %v: %w", reg, err)
-			}
+	return false, nil
+}
 
-			if match {
-				return true, nil
-			}
-		}
+// remoteKeyPolicy returns the policy the access policies of a ClusterSecretStore apply to the namespace,
+// or nil if the namespace may access all remote keys.
+func (m *Manager) remoteKeyPolicy(ctx context.Context, store esv1.GenericStore, ns string) (*esv1.RemoteKeyPolicy, error) {
+	if store.GetKind() != esv1.ClusterSecretStoreKind || len(store.GetSpec().AccessPolicies) == 0 {
+		return nil, nil
+	}
+	nsLabels, err := m.namespaceLabels(ctx, ns)
+	if err != nil {
+		return nil, err
 	}
+	return esv1.NewRemoteKeyPolicy(store, ns, nsLabels)
+}
 
-	return false, nil
+func (m *Manager) namespaceLabels(ctx context.Context, ns string) (map[string]string, error) {
+	namespace := v1.Namespace{}
+	if err := m.client.Get(ctx, client.ObjectKey{Name: ns}, &namespace); err != nil {
+		return nil, fmt.Errorf("failed to get a namespace %q: %w", ns, err)
+	}
+	return namespace.GetLabels(), nil
 }
 
 // assertStoreIsUsable assert that the store is ready to use.
@@ -0,0 +1,369 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/runtime/cache"
+)
+
+const (
+	// credentialsExpiryMargin is how long before their credentials expire clients are replaced.
+	credentialsExpiryMargin = time.Minute
+	// minEvictionInterval is the minimal interval at which idle clients are evicted.
+	minEvictionInterval = 10 * time.Second
+
+	evictionReasonIdle     = "idle"
+	evictionReasonExpired  = "expired"
+	evictionReasonOutdated = "outdated"
+	evictionReasonCapacity = "capacity"
+	evictionReasonShutdown = "shutdown"
+)
+
+// ClientPool keeps provider clients open across reconciles, so that reconciles
+// borrow a client instead of authenticating against the provider every time.
+//
+// Clients are pooled per store and, for ClusterSecretStores, per namespace,
+// as providers with referent authentication resolve their credentials in the
+// namespace of the reconciled object. A client is replaced once the UID or
+// resourceVersion of its store or of a Secret read while creating it changes,
+// its credentials are about to expire, or it is older than the maximum age. Clients that have not been borrowed for
+// the idle timeout are closed. Borrowed clients are reference counted and only
+// closed once all borrowers have released them.
+type ClientPool struct {
+	log         logr.Logger
+	idleTimeout time.Duration
+	maxAge      time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	clients *cache.Cache[*pooledClient]
+	// closing are the evicted clients that are no longer borrowed and have to be closed.
+	closing []*pooledClient
+}
+
+type pooledClient struct {
+	client  esv1.SecretsClient
+	version string
+	// secrets are the Secrets the provider read while creating the client.
+	secrets   []types.NamespacedName
+	createdAt time.Time
+	lastUsed  time.Time
+	expiresAt time.Time
+	refs      int
+	// reason is why the client is evicted, if it is.
+	reason string
+}
+
+// NewClientPool creates a pool of up to size clients. Clients are closed once they have not been
+// borrowed for idleTimeout and replaced once they are older than maxAge, a maxAge of 0 disables it.
+func NewClientPool(log logr.Logger, size int, idleTimeout, maxAge time.Duration) (*ClientPool, error) {
+	p := &ClientPool{
+		log:         log,
+		idleTimeout: idleTimeout,
+		maxAge:      maxAge,
+		now:         time.Now,
+	}
+	clients, err := cache.New(size, p.evicted)
+	if err != nil {
+		return nil, err
+	}
+	p.clients = clients
+	return p, nil
+}
+
+// evicted is called by the cache with p.mu held whenever a client is evicted.
+func (p *ClientPool) evicted(pc *pooledClient) {
+	if pc.reason == "" {
+		pc.reason = evictionReasonCapacity
+	}
+	clientPoolEvictions.WithLabelValues(pc.reason).Inc()
+	if pc.refs == 0 {
+		p.closing = append(p.closing, pc)
+	}
+}
+
+// Borrow returns a pooled client of the store for the namespace, or creates one with newClient.
+// newClient must create the client with the given kube client, which records the Secrets it reads.
+// The client must not be closed, instead release must be called once the reconcile is done with it.
+func (p *ClientPool) Borrow(ctx context.Context, store esv1.GenericStore, namespace string, kube client.Client, newClient func(kube client.Client) (esv1.SecretsClient, error)) (esv1.SecretsClient, func(context.Context), error) {
+	key := poolKey(store, namespace)
+	storeVersion := fmt.Sprintf("%s/%s", store.GetUID(), store.GetResourceVersion())
+
+	// the version of the pooled client includes the Secrets it has been created with,
+	// so that it is replaced once credentials in one of them are rotated.
+	p.mu.Lock()
+	var secrets []types.NamespacedName
+	if pc, ok := p.clients.Peek(key); ok {
+		secrets = pc.secrets
+	}
+	p.mu.Unlock()
+	version := storeVersion + secretVersions(ctx, kube, secrets)
+
+	p.mu.Lock()
+	pc, ok := p.get(key, version)
+	p.mu.Unlock()
+	p.closeEvicted(ctx)
+	if ok {
+		clientPoolBorrows.WithLabelValues("hit").Inc()
+		return pc.client, p.releaseFunc(pc), nil
+	}
+
+	clientPoolBorrows.WithLabelValues("miss").Inc()
+	recorder := &secretRecorder{Client: kube, versions: make(map[types.NamespacedName]string)}
+	secretClient, err := newClient(recorder)
+	if err != nil {
+		return nil, nil, err
+	}
+	secrets, version = recorder.recorded()
+	version = storeVersion + version
+	now := p.now()
+	created := &pooledClient{
+		client:    secretClient,
+		version:   version,
+		secrets:   secrets,
+		createdAt: now,
+		lastUsed:  now,
+		refs:      1,
+	}
+	if expirer, ok := secretClient.(esv1.CredentialsExpirer); ok {
+		created.expiresAt = expirer.CredentialsExpireAt()
+	}
+
+	p.mu.Lock()
+	// another reconcile may have created a client of the store in the meantime
+	pc, ok = p.get(key, version)
+	if !ok {
+		p.clients.Add(version, key, created)
+		pc = created
+	}
+	clientPoolClients.Set(float64(p.clients.Len()))
+	p.mu.Unlock()
+	if pc != created {
+		created.refs = 0
+		p.close(ctx, created)
+	}
+	p.closeEvicted(ctx)
+	return pc.client, p.releaseFunc(pc), nil
+}
+
+// get returns a usable client of the key and borrows it. Outdated or expired clients are evicted.
+func (p *ClientPool) get(key cache.Key, version string) (*pooledClient, bool) {
+	pc, ok := p.clients.Peek(key)
+	if !ok {
+		return nil, false
+	}
+	now := p.now()
+	switch {
+	case pc.version != version:
+		pc.reason = evictionReasonOutdated
+	case p.expired(pc, now):
+		pc.reason = evictionReasonExpired
+	}
+	if pc.reason != "" {
+		p.clients.Remove(key)
+		clientPoolClients.Set(float64(p.clients.Len()))
+		return nil, false
+	}
+	// mark the client as recently used
+	p.clients.Get(version, key)
+	pc.refs++
+	pc.lastUsed = now
+	return pc, true
+}
+
+// expired returns true if the credentials of the client are about to expire or the client is older than the maximum age.
+func (p *ClientPool) expired(pc *pooledClient, now time.Time) bool {
+	if !pc.expiresAt.IsZero() && !now.Before(pc.expiresAt.Add(-credentialsExpiryMargin)) {
+		return true
+	}
+	return p.maxAge > 0 && now.Sub(pc.createdAt) >= p.maxAge
+}
+
+func (p *ClientPool) releaseFunc(pc *pooledClient) func(context.Context) {
+	var once sync.Once
+	return func(ctx context.Context) {
+		once.Do(func() {
+			p.mu.Lock()
+			pc.refs--
+			pc.lastUsed = p.now()
+			if pc.reason != "" && pc.refs == 0 {
+				p.closing = append(p.closing, pc)
+			}
+			p.mu.Unlock()
+			p.closeEvicted(ctx)
+		})
+	}
+}
+
+// closeEvicted closes the evicted clients that are no longer borrowed.
+func (p *ClientPool) closeEvicted(ctx context.Context) {
+	p.mu.Lock()
+	closing := p.closing
+	p.closing = nil
+	p.mu.Unlock()
+	for _, pc := range closing {
+		p.close(ctx, pc)
+	}
+}
+
+func (p *ClientPool) close(ctx context.Context, pc *pooledClient) {
+	if err := pc.client.Close(ctx); err != nil {
+		p.log.Error(err, "unable to close pooled client", "reason", pc.reason)
+	}
+}
+
+// evictIdle evicts the clients that are not borrowed and have not been used for the idle timeout,
+// as well as the clients whose credentials are about to expire.
+func (p *ClientPool) evictIdle(ctx context.Context) {
+	p.mu.Lock()
+	now := p.now()
+	for _, key := range p.clients.Keys() {
+		pc, ok := p.clients.Peek(key)
+		if !ok || pc.refs > 0 {
+			continue
+		}
+		switch {
+		case now.Sub(pc.lastUsed) >= p.idleTimeout:
+			pc.reason = evictionReasonIdle
+		case p.expired(pc, now):
+			pc.reason = evictionReasonExpired
+		default:
+			continue
+		}
+		p.clients.Remove(key)
+	}
+	clientPoolClients.Set(float64(p.clients.Len()))
+	p.mu.Unlock()
+	p.closeEvicted(ctx)
+}
+
+// Start evicts idle clients until ctx is done, and closes all clients afterwards.
+func (p *ClientPool) Start(ctx context.Context) error {
+	interval := max(p.idleTimeout/2, minEvictionInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			p.purge()
+			return nil
+		case <-ticker.C:
+			p.evictIdle(ctx)
+		}
+	}
+}
+
+// purge evicts all clients, borrowed clients are closed once they are released.
+func (p *ClientPool) purge() {
+	p.mu.Lock()
+	for _, key := range p.clients.Keys() {
+		if pc, ok := p.clients.Peek(key); ok {
+			pc.reason = evictionReasonShutdown
+		}
+		p.clients.Remove(key)
+	}
+	clientPoolClients.Set(0)
+	p.mu.Unlock()
+	p.closeEvicted(context.Background())
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable,
+// as the pool is used by every replica that reconciles.
+func (p *ClientPool) NeedLeaderElection() bool {
+	return false
+}
+
+// secretRecorder records the resourceVersions of the Secrets read through the client.
+type secretRecorder struct {
+	client.Client
+	mu       sync.Mutex
+	versions map[types.NamespacedName]string
+}
+
+func (r *secretRecorder) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := r.Client.Get(ctx, key, obj, opts...)
+	if secret, ok := obj.(*corev1.Secret); ok && err == nil {
+		r.mu.Lock()
+		r.versions[key] = secret.ResourceVersion
+		r.mu.Unlock()
+	}
+	return err
+}
+
+// recorded returns the recorded Secrets and their versions in the format of secretVersions.
+func (r *secretRecorder) recorded() ([]types.NamespacedName, string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	secrets := make([]types.NamespacedName, 0, len(r.versions))
+	for key := range r.versions {
+		secrets = append(secrets, key)
+	}
+	slices.SortFunc(secrets, func(a, b types.NamespacedName) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	var version strings.Builder
+	for _, key := range secrets {
+		fmt.Fprintf(&version, ";%s=%s", key, r.versions[key])
+	}
+	return secrets, version.String()
+}
+
+// secretVersions returns the current resourceVersions of the Secrets. A Secret that can not be
+// read has an empty version, so that a client created with it is replaced.
+func secretVersions(ctx context.Context, kube client.Client, secrets []types.NamespacedName) string {
+	var version strings.Builder
+	for _, key := range secrets {
+		var secret corev1.Secret
+		if err := kube.Get(ctx, key, &secret); err != nil {
+			secret.ResourceVersion = ""
+		}
+		fmt.Fprintf(&version, ";%s=%s", key, secret.ResourceVersion)
+	}
+	return version.String()
+}
+
+// poolKey returns the key of the clients of a store for a namespace.
+func poolKey(store esv1.GenericStore, namespace string) cache.Key {
+	key := cache.Key{
+		Name:      store.GetName(),
+		Namespace: store.GetNamespace(),
+		Kind:      store.GetKind(),
+	}
+	if store.GetKind() == esv1.ClusterSecretStoreKind {
+		key.Namespace = namespace
+	}
+	return key
+}
+
+// poolableClients returns true if the clients of the provider may be pooled.
+func poolableClients(storeProvider esv1.Provider) bool {
+	poolable, ok := storeProvider.(esv1.PoolableClientProvider)
+	return ok && poolable.PoolableClients()
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ClientPoolSubsystem is the subsystem of the client pool metrics.
+	ClientPoolSubsystem = "client_pool"
+	// ClientPoolClientsKey is the metric key for the number of pooled clients.
+	ClientPoolClientsKey = "clients"
+	// ClientPoolBorrowsKey is the metric key for the number of borrowed clients.
+	ClientPoolBorrowsKey = "borrows_total"
+	// ClientPoolEvictionsKey is the metric key for the number of evicted clients.
+	ClientPoolEvictionsKey = "evictions_total"
+)
+
+var (
+	clientPoolClients = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: ClientPoolSubsystem,
+		Name:      ClientPoolClientsKey,
+		Help:      "The number of provider clients in the client pool",
+	})
+
+	clientPoolBorrows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ClientPoolSubsystem,
+		Name:      ClientPoolBorrowsKey,
+		Help:      "Total number of provider clients borrowed from the client pool, by whether a pooled client has been reused (hit) or a new client has been created (miss)",
+	}, []string{"result"})
+
+	clientPoolEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ClientPoolSubsystem,
+		Name:      ClientPoolEvictionsKey,
+		Help:      "Total number of provider clients evicted from the client pool, by reason",
+	}, []string{"reason"})
+)
+
+// SetUpClientPoolMetrics is called at the root to register the client pool metrics.
+func SetUpClientPoolMetrics() {
+	metrics.Registry.MustRegister(clientPoolClients, clientPoolBorrows, clientPoolEvictions)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+type expiringClient struct {
+	MockFakeClient
+	expiresAt time.Time
+}
+
+func (c *expiringClient) CredentialsExpireAt() time.Time {
+	return c.expiresAt
+}
+
+type testPool struct {
+	*ClientPool
+	now     time.Time
+	created []esv1.SecretsClient
+}
+
+func newTestPool(t *testing.T, size int, maxAge time.Duration) *testPool {
+	t.Helper()
+	pool, err := NewClientPool(logr.Discard(), size, 10*time.Minute, maxAge)
+	require.NoError(t, err)
+	tp := &testPool{ClientPool: pool, now: time.Now()}
+	pool.now = func() time.Time { return tp.now }
+	return tp
+}
+
+// borrow borrows a client of the store, new clients are created by newClient or as MockFakeClient.
+func (tp *testPool) borrow(t *testing.T, store esv1.GenericStore, namespace string, newClient func() esv1.SecretsClient) (esv1.SecretsClient, func(context.Context)) {
+	t.Helper()
+	secretClient, release, err := tp.Borrow(context.Background(), store, namespace, nil, func(client.Client) (esv1.SecretsClient, error) {
+		var created esv1.SecretsClient = &MockFakeClient{}
+		if newClient != nil {
+			created = newClient()
+		}
+		tp.created = append(tp.created, created)
+		return created, nil
+	})
+	require.NoError(t, err)
+	return secretClient, release
+}
+
+func testStore(kind, name, resourceVersion string) esv1.GenericStore {
+	meta := metav1.ObjectMeta{Name: name, UID: types.UID("uid-" + name), ResourceVersion: resourceVersion}
+	if kind == esv1.ClusterSecretStoreKind {
+		return &esv1.ClusterSecretStore{TypeMeta: metav1.TypeMeta{Kind: kind}, ObjectMeta: meta}
+	}
+	meta.Namespace = "foo"
+	return &esv1.SecretStore{TypeMeta: metav1.TypeMeta{Kind: kind}, ObjectMeta: meta}
+}
+
+func TestClientPoolBorrow(t *testing.T) {
+	ctx := context.Background()
+	pool := newTestPool(t, 10, 0)
+	store := testStore(esv1.SecretStoreKind, "store", "1")
+	clusterStore := testStore(esv1.ClusterSecretStoreKind, "cluster", "1")
+
+	first, release := pool.borrow(t, store, "foo", nil)
+	second, releaseSecond := pool.borrow(t, store, "foo", nil)
+	assert.Same(t, first, second, "a pooled client is shared")
+	release(ctx)
+	release(ctx)
+	releaseSecond(ctx)
+
+	nsA, releaseA := pool.borrow(t, clusterStore, "a", nil)
+	nsB, releaseB := pool.borrow(t, clusterStore, "b", nil)
+	assert.NotSame(t, nsA, nsB, "clients of a ClusterSecretStore are pooled per namespace")
+	releaseA(ctx)
+	releaseB(ctx)
+	assert.Len(t, pool.created, 3)
+
+	// a changed store replaces the client once it is released
+	borrowed, release := pool.borrow(t, store, "foo", nil)
+	changed, releaseChanged := pool.borrow(t, testStore(esv1.SecretStoreKind, "store", "2"), "foo", nil)
+	assert.NotSame(t, borrowed, changed)
+	assert.False(t, borrowed.(*MockFakeClient).closeCalled, "a borrowed client is not closed")
+	release(ctx)
+	assert.True(t, borrowed.(*MockFakeClient).closeCalled, "an outdated client is closed once it is released")
+	releaseChanged(ctx)
+	assert.False(t, changed.(*MockFakeClient).closeCalled)
+}
+
+func TestClientPoolSecretRotation(t *testing.T) {
+	ctx := context.Background()
+	pool := newTestPool(t, 10, 0)
+	store := testStore(esv1.SecretStoreKind, "store", "1")
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("old")},
+	}
+	kube := fakeclient.NewClientBuilder().WithObjects(credentials).Build()
+	borrow := func() esv1.SecretsClient {
+		t.Helper()
+		secretClient, release, err := pool.Borrow(ctx, store, "foo", kube, func(kube client.Client) (esv1.SecretsClient, error) {
+			// the provider reads its credentials while creating the client
+			var secret corev1.Secret
+			if err := kube.Get(ctx, client.ObjectKeyFromObject(credentials), &secret); err != nil {
+				return nil, err
+			}
+			return &MockFakeClient{}, nil
+		})
+		require.NoError(t, err)
+		release(ctx)
+		return secretClient
+	}
+
+	first := borrow()
+	assert.Same(t, first, borrow(), "the client is kept while its Secrets do not change")
+
+	credentials.Data["token"] = []byte("new")
+	require.NoError(t, kube.Update(ctx, credentials))
+	rotated := borrow()
+	assert.NotSame(t, first, rotated, "the client is replaced once a Secret it has been created with changes")
+	assert.True(t, first.(*MockFakeClient).closeCalled)
+	assert.Same(t, rotated, borrow())
+
+	require.NoError(t, kube.Delete(ctx, credentials))
+	_, _, err := pool.Borrow(ctx, store, "foo", kube, func(kube client.Client) (esv1.SecretsClient, error) {
+		return nil, kube.Get(ctx, client.ObjectKeyFromObject(credentials), &corev1.Secret{})
+	})
+	require.Error(t, err, "the client is not reused once a Secret it has been created with is deleted")
+}
+
+func TestClientPoolExpiry(t *testing.T) {
+	ctx := context.Background()
+	pool := newTestPool(t, 10, time.Hour)
+	store := testStore(esv1.SecretStoreKind, "store", "1")
+
+	expiring, release := pool.borrow(t, store, "foo", func() esv1.SecretsClient {
+		return &expiringClient{expiresAt: pool.now.Add(10 * time.Minute)}
+	})
+	release(ctx)
+
+	pool.now = pool.now.Add(9*time.Minute + 30*time.Second)
+	renewed, release := pool.borrow(t, store, "foo", nil)
+	release(ctx)
+	assert.NotSame(t, expiring, renewed, "a client is replaced before its credentials expire")
+	assert.True(t, expiring.(*expiringClient).closeCalled)
+
+	pool.now = pool.now.Add(time.Hour)
+	aged, release := pool.borrow(t, store, "foo", nil)
+	release(ctx)
+	assert.NotSame(t, renewed, aged, "a client is replaced once it reaches the maximum age")
+	assert.True(t, renewed.(*MockFakeClient).closeCalled)
+}
+
+func TestClientPoolEviction(t *testing.T) {
+	ctx := context.Background()
+	pool := newTestPool(t, 1, 0)
+
+	first, releaseFirst := pool.borrow(t, testStore(esv1.SecretStoreKind, "first", "1"), "foo", nil)
+	second, releaseSecond := pool.borrow(t, testStore(esv1.SecretStoreKind, "second", "1"), "foo", nil)
+	assert.False(t, first.(*MockFakeClient).closeCalled, "a borrowed client is not closed when the pool is full")
+	releaseFirst(ctx)
+	assert.True(t, first.(*MockFakeClient).closeCalled)
+
+	pool.now = pool.now.Add(time.Hour)
+	pool.evictIdle(ctx)
+	assert.False(t, second.(*MockFakeClient).closeCalled, "a borrowed client is not idle")
+
+	releaseSecond(ctx)
+	pool.now = pool.now.Add(time.Hour)
+	pool.evictIdle(ctx)
+	assert.True(t, second.(*MockFakeClient).closeCalled, "an idle client is closed")
+	assert.Equal(t, 0, pool.clients.Len())
+}
+
+// poolableProvider is a provider whose clients may be pooled.
+type poolableProvider struct {
+	*WrapProvider
+}
+
+func (p *poolableProvider) PoolableClients() bool {
+	return true
+}
+
+func TestManagerClientPool(t *testing.T) {
+	ctx := context.Background()
+	pool := newTestPool(t, 10, 0)
+	store := testStore(esv1.SecretStoreKind, "store", "1")
+	store.GetSpec().Provider = &esv1.SecretStoreProvider{AWS: &esv1.AWSProvider{}}
+	pooled := &MockFakeClient{}
+	esv1.ForceRegister(&poolableProvider{&WrapProvider{newClientFunc: func(context.Context, esv1.GenericStore, client.Client, string) (esv1.SecretsClient, error) {
+		return pooled, nil
+	}}}, &esv1.SecretStoreProvider{AWS: &esv1.AWSProvider{}}, esv1.MaintenanceStatusMaintained)
+
+	for range 2 {
+		mgr := NewManager(nil, "", false).WithClientPool(pool.ClientPool)
+		got, err := mgr.GetFromStore(ctx, store, "foo")
+		require.NoError(t, err)
+		assert.Same(t, pooled, got)
+		require.NoError(t, mgr.Close(ctx))
+		assert.False(t, pooled.closeCalled, "closing the manager releases a pooled client")
+	}
+	assert.Equal(t, 1, pool.clients.Len())
+}
+
+func TestManagerClientPoolOptIn(t *testing.T) {
+	ctx := context.Background()
+	pool := newTestPool(t, 10, 0)
+	store := testStore(esv1.SecretStoreKind, "store", "1")
+	store.GetSpec().Provider = &esv1.SecretStoreProvider{AWS: &esv1.AWSProvider{}}
+	esv1.ForceRegister(&WrapProvider{newClientFunc: func(context.Context, esv1.GenericStore, client.Client, string) (esv1.SecretsClient, error) {
+		return &MockFakeClient{}, nil
+	}}, &esv1.SecretStoreProvider{AWS: &esv1.AWSProvider{}}, esv1.MaintenanceStatusMaintained)
+
+	mgr := NewManager(nil, "", false).WithClientPool(pool.ClientPool)
+	got, err := mgr.GetFromStore(ctx, store, "foo")
+	require.NoError(t, err)
+	require.NoError(t, mgr.Close(ctx))
+	assert.True(t, got.(*MockFakeClient).closeCalled, "the clients of providers that do not opt in are not pooled")
+	assert.Equal(t, 0, pool.clients.Len())
+}
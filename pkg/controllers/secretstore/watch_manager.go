@@ -0,0 +1,243 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+const (
+	// watchResetAfter resets the backoff of a watch that has been established for a while.
+	watchResetAfter = time.Minute
+	// watchGCInterval is the interval in which watches of deleted or changed stores are stopped.
+	watchGCInterval = 5 * time.Minute
+)
+
+// WatchManager keeps a long-lived watch per store and namespace for the providers whose
+// clients implement esv1.SecretsWatcher, and refreshes the ExternalSecrets that read a
+// changed key. The Manager starts a watch when it creates a client of a watchable store.
+// While a watch is down, ExternalSecrets are only refreshed by their refreshInterval
+// until the watch is re-established, which then refreshes all ExternalSecrets of the store
+// to catch up on the changes it has missed.
+type WatchManager struct {
+	log       logr.Logger
+	client    client.Client
+	refresher esv1.Refresher
+	backoff   wait.Backoff
+
+	mu      sync.Mutex
+	ctx     context.Context
+	watches map[watchKey]*storeWatch
+}
+
+type watchKey struct {
+	kind      string
+	name      string
+	namespace string
+}
+
+type storeWatch struct {
+	uid        types.UID
+	generation int64
+	cancel     context.CancelFunc
+}
+
+// NewWatchManager constructs a WatchManager that refreshes ExternalSecrets with refresher.
+func NewWatchManager(ctrlClient client.Client, refresher esv1.Refresher) *WatchManager {
+	return &WatchManager{
+		log:       ctrl.Log.WithName("watchmanager"),
+		client:    ctrlClient,
+		refresher: refresher,
+		backoff: wait.Backoff{
+			Duration: 5 * time.Second,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    8,
+			Cap:      5 * time.Minute,
+		},
+		watches: make(map[watchKey]*storeWatch),
+	}
+}
+
+// NeedLeaderElection makes sure that only the leader, which runs the
+// ExternalSecret controller, watches the stores.
+func (w *WatchManager) NeedLeaderElection() bool {
+	return true
+}
+
+// Start enables watches and stops the watches of deleted or changed stores until ctx is done.
+func (w *WatchManager) Start(ctx context.Context) error {
+	w.mu.Lock()
+	w.ctx = ctx
+	w.mu.Unlock()
+	wait.UntilWithContext(ctx, w.gc, watchGCInterval)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key, sw := range w.watches {
+		sw.cancel()
+		delete(w.watches, key)
+	}
+	return nil
+}
+
+// Ensure starts a watch of the store for the ExternalSecrets in namespace, unless the
+// very same store is already watched. Watches of a previous store generation are replaced.
+func (w *WatchManager) Ensure(store esv1.GenericStore, namespace string) {
+	if w == nil {
+		return
+	}
+	key := newWatchKey(store, namespace)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.ctx == nil || w.ctx.Err() != nil {
+		return
+	}
+	if sw, ok := w.watches[key]; ok {
+		if sw.uid == store.GetUID() && sw.generation == store.GetGeneration() {
+			return
+		}
+		sw.cancel()
+	}
+	ctx, cancel := context.WithCancel(w.ctx)
+	sw := &storeWatch{uid: store.GetUID(), generation: store.GetGeneration(), cancel: cancel}
+	w.watches[key] = sw
+	go w.run(ctx, key, sw, store)
+}
+
+func newWatchKey(store esv1.GenericStore, namespace string) watchKey {
+	if store.GetKind() == esv1.SecretStoreKind {
+		namespace = store.GetNamespace()
+	}
+	return watchKey{kind: store.GetKind(), name: store.GetName(), namespace: namespace}
+}
+
+// run watches the store until ctx is done and re-establishes the watch with backoff when it drops.
+func (w *WatchManager) run(ctx context.Context, key watchKey, sw *storeWatch, store esv1.GenericStore) {
+	log := w.log.WithValues("kind", key.kind, "name", key.name, "namespace", key.namespace)
+	defer w.remove(key, sw)
+	backoff := w.backoff
+	for reconnect := false; ; reconnect = true {
+		started := time.Now()
+		err := w.watch(ctx, key, store, reconnect)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			// the store no longer supports watches
+			return
+		}
+		if time.Since(started) > watchResetAfter {
+			backoff = w.backoff
+		}
+		delay := backoff.Step()
+		log.Error(err, "watch of store dropped, falling back to polling", "retryAfter", delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if !w.current(ctx, key, sw) {
+			return
+		}
+	}
+}
+
+// watch creates a client of the store and blocks while it watches the backend.
+// A watch that is re-established starts at the current state of the backend, so on
+// reconnect all ExternalSecrets of the store are refreshed to catch up on missed changes.
+func (w *WatchManager) watch(ctx context.Context, key watchKey, store esv1.GenericStore, reconnect bool) error {
+	provider, err := esv1.GetProvider(store)
+	if err != nil {
+		return err
+	}
+	secretsClient, err := provider.NewClient(ctx, store, w.client, key.namespace)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = secretsClient.Close(context.Background())
+	}()
+	watcher, ok := secretsClient.(esv1.SecretsWatcher)
+	if !ok {
+		return nil
+	}
+	ref := esv1.SecretStoreRef{Kind: key.kind, Name: key.name}
+	refresh := func(keys []string) {
+		n, err := w.refresher.RequestRefresh(ctx, ref, key.namespace, keys)
+		if err != nil {
+			w.log.Error(err, "unable to refresh ExternalSecrets", "kind", key.kind, "name", key.name, "keys", keys)
+			return
+		}
+		w.log.V(1).Info("secrets changed", "kind", key.kind, "name", key.name, "keys", keys, "refreshed", n)
+	}
+	w.log.V(1).Info("watching store", "kind", key.kind, "name", key.name, "namespace", key.namespace)
+	if reconnect {
+		refresh([]string{esv1.RefreshAnyKey})
+	}
+	return watcher.WatchSecrets(ctx, refresh)
+}
+
+// current reports whether the watched store still exists in the watched generation.
+func (w *WatchManager) current(ctx context.Context, key watchKey, sw *storeWatch) bool {
+	var store client.Object = &esv1.SecretStore{}
+	ref := types.NamespacedName{Namespace: key.namespace, Name: key.name}
+	if key.kind == esv1.ClusterSecretStoreKind {
+		store = &esv1.ClusterSecretStore{}
+		ref.Namespace = ""
+	}
+	if err := w.client.Get(ctx, ref, store); err != nil {
+		// keep the watch on transient errors
+		return !apierrors.IsNotFound(err)
+	}
+	return store.GetUID() == sw.uid && store.GetGeneration() == sw.generation
+}
+
+// gc stops the watches of deleted or changed stores.
+func (w *WatchManager) gc(ctx context.Context) {
+	w.mu.Lock()
+	watches := make(map[watchKey]*storeWatch, len(w.watches))
+	for key, sw := range w.watches {
+		watches[key] = sw
+	}
+	w.mu.Unlock()
+	for key, sw := range watches {
+		if !w.current(ctx, key, sw) {
+			sw.cancel()
+		}
+	}
+}
+
+func (w *WatchManager) remove(key watchKey, sw *storeWatch) {
+	sw.cancel()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watches[key] == sw {
+		delete(w.watches, key)
+	}
+}
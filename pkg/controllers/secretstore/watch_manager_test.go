@@ -0,0 +1,152 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+type watchFakeClient struct {
+	MockFakeClient
+	watches chan<- struct{}
+	changes <-chan []string
+	fail    <-chan error
+}
+
+func (c *watchFakeClient) WatchSecrets(ctx context.Context, onChange func(keys []string)) error {
+	c.watches <- struct{}{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case keys := <-c.changes:
+			onChange(keys)
+		case err := <-c.fail:
+			return err
+		}
+	}
+}
+
+type refreshCall struct {
+	store     esv1.SecretStoreRef
+	namespace string
+	keys      []string
+}
+
+type fakeRefresher chan refreshCall
+
+func (f fakeRefresher) RequestRefresh(_ context.Context, store esv1.SecretStoreRef, namespace string, keys []string) (int, error) {
+	f <- refreshCall{store: store, namespace: namespace, keys: keys}
+	return len(keys), nil
+}
+
+func TestWatchManager(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(esv1.AddToScheme(scheme))
+
+	watches := make(chan struct{}, 10)
+	changes := make(chan []string)
+	fail := make(chan error)
+	esv1.ForceRegister(&WrapProvider{
+		newClientFunc: func(context.Context, esv1.GenericStore, client.Client, string) (esv1.SecretsClient, error) {
+			return &watchFakeClient{watches: watches, changes: changes, fail: fail}, nil
+		},
+	}, &esv1.SecretStoreProvider{AWS: &esv1.AWSProvider{}}, esv1.MaintenanceStatusMaintained)
+
+	store := &esv1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", UID: "uid", Generation: 1},
+		Spec:       esv1.SecretStoreSpec{Provider: &esv1.SecretStoreProvider{AWS: &esv1.AWSProvider{}}},
+	}
+	kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(store.DeepCopy()).Build()
+	refresher := make(fakeRefresher, 10)
+	wm := NewWatchManager(kube, refresher)
+	wm.backoff = wait.Backoff{Duration: time.Millisecond, Steps: 1}
+
+	receive := func(ch <-chan struct{}) bool {
+		select {
+		case <-ch:
+			return true
+		case <-time.After(5 * time.Second):
+			return false
+		}
+	}
+
+	// watches are only started once the manager has been started
+	wm.Ensure(store, "ns")
+	assert.Empty(t, wm.watches)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = wm.Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		wm.mu.Lock()
+		defer wm.mu.Unlock()
+		return wm.ctx != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the client manager detects the watcher
+	mgr := NewManager(kube, "", false).WithWatchManager(wm)
+	_, err := mgr.GetFromStore(ctx, store, "ns")
+	require.NoError(t, err)
+	require.True(t, receive(watches), "watch has not been started")
+	_, err = NewManager(kube, "", false).WithWatchManager(wm).GetFromStore(ctx, store, "ns")
+	require.NoError(t, err)
+
+	changes <- []string{"db"}
+	call := <-refresher
+	assert.Equal(t, refreshCall{store: esv1.SecretStoreRef{Kind: esv1.SecretStoreKind, Name: "foo"}, namespace: "ns", keys: []string{"db"}}, call)
+	assert.Empty(t, watches, "the same store has been watched twice")
+
+	// a dropped watch is re-established
+	fail <- errors.New("connection reset")
+	require.True(t, receive(watches), "watch has not been re-established")
+	// and catches up on the changes missed while it was down
+	call = <-refresher
+	assert.Equal(t, refreshCall{store: esv1.SecretStoreRef{Kind: esv1.SecretStoreKind, Name: "foo"}, namespace: "ns", keys: []string{esv1.RefreshAnyKey}}, call)
+
+	// the watch of a changed store stops
+	updated := &esv1.SecretStore{}
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(store), updated))
+	updated.Generation = 2
+	require.NoError(t, kube.Update(ctx, updated))
+	fail <- errors.New("connection reset")
+	require.Eventually(t, func() bool {
+		wm.mu.Lock()
+		defer wm.mu.Unlock()
+		return len(wm.watches) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, watches)
+
+	// a new generation starts a new watch
+	wm.Ensure(updated, "ns")
+	require.True(t, receive(watches), "watch of the new generation has not been started")
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ShardSubsystem is the subsystem of the sharding metrics.
+	ShardSubsystem = "shard"
+	// MembersKey is the metric key for the number of replicas that share the objects.
+	MembersKey = "members"
+	// HashRangeRatioKey is the metric key for the fraction of the hash space a replica owns.
+	HashRangeRatioKey = "hash_range_ratio"
+	// OwnedObjectsKey is the metric key for the number of objects a replica owns.
+	OwnedObjectsKey = "owned_objects"
+	// RebalancesKey is the metric key for the number of rebalances.
+	RebalancesKey = "rebalances_total"
+)
+
+var shardLabelNames = []string{"shard"}
+
+var (
+	membersGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ShardSubsystem,
+		Name:      MembersKey,
+		Help:      "The number of controller replicas the shard has observed",
+	}, shardLabelNames)
+
+	hashRangeRatioGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ShardSubsystem,
+		Name:      HashRangeRatioKey,
+		Help:      "The fraction of the hash space owned by the shard",
+	}, shardLabelNames)
+
+	ownedObjectsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ShardSubsystem,
+		Name:      OwnedObjectsKey,
+		Help:      "The number of objects owned by the shard",
+	}, shardLabelNames)
+
+	rebalancesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ShardSubsystem,
+		Name:      RebalancesKey,
+		Help:      "Total number of changes of the shard members",
+	}, shardLabelNames)
+)
+
+// SetUpMetrics is called at the root to register the sharding metrics.
+func SetUpMetrics() {
+	metrics.Registry.MustRegister(membersGauge, hashRangeRatioGauge, ownedObjectsGauge, rebalancesCounter)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// virtualNodes is the number of points of every member on the ring,
+// which spreads the hash ranges of a member evenly across the hash space.
+const virtualNodes = 128
+
+type point struct {
+	hash  uint64
+	owner string
+}
+
+// ring is a consistent hash ring. Every member claims the hash ranges that end at its points,
+// so that only the ranges of a member move when it joins or leaves.
+type ring struct {
+	members []string
+	points  []point
+}
+
+func newRing(members []string) *ring {
+	r := &ring{members: slices.Compact(slices.Sorted(slices.Values(members)))}
+	r.points = make([]point, 0, len(r.members)*virtualNodes)
+	for _, member := range r.members {
+		for i := range virtualNodes {
+			r.points = append(r.points, point{hash: hash(member + "#" + strconv.Itoa(i)), owner: member})
+		}
+	}
+	// members with colliding points are ordered by name, so that all replicas agree on the owner
+	slices.SortFunc(r.points, func(a, b point) int {
+		return cmp.Or(cmp.Compare(a.hash, b.hash), cmp.Compare(a.owner, b.owner))
+	})
+	return r
+}
+
+// owner returns the member that owns the key, or an empty string if the ring has no members.
+// It is safe to call on a nil ring.
+func (r *ring) owner(key string) string {
+	if r == nil || len(r.points) == 0 {
+		return ""
+	}
+	h := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].owner
+}
+
+// share returns the fraction of the hash space the member owns.
+// It is safe to call on a nil ring.
+func (r *ring) share(member string) float64 {
+	if r == nil || len(r.points) == 0 {
+		return 0
+	}
+	var owned float64
+	for i, p := range r.points {
+		if p.owner != member {
+			continue
+		}
+		if i == 0 {
+			// the first point owns the range that wraps around the end of the hash space
+			owned += float64(p.hash) + float64(math.MaxUint64-r.points[len(r.points)-1].hash) + 1
+			continue
+		}
+		owned += float64(p.hash - r.points[i-1].hash)
+	}
+	return owned / (float64(math.MaxUint64) + 1)
+}
+
+// getMembers returns the sorted members of the ring. It is safe to call on a nil ring.
+func (r *ring) getMembers() []string {
+	if r == nil {
+		return nil
+	}
+	return r.members
+}
+
+func hash(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
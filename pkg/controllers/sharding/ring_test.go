@@ -0,0 +1,72 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	var empty *ring
+	if got := empty.owner("default/db"); got != "" {
+		t.Errorf("owner() of a nil ring = %q, want none", got)
+	}
+	if got := newRing(nil).owner("default/db"); got != "" {
+		t.Errorf("owner() of an empty ring = %q, want none", got)
+	}
+
+	members := []string{"eso-0", "eso-1", "eso-2"}
+	r := newRing(members)
+	if got := newRing([]string{"eso-2", "eso-0", "eso-1", "eso-0"}).getMembers(); fmt.Sprint(got) != fmt.Sprint(members) {
+		t.Errorf("getMembers() = %v, want %v", got, members)
+	}
+
+	var total float64
+	for _, member := range members {
+		share := r.share(member)
+		if share < 0.2 || share > 0.47 {
+			t.Errorf("share(%q) = %f, want about a third", member, share)
+		}
+		total += share
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("shares sum up to %f, want 1", total)
+	}
+
+	keys := make([]string, 3000)
+	counts := make(map[string]int)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("ns-%d/es-%d", i%7, i)
+		counts[r.owner(keys[i])]++
+	}
+	for _, member := range members {
+		if counts[member] < 600 || counts[member] > 1400 {
+			t.Errorf("%s owns %d of %d keys, want about a third", member, counts[member], len(keys))
+		}
+	}
+
+	// only the keys of a member that leaves move
+	smaller := newRing([]string{"eso-0", "eso-1"})
+	for _, key := range keys {
+		before, after := r.owner(key), smaller.owner(key)
+		if before != "eso-2" && before != after {
+			t.Fatalf("%s moved from %s to %s, although its owner is still a member", key, before, after)
+		}
+	}
+}
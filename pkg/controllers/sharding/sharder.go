@@ -0,0 +1,388 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding distributes the objects of a controller across its replicas.
+// Every replica renews a Lease, the replicas with a valid Lease form a consistent
+// hash ring and every replica only reconciles the objects whose namespace/name
+// hashes into the ranges it owns.
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// LabelShardGroup is set on the Leases of the replicas that share the objects of a controller.
+	LabelShardGroup = "external-secrets.io/shard-group"
+
+	// leases that have not been renewed for gcLeaseDurations lease durations are deleted.
+	gcLeaseDurations = 10
+	// ownedInterval is the interval at which the owned objects are counted, in addition to every rebalance.
+	ownedInterval  = time.Minute
+	releaseTimeout = 10 * time.Second
+
+	errIdentity      = "the identity of the replica is required"
+	errNamespace     = "the namespace of the shard leases is required"
+	errGroup         = "invalid shard group %q: %s"
+	errLeaseName     = "invalid shard lease name %q: %s"
+	errRenewInterval = "the renew interval %s must be positive and shorter than the lease duration %s"
+)
+
+// Options configure a Sharder.
+type Options struct {
+	// Namespace of the Leases.
+	Namespace string
+	// Group of the replicas that share the objects, replicas of different groups do not affect each other.
+	Group string
+	// Identity of the replica, e.g. its pod name.
+	Identity string
+	// LeaseDuration after which a replica that has not renewed its Lease leaves the ring.
+	LeaseDuration time.Duration
+	// RenewInterval at which the replica renews its Lease and observes the Leases of the other replicas.
+	RenewInterval time.Duration
+	Log           logr.Logger
+}
+
+// observation of the Lease of another replica. Leases are considered expired relative to the
+// time their renewal has been observed, which makes the membership independent of clock skew.
+type observation struct {
+	renewTime  time.Time
+	observedAt time.Time
+}
+
+// Sharder is a manager.Runnable that maintains the Lease of the replica and the
+// hash ring of all replicas. It runs on every replica, not only on the leader.
+type Sharder struct {
+	client client.Client
+	// reader reads the Leases from the API server, so that they are not cached.
+	reader client.Reader
+	opts   Options
+	now    func() time.Time
+
+	// observed and lastRenew are only accessed by sync.
+	observed  map[string]observation
+	lastRenew time.Time
+
+	mu          sync.RWMutex
+	ring        *ring
+	subscribers []chan struct{}
+}
+
+// New creates a Sharder.
+func New(c client.Client, reader client.Reader, opts Options) (*Sharder, error) {
+	if opts.Identity == "" {
+		return nil, errors.New(errIdentity)
+	}
+	if opts.Namespace == "" {
+		return nil, errors.New(errNamespace)
+	}
+	if errs := validation.IsValidLabelValue(opts.Group); opts.Group == "" || len(errs) > 0 {
+		return nil, fmt.Errorf(errGroup, opts.Group, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Subdomain(leaseName(opts)); len(errs) > 0 {
+		return nil, fmt.Errorf(errLeaseName, leaseName(opts), strings.Join(errs, ", "))
+	}
+	if opts.RenewInterval <= 0 || opts.RenewInterval >= opts.LeaseDuration {
+		return nil, fmt.Errorf(errRenewInterval, opts.RenewInterval, opts.LeaseDuration)
+	}
+	return &Sharder{
+		client:   c,
+		reader:   reader,
+		opts:     opts,
+		now:      time.Now,
+		observed: make(map[string]observation),
+	}, nil
+}
+
+func leaseName(opts Options) string {
+	return opts.Group + "-" + opts.Identity
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable,
+// every replica takes part in the ring.
+func (s *Sharder) NeedLeaderElection() bool {
+	return false
+}
+
+// Start renews the Lease of the replica and updates the ring until ctx is done.
+// The Lease is deleted on shutdown, so that the other replicas take over right away.
+func (s *Sharder) Start(ctx context.Context) error {
+	s.opts.Log.Info("starting shard", "identity", s.opts.Identity, "group", s.opts.Group, "namespace", s.opts.Namespace)
+	ticker := time.NewTicker(s.opts.RenewInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.sync(ctx); err != nil {
+			s.opts.Log.Error(err, "unable to sync shard members")
+		}
+		select {
+		case <-ctx.Done():
+			s.release()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Owns reports whether the replica owns the object. A replica owns no objects until
+// it has joined the ring. It is safe to call on a nil Sharder, which owns all objects.
+func (s *Sharder) Owns(namespace, name string) bool {
+	if s == nil {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ring.owner(namespace+"/"+name) == s.opts.Identity
+}
+
+// Predicate filters the events of objects that are owned by other replicas.
+func (s *Sharder) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return s.Owns(obj.GetNamespace(), obj.GetName())
+	})
+}
+
+// Source returns a source that enqueues the objects the replica owns whenever the
+// members of the ring change, so that objects are reconciled by their new owner.
+// list returns all objects of the controller, release is called for the objects
+// the replica no longer owns, e.g. to remove their metrics.
+func (s *Sharder) Source(list func(ctx context.Context) ([]types.NamespacedName, error), release func(types.NamespacedName)) source.TypedSource[reconcile.Request] {
+	return source.Func(func(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+		changed := s.subscribe()
+		go func() {
+			ticker := time.NewTicker(ownedInterval)
+			defer ticker.Stop()
+			owned := make(map[types.NamespacedName]struct{})
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-changed:
+				case <-ticker.C:
+				}
+				owned = s.rebalance(ctx, list, release, queue, owned)
+			}
+		}()
+		return nil
+	})
+}
+
+// rebalance enqueues the objects the replica has taken over, releases the objects it has handed
+// over and returns the owned objects.
+func (s *Sharder) rebalance(ctx context.Context, list func(ctx context.Context) ([]types.NamespacedName, error), release func(types.NamespacedName), queue workqueue.TypedRateLimitingInterface[reconcile.Request], previous map[types.NamespacedName]struct{}) map[types.NamespacedName]struct{} {
+	names, err := list(ctx)
+	if err != nil {
+		s.opts.Log.Error(err, "unable to list objects to rebalance")
+		return previous
+	}
+	owned := make(map[types.NamespacedName]struct{}, len(previous))
+	for _, name := range names {
+		if !s.Owns(name.Namespace, name.Name) {
+			continue
+		}
+		owned[name] = struct{}{}
+		if _, ok := previous[name]; !ok {
+			queue.Add(reconcile.Request{NamespacedName: name})
+		}
+	}
+	for name := range previous {
+		if _, ok := owned[name]; !ok && release != nil {
+			release(name)
+		}
+	}
+	ownedObjectsGauge.WithLabelValues(s.opts.Identity).Set(float64(len(owned)))
+	return owned
+}
+
+// subscribe returns a channel that receives a value once and whenever the members of the ring change.
+func (s *Sharder) subscribe() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	s.subscribers = append(s.subscribers, ch)
+	return ch
+}
+
+// sync renews the Lease of the replica, observes the Leases of the other replicas and updates the ring.
+func (s *Sharder) sync(ctx context.Context) error {
+	now := s.now()
+	renewErr := s.renew(ctx, now)
+	if renewErr == nil {
+		s.lastRenew = now
+	}
+
+	leases := &coordinationv1.LeaseList{}
+	if err := s.reader.List(ctx, leases, client.InNamespace(s.opts.Namespace), client.MatchingLabels{LabelShardGroup: s.opts.Group}); err != nil {
+		// without the Leases of the other replicas, the replica only keeps its ranges while its own Lease is valid
+		s.update(s.members(s.currentMembers(), now))
+		return errors.Join(renewErr, err)
+	}
+	seen := make(map[string]struct{}, len(leases.Items))
+	alive := make([]string, 0, len(leases.Items))
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		identity := ptr.Deref(lease.Spec.HolderIdentity, "")
+		if identity == "" || identity == s.opts.Identity {
+			continue
+		}
+		seen[identity] = struct{}{}
+		var renewTime time.Time
+		if lease.Spec.RenewTime != nil {
+			renewTime = lease.Spec.RenewTime.Time
+		}
+		o, ok := s.observed[identity]
+		if !ok || !o.renewTime.Equal(renewTime) {
+			// a Lease that is observed for the first time has been renewed at its renewTime at the latest
+			o = observation{renewTime: renewTime, observedAt: now}
+			if !ok && renewTime.Before(now) {
+				o.observedAt = renewTime
+			}
+			s.observed[identity] = o
+		}
+		duration := s.opts.LeaseDuration
+		if lease.Spec.LeaseDurationSeconds != nil {
+			duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		}
+		switch age := now.Sub(o.observedAt); {
+		case age < duration:
+			alive = append(alive, identity)
+		case age > gcLeaseDurations*duration:
+			s.deleteLease(ctx, lease)
+		}
+	}
+	for identity := range s.observed {
+		if _, ok := seen[identity]; !ok {
+			delete(s.observed, identity)
+		}
+	}
+	s.update(s.members(alive, now))
+	return renewErr
+}
+
+// members adds the replica to the members if its own Lease is valid, or removes it otherwise.
+func (s *Sharder) members(others []string, now time.Time) []string {
+	members := make([]string, 0, len(others)+1)
+	for _, member := range others {
+		if member != s.opts.Identity {
+			members = append(members, member)
+		}
+	}
+	if !s.lastRenew.IsZero() && now.Sub(s.lastRenew) < s.opts.LeaseDuration {
+		members = append(members, s.opts.Identity)
+	}
+	return members
+}
+
+// renew creates or renews the Lease of the replica.
+func (s *Sharder) renew(ctx context.Context, now time.Time) error {
+	renewTime := metav1.NewMicroTime(now)
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       ptr.To(s.opts.Identity),
+		LeaseDurationSeconds: ptr.To(int32(s.opts.LeaseDuration.Seconds())),
+		RenewTime:            &renewTime,
+	}
+	lease := &coordinationv1.Lease{}
+	err := s.reader.Get(ctx, types.NamespacedName{Namespace: s.opts.Namespace, Name: leaseName(s.opts)}, lease)
+	if apierrors.IsNotFound(err) {
+		spec.AcquireTime = &renewTime
+		return s.client.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.opts.Namespace,
+				Name:      leaseName(s.opts),
+				Labels:    map[string]string{LabelShardGroup: s.opts.Group},
+			},
+			Spec: spec,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	spec.AcquireTime = lease.Spec.AcquireTime
+	lease.Spec = spec
+	return s.client.Update(ctx, lease)
+}
+
+// deleteLease deletes the expired Lease of another replica, unless it has been renewed in the meantime.
+func (s *Sharder) deleteLease(ctx context.Context, lease *coordinationv1.Lease) {
+	err := s.client.Delete(ctx, lease, client.Preconditions{UID: &lease.UID, ResourceVersion: &lease.ResourceVersion})
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+		s.opts.Log.Error(err, "unable to delete expired shard lease", "lease", lease.Name)
+	}
+}
+
+func (s *Sharder) currentMembers() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ring.getMembers()
+}
+
+// update replaces the ring and notifies the subscribers if the members have changed.
+func (s *Sharder) update(members []string) {
+	r := newRing(members)
+	s.mu.Lock()
+	if slices.Equal(s.ring.getMembers(), r.getMembers()) {
+		s.mu.Unlock()
+		return
+	}
+	previous := s.ring.getMembers()
+	s.ring = r
+	subscribers := s.subscribers
+	s.mu.Unlock()
+
+	s.opts.Log.Info("shard members changed", "previous", previous, "members", r.getMembers(), "hashRangeRatio", r.share(s.opts.Identity))
+	membersGauge.WithLabelValues(s.opts.Identity).Set(float64(len(r.getMembers())))
+	hashRangeRatioGauge.WithLabelValues(s.opts.Identity).Set(r.share(s.opts.Identity))
+	rebalancesCounter.WithLabelValues(s.opts.Identity).Inc()
+	for _, ch := range subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// release leaves the ring and deletes the Lease of the replica.
+func (s *Sharder) release() {
+	s.mu.Lock()
+	s.ring = nil
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: s.opts.Namespace, Name: leaseName(s.opts)}}
+	if err := s.client.Delete(ctx, lease); err != nil && !apierrors.IsNotFound(err) {
+		s.opts.Log.Error(err, "unable to release shard lease")
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const testNamespace = "external-secrets"
+
+func newTestSharder(t *testing.T, c client.Client, identity string, now *time.Time) *Sharder {
+	t.Helper()
+	s, err := New(c, c, Options{
+		Namespace:     testNamespace,
+		Group:         "external-secrets-default",
+		Identity:      identity,
+		LeaseDuration: 15 * time.Second,
+		RenewInterval: 5 * time.Second,
+		Log:           logr.Discard(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := coordinationv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestNew(t *testing.T) {
+	valid := Options{Namespace: "ns", Group: "group", Identity: "pod-0", LeaseDuration: 15 * time.Second, RenewInterval: 5 * time.Second}
+	tests := []struct {
+		name   string
+		modify func(*Options)
+	}{
+		{name: "missing identity", modify: func(o *Options) { o.Identity = "" }},
+		{name: "missing namespace", modify: func(o *Options) { o.Namespace = "" }},
+		{name: "missing group", modify: func(o *Options) { o.Group = "" }},
+		{name: "invalid group", modify: func(o *Options) { o.Group = "a/b" }},
+		{name: "invalid lease name", modify: func(o *Options) { o.Identity = "Pod_0" }},
+		{name: "renew interval too long", modify: func(o *Options) { o.RenewInterval = o.LeaseDuration }},
+		{name: "renew interval not positive", modify: func(o *Options) { o.RenewInterval = 0 }},
+	}
+	if _, err := New(nil, nil, valid); err != nil {
+		t.Errorf("New() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.modify(&opts)
+			if _, err := New(nil, nil, opts); err == nil {
+				t.Error("New() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// the lease of a replica that has stopped renewing it long ago
+	stale := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "external-secrets-default-eso-old",
+			Labels:    map[string]string{LabelShardGroup: "external-secrets-default"},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To("eso-old"),
+			LeaseDurationSeconds: ptr.To[int32](15),
+			RenewTime:            ptr.To(metav1.NewMicroTime(now.Add(-time.Hour))),
+		},
+	}
+	c := newTestClient(t, stale)
+	a := newTestSharder(t, c, "eso-0", &now)
+	b := newTestSharder(t, c, "eso-1", &now)
+
+	if a.Owns("default", "db") {
+		t.Error("Owns() before the first sync = true")
+	}
+	if err := a.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"eso-0"}, a.currentMembers()); diff != "" {
+		t.Errorf("members mismatch (-want +got):\n%s", diff)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(stale), &coordinationv1.Lease{}); !apierrors.IsNotFound(err) {
+		t.Errorf("stale lease has not been deleted: %v", err)
+	}
+	if !a.Owns("default", "db") {
+		t.Error("Owns() of the only member = false")
+	}
+
+	if err := b.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"eso-0", "eso-1"}
+	for _, s := range []*Sharder{a, b} {
+		if diff := cmp.Diff(want, s.currentMembers()); diff != "" {
+			t.Errorf("members of %s mismatch (-want +got):\n%s", s.opts.Identity, diff)
+		}
+	}
+	for i := range 100 {
+		name := fmt.Sprintf("es-%d", i)
+		if a.Owns("default", name) == b.Owns("default", name) {
+			t.Errorf("default/%s is owned by %v replicas, want exactly one", name, a.Owns("default", name))
+		}
+	}
+
+	// eso-1 stops renewing its lease
+	now = now.Add(10 * time.Second)
+	if err := a.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, a.currentMembers()); diff != "" {
+		t.Errorf("members before the lease expired mismatch (-want +got):\n%s", diff)
+	}
+	now = now.Add(10 * time.Second)
+	if err := a.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"eso-0"}, a.currentMembers()); diff != "" {
+		t.Errorf("members after the lease expired mismatch (-want +got):\n%s", diff)
+	}
+
+	// eso-1 shuts down gracefully
+	b.release()
+	if b.Owns("default", "db") {
+		t.Error("Owns() after release = true")
+	}
+	lease := &coordinationv1.Lease{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "external-secrets-default-eso-1"}, lease); !apierrors.IsNotFound(err) {
+		t.Errorf("lease has not been released: %v", err)
+	}
+}
+
+func TestSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newTestClient(t)
+	a := newTestSharder(t, c, "eso-0", &now)
+	b := newTestSharder(t, c, "eso-1", &now)
+
+	names := make([]types.NamespacedName, 50)
+	for i := range names {
+		names[i] = types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("es-%d", i)}
+	}
+	list := func(context.Context) ([]types.NamespacedName, error) {
+		return names, nil
+	}
+	released := make(chan types.NamespacedName, len(names))
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+	if err := a.Source(list, func(name types.NamespacedName) { released <- name }).Start(ctx, queue); err != nil {
+		t.Fatal(err)
+	}
+
+	// a replica that has not joined the ring owns nothing
+	time.Sleep(50 * time.Millisecond)
+	if queue.Len() != 0 {
+		t.Errorf("queue length before joining = %d, want 0", queue.Len())
+	}
+
+	if err := a.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return queue.Len() == len(names) })
+	for range names {
+		req, _ := queue.Get()
+		queue.Done(req)
+	}
+
+	if err := b.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	lost := 0
+	for _, name := range names {
+		if !a.Owns(name.Namespace, name.Name) {
+			lost++
+		}
+	}
+	if lost == 0 || lost == len(names) {
+		t.Fatalf("eso-0 has handed over %d of %d ExternalSecrets", lost, len(names))
+	}
+	waitFor(t, func() bool { return len(released) == lost })
+	for range lost {
+		if name := <-released; a.Owns(name.Namespace, name.Name) {
+			t.Errorf("%s has been released, although it is still owned", name)
+		}
+	}
+	if queue.Len() != 0 {
+		t.Errorf("queue length after handing over = %d, want 0", queue.Len())
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
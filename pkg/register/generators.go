@@ -20,14 +20,18 @@ import (
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	acr "github.com/external-secrets/external-secrets/generators/v1/acr"
 	cloudsmith "github.com/external-secrets/external-secrets/generators/v1/cloudsmith"
+	cryptokey "github.com/external-secrets/external-secrets/generators/v1/cryptokey"
 	ecr "github.com/external-secrets/external-secrets/generators/v1/ecr"
 	fakegen "github.com/external-secrets/external-secrets/generators/v1/fake"
 	gcr "github.com/external-secrets/external-secrets/generators/v1/gcr"
 	githubgen "github.com/external-secrets/external-secrets/generators/v1/github"
 	grafana "github.com/external-secrets/external-secrets/generators/v1/grafana"
+	harbor "github.com/external-secrets/external-secrets/generators/v1/harbor"
 	mfa "github.com/external-secrets/external-secrets/generators/v1/mfa"
 	password "github.com/external-secrets/external-secrets/generators/v1/password"
+	pipeline "github.com/external-secrets/external-secrets/generators/v1/pipeline"
 	quay "github.com/external-secrets/external-secrets/generators/v1/quay"
+	satoken "github.com/external-secrets/external-secrets/generators/v1/serviceaccounttoken"
 	sshkey "github.com/external-secrets/external-secrets/generators/v1/sshkey"
 	sts "github.com/external-secrets/external-secrets/generators/v1/sts"
 	uuid "github.com/external-secrets/external-secrets/generators/v1/uuid"
@@ -39,14 +43,18 @@ func init() {
 	// Register all generators
 	genv1alpha1.Register(acr.Kind(), acr.NewGenerator())
 	genv1alpha1.Register(cloudsmith.Kind(), cloudsmith.NewGenerator())
+	genv1alpha1.Register(cryptokey.Kind(), cryptokey.NewGenerator())
 	genv1alpha1.Register(ecr.Kind(), ecr.NewGenerator())
 	genv1alpha1.Register(fakegen.Kind(), fakegen.NewGenerator())
 	genv1alpha1.Register(gcr.Kind(), gcr.NewGenerator())
 	genv1alpha1.Register(githubgen.Kind(), githubgen.NewGenerator())
 	genv1alpha1.Register(grafana.Kind(), grafana.NewGenerator())
+	genv1alpha1.Register(harbor.Kind(), harbor.NewGenerator())
 	genv1alpha1.Register(mfa.Kind(), mfa.NewGenerator())
 	genv1alpha1.Register(password.Kind(), password.NewGenerator())
+	genv1alpha1.Register(pipeline.Kind(), pipeline.NewGenerator())
 	genv1alpha1.Register(quay.Kind(), quay.NewGenerator())
+	genv1alpha1.Register(satoken.Kind(), satoken.NewGenerator())
 	genv1alpha1.Register(sshkey.Kind(), sshkey.NewGenerator())
 	genv1alpha1.Register(sts.Kind(), sts.NewGenerator())
 	genv1alpha1.Register(uuid.Kind(), uuid.NewGenerator())
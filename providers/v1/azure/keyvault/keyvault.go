@@ -874,6 +874,13 @@ func parseError(err error) error {
 // Retrieves a secret/Key/Certificate/Tag with the secret name defined in ref.Name
 // The Object Type is defined as a prefix in the ref.Name , if no prefix is defined , we assume a secret is required.
 func (a *Azure) GetSecret(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	data, _, err := a.GetSecretWithMetadata(ctx, ref)
+	return data, err
+}
+
+// GetSecretWithMetadata returns a single secret like GetSecret, together with the version,
+// the expiry and the creation time of the secret, certificate or key.
+func (a *Azure) GetSecretWithMetadata(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, *esv1.SecretMetadata, error) {
 	if a.useNewSDK() {
 		return a.getSecretWithNewSDK(ctx, ref)
 	}
@@ -1385,7 +1392,7 @@ func okByTags(ref esv1.ExternalSecretFind, secret keyvault.SecretItem) bool {
 }
 
 // GetSecret implementation using legacy go-autorest SDK.
-func (a *Azure) getSecretWithLegacySDK(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+func (a *Azure) getSecretWithLegacySDK(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, *esv1.SecretMetadata, error) {
 	objectType, secretName := getObjType(ref)
 
 	switch objectType {
@@ -1396,12 +1403,18 @@ func (a *Azure) getSecretWithLegacySDK(ctx context.Context, ref esv1.ExternalSec
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
 		err = parseError(err)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		metadata := &esv1.SecretMetadata{}
+		if secretResp.Attributes != nil {
+			metadata = legacyMetadata(secretResp.ID, secretResp.Attributes.Expires, secretResp.Attributes.Created)
 		}
 		if ref.MetadataPolicy == esv1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(secretResp.Tags, ref.Property)
+			data, err := getSecretTag(secretResp.Tags, ref.Property)
+			return data, metadata, err
 		}
-		return getProperty(*secretResp.Value, ref.Property, ref.Key)
+		data, err := getProperty(*secretResp.Value, ref.Property, ref.Key)
+		return data, metadata, err
 
 	case objectTypeCert:
 		// returns a CertBundle. We return CER contents of x509 certificate
@@ -1410,12 +1423,17 @@ func (a *Azure) getSecretWithLegacySDK(ctx context.Context, ref esv1.ExternalSec
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
 		err = parseError(err)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		metadata := &esv1.SecretMetadata{}
+		if certResp.Attributes != nil {
+			metadata = legacyMetadata(certResp.ID, certResp.Attributes.Expires, certResp.Attributes.Created)
 		}
 		if ref.MetadataPolicy == esv1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(certResp.Tags, ref.Property)
+			data, err := getSecretTag(certResp.Tags, ref.Property)
+			return data, metadata, err
 		}
-		return *certResp.Cer, nil
+		return *certResp.Cer, metadata, nil
 
 	case objectTypeKey:
 		// returns a KeyBundle
@@ -1424,19 +1442,41 @@ func (a *Azure) getSecretWithLegacySDK(ctx context.Context, ref esv1.ExternalSec
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKey, err)
 		err = parseError(err)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		metadata := &esv1.SecretMetadata{}
+		if keyResp.Attributes != nil && keyResp.Key != nil {
+			metadata = legacyMetadata(keyResp.Key.Kid, keyResp.Attributes.Expires, keyResp.Attributes.Created)
 		}
 		if ref.MetadataPolicy == esv1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(keyResp.Tags, ref.Property)
+			data, err := getSecretTag(keyResp.Tags, ref.Property)
+			return data, metadata, err
 		}
 		keyBytes, err := json.Marshal(keyResp.Key)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal key: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal key: %w", err)
 		}
-		return getProperty(string(keyBytes), ref.Property, ref.Key)
+		data, err := getProperty(string(keyBytes), ref.Property, ref.Key)
+		return data, metadata, err
 	}
 
-	return nil, fmt.Errorf(errUnknownObjectType, secretName)
+	return nil, nil, fmt.Errorf(errUnknownObjectType, secretName)
+}
+
+// legacyMetadata returns the metadata of an object of the legacy SDK.
+// The version is the last segment of the ID of the object.
+func legacyMetadata(id *string, expires, created *date.UnixTime) *esv1.SecretMetadata {
+	metadata := &esv1.SecretMetadata{}
+	if id != nil {
+		metadata.Version = path.Base(*id)
+	}
+	if expires != nil {
+		metadata.ExpiresAt = time.Time(*expires)
+	}
+	if created != nil {
+		metadata.LastRotatedAt = time.Time(*created)
+	}
+	return metadata
 }
 
 // NewProvider creates a new Provider instance.
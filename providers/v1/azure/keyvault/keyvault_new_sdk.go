@@ -660,7 +660,7 @@ func (a *Azure) deleteKeyVaultKeyWithNewSDK(ctx context.Context, keyName string)
 }
 
 // GetSecret implementation using new Azure SDK.
-func (a *Azure) getSecretWithNewSDK(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+func (a *Azure) getSecretWithNewSDK(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, *esv1.SecretMetadata, error) {
 	objectType, secretName := getObjType(ref)
 
 	switch objectType {
@@ -669,43 +669,72 @@ func (a *Azure) getSecretWithNewSDK(ctx context.Context, ref esv1.ExternalSecret
 		resp, err := a.secretsClient.GetSecret(ctx, secretName, ref.Version, nil)
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
 		if err != nil {
-			return nil, parseNewSDKError(err)
+			return nil, nil, parseNewSDKError(err)
+		}
+		metadata := &esv1.SecretMetadata{}
+		if resp.ID != nil && resp.Attributes != nil {
+			metadata = newSDKMetadata(resp.ID.Version(), resp.Attributes.Expires, resp.Attributes.Created)
 		}
 		if ref.MetadataPolicy == esv1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(resp.Tags, ref.Property)
+			data, err := getSecretTag(resp.Tags, ref.Property)
+			return data, metadata, err
 		}
-		return getProperty(*resp.Value, ref.Property, ref.Key)
+		data, err := getProperty(*resp.Value, ref.Property, ref.Key)
+		return data, metadata, err
 
 	case objectTypeCert:
 		// Get certificate using new SDK
 		resp, err := a.certsClient.GetCertificate(ctx, secretName, ref.Version, nil)
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
 		if err != nil {
-			return nil, parseNewSDKError(err)
+			return nil, nil, parseNewSDKError(err)
+		}
+		metadata := &esv1.SecretMetadata{}
+		if resp.ID != nil && resp.Attributes != nil {
+			metadata = newSDKMetadata(resp.ID.Version(), resp.Attributes.Expires, resp.Attributes.Created)
 		}
 		if ref.MetadataPolicy == esv1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(resp.Tags, ref.Property)
+			data, err := getSecretTag(resp.Tags, ref.Property)
+			return data, metadata, err
 		}
-		return resp.CER, nil
+		return resp.CER, metadata, nil
 
 	case objectTypeKey:
 		// Get key using new SDK
 		resp, err := a.keysClient.GetKey(ctx, secretName, ref.Version, nil)
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKey, err)
 		if err != nil {
-			return nil, parseNewSDKError(err)
+			return nil, nil, parseNewSDKError(err)
+		}
+		metadata := &esv1.SecretMetadata{}
+		if resp.Key != nil && resp.Key.KID != nil && resp.Attributes != nil {
+			metadata = newSDKMetadata(resp.Key.KID.Version(), resp.Attributes.Expires, resp.Attributes.Created)
 		}
 		if ref.MetadataPolicy == esv1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(resp.Tags, ref.Property)
+			data, err := getSecretTag(resp.Tags, ref.Property)
+			return data, metadata, err
 		}
 		keyBytes, err := json.Marshal(resp.Key)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal key: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal key: %w", err)
 		}
-		return getProperty(string(keyBytes), ref.Property, ref.Key)
+		data, err := getProperty(string(keyBytes), ref.Property, ref.Key)
+		return data, metadata, err
 	}
 
-	return nil, fmt.Errorf(errUnknownObjectType, secretName)
+	return nil, nil, fmt.Errorf(errUnknownObjectType, secretName)
+}
+
+// newSDKMetadata returns the metadata of an object of the new SDK.
+func newSDKMetadata(version string, expires, created *time.Time) *esv1.SecretMetadata {
+	metadata := &esv1.SecretMetadata{Version: version}
+	if expires != nil {
+		metadata.ExpiresAt = *expires
+	}
+	if created != nil {
+		metadata.LastRotatedAt = *created
+	}
+	return metadata
 }
 
 // secretExistsWithNewSDK checks if a secret/certificate/key exists in Azure Key Vault using the new SDK.
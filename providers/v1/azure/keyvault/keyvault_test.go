@@ -1373,6 +1373,59 @@ func TestAzureKeyVaultSecretManagerGetSecret(t *testing.T) {
 	}
 }
 
+func TestAzureKeyVaultSecretManagerGetSecretWithMetadata(t *testing.T) {
+	secretString := "changedvalue"
+	certificate := []byte("certificate_value")
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	setSecretWithAttributes := func(smtc *secretManagerTestCase) {
+		smtc.expectedSecret = secretString
+		smtc.secretOutput = keyvault.SecretBundle{
+			ID:    pointer.To(fakeURL + "secrets/MySecret/v2"),
+			Value: &secretString,
+			Attributes: &keyvault.SecretAttributes{
+				Expires: pointer.To(date.UnixTime(expires)),
+				Created: pointer.To(date.UnixTime(created)),
+			},
+		}
+	}
+	setCertWithAttributes := func(smtc *secretManagerTestCase) {
+		smtc.expectedSecret = string(certificate)
+		smtc.secretName = certName
+		smtc.certOutput = keyvault.CertificateBundle{
+			ID:  pointer.To(fakeURL + "certificates/certname/v2"),
+			Cer: &certificate,
+			Attributes: &keyvault.CertificateAttributes{
+				Expires: pointer.To(date.UnixTime(expires)),
+				Created: pointer.To(date.UnixTime(created)),
+			},
+		}
+		smtc.ref.Key = certName
+	}
+
+	sm := Azure{
+		provider: &esv1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+	}
+	want := &esv1.SecretMetadata{Version: "v2", ExpiresAt: expires, LastRotatedAt: created}
+	for k, v := range []*secretManagerTestCase{
+		makeValidSecretManagerTestCaseCustom(setSecretWithAttributes),
+		makeValidSecretManagerTestCaseCustom(setCertWithAttributes),
+	} {
+		sm.baseClient = v.mockClient
+		out, metadata, err := sm.GetSecretWithMetadata(context.Background(), *v.ref)
+		if err != nil {
+			t.Errorf("[%d] unexpected error: %v", k, err)
+		}
+		if string(out) != v.expectedSecret {
+			t.Errorf("[%d] unexpected secret: expected %s, got %s", k, v.expectedSecret, string(out))
+		}
+		if !reflect.DeepEqual(metadata, want) {
+			t.Errorf("[%d] unexpected metadata: expected %+v, got %+v", k, want, metadata)
+		}
+	}
+}
+
 func TestAzureKeyVaultSecretManagerGetSecretMap(t *testing.T) {
 	secretString := "changedvalue"
 	secretCertificate := "certificate_value"
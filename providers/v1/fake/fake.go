@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
@@ -48,9 +49,10 @@ const (
 )
 
 type Data struct {
-	Value   string
-	Version string
-	Origin  SourceOrigin
+	Value     string
+	Version   string
+	ExpiresAt time.Time
+	Origin    SourceOrigin
 }
 type Config map[string]*Data
 type Provider struct {
@@ -90,6 +92,9 @@ func (p *Provider) NewClient(_ context.Context, store esv1.GenericStore, _ clien
 			Version: data.Version,
 			Origin:  FakeSecretStore,
 		}
+		if data.ExpiresAt != nil {
+			cfg[key].ExpiresAt = data.ExpiresAt.Time
+		}
 	}
 	p.database[store.GetName()] = cfg
 	return &Provider{
@@ -177,22 +182,32 @@ func (p *Provider) updateLatestVersion(originalKey string, data *Data, latestVer
 }
 
 // GetSecret returns a single secret from the provider.
-func (p *Provider) GetSecret(_ context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+func (p *Provider) GetSecret(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	val, _, err := p.GetSecretWithMetadata(ctx, ref)
+	return val, err
+}
+
+// GetSecretWithMetadata returns a single secret from the provider, together with its version and expiry.
+func (p *Provider) GetSecretWithMetadata(_ context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, *esv1.SecretMetadata, error) {
 	data, ok := p.config[mapKey(ref.Key, ref.Version)]
 	if !ok || data.Version != ref.Version {
-		return nil, esv1.NoSecretErr
+		return nil, nil, esv1.NoSecretErr
+	}
+	metadata := &esv1.SecretMetadata{
+		Version:   data.Version,
+		ExpiresAt: data.ExpiresAt,
 	}
 
 	if ref.Property != "" {
 		val := gjson.Get(data.Value, ref.Property)
 		if !val.Exists() {
-			return nil, esv1.NoSecretErr
+			return nil, nil, esv1.NoSecretErr
 		}
 
-		return []byte(val.String()), nil
+		return []byte(val.String()), metadata, nil
 	}
 
-	return []byte(data.Value), nil
+	return []byte(data.Value), metadata, nil
 }
 
 // GetSecretMap returns multiple k/v pairs from the provider.
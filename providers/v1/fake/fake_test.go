@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/onsi/gomega"
@@ -325,6 +326,42 @@ func TestGetSecret(t *testing.T) {
 	}
 }
 
+func TestGetSecretWithMetadata(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	expiresAt := metav1.NewTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	cl, err := p.NewClient(context.Background(), &esv1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "secret-store-metadata",
+		},
+		Spec: esv1.SecretStoreSpec{
+			Provider: &esv1.SecretStoreProvider{
+				Fake: &esv1.FakeProvider{
+					Data: []esv1.FakeProviderData{
+						{Key: "/foo", Value: `{"p1":"bar"}`, Version: "v1", ExpiresAt: &expiresAt},
+						{Key: "/bar", Value: "baz"},
+					},
+				},
+			},
+		},
+	}, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	getter, ok := cl.(esv1.SecretMetadataGetter)
+	gomega.Expect(ok).To(gomega.BeTrue())
+
+	out, metadata, err := getter.GetSecretWithMetadata(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: "/foo", Property: "p1", Version: "v1"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(string(out)).To(gomega.Equal("bar"))
+	gomega.Expect(metadata).To(gomega.Equal(&esv1.SecretMetadata{Version: "v1", ExpiresAt: expiresAt.Time}))
+
+	_, metadata, err = getter.GetSecretWithMetadata(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: "/bar"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(metadata.ExpiresAt.IsZero()).To(gomega.BeTrue())
+
+	_, _, err = getter.GetSecretWithMetadata(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: "/missing"})
+	gomega.Expect(err).To(gomega.MatchError(esv1.NoSecretErr))
+}
+
 type setSecretTestCase struct {
 	name       string
 	input      []esv1.FakeProviderData
@@ -94,6 +94,13 @@ type Client struct {
 	// namespace of the external secret
 	namespace        string
 	workloadIdentity *workloadIdentity
+
+	// credentialsExpireAt is the time the token of the client
+	// expires, if it is not refreshed.
+	credentialsExpireAt time.Time
+
+	// locked is true while the client holds useMu.
+	locked bool
 }
 
 // GoogleSecretManagerClient defines the interface for interacting with Google Secret Manager.
@@ -643,6 +650,12 @@ func (c *Client) GetSecretMap(ctx context.Context, ref esv1.ExternalSecretDataRe
 	return secretData, nil
 }
 
+// CredentialsExpireAt implements esv1.CredentialsExpirer. Tokens of
+// workload identity are requested once and not refreshed.
+func (c *Client) CredentialsExpireAt() time.Time {
+	return c.credentialsExpireAt
+}
+
 // Close closes the Google Cloud Secret Manager client connection.
 func (c *Client) Close(_ context.Context) error {
 	var err error
@@ -652,7 +665,10 @@ func (c *Client) Close(_ context.Context) error {
 	if c.workloadIdentity != nil {
 		err = c.workloadIdentity.Close()
 	}
-	useMu.Unlock()
+	if c.locked {
+		c.locked = false
+		useMu.Unlock()
+	}
 	if err != nil {
 		return fmt.Errorf(errClientClose, err)
 	}
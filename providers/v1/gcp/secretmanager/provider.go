@@ -39,6 +39,8 @@ type Provider struct{}
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1.SecretsClient = &Client{}
 var _ esv1.Provider = &Provider{}
+var _ esv1.CredentialsExpirer = &Client{}
+var _ esv1.PoolableClientProvider = &Provider{}
 
 /*
 Currently, GCPSM client has a limitation around how concurrent connections work
@@ -46,9 +48,17 @@ This limitation causes memory leaks due to random disconnects from living client
 and also payload switches when sending a call (such as using a credential from one
 thread to ask secrets from another thread).
 A Mutex was implemented to make sure only one connection can be in place at a time.
+Pooled clients are not created per reconcile and do not hold the mutex.
 */
 var useMu = sync.Mutex{}
 
+// PoolableClients implements esv1.PoolableClientProvider. A pooled client is
+// created once for all reconciles of its store, and clients that authenticate
+// with a token that is not refreshed report when it expires.
+func (p *Provider) PoolableClients() bool {
+	return true
+}
+
 // Capabilities returns the provider's capabilities to read/write secrets.
 func (p *Provider) Capabilities() esv1.SecretStoreCapabilities {
 	return esv1.SecretStoreReadWrite
@@ -62,14 +72,16 @@ func (p *Provider) NewClient(ctx context.Context, store esv1.GenericStore, kube
 	}
 	gcpStore := storeSpec.Provider.GCPSM
 
-	useMu.Lock()
-
 	client := &Client{
 		kube:      kube,
 		store:     gcpStore,
 		storeKind: store.GetKind(),
 		namespace: namespace,
 	}
+	if !esv1.IsPooledClient(ctx) {
+		useMu.Lock()
+		client.locked = true
+	}
 	defer func() {
 		if client.smClient == nil {
 			_ = client.Close(ctx)
@@ -96,10 +108,13 @@ func (p *Provider) NewClient(ctx context.Context, store esv1.GenericStore, kube
 	}
 
 	// check if we can get credentials
-	_, err = ts.Token()
+	token, err := ts.Token()
 	if err != nil {
 		return nil, fmt.Errorf(errUnableGetCredentials, err)
 	}
+	if staticToken(gcpStore.Auth) {
+		client.credentialsExpireAt = token.Expiry
+	}
 
 	var clientGCPSM *secretmanager.Client
 	if gcpStore.Location != "" {
@@ -158,6 +173,12 @@ func clusterProjectID(spec *esv1.SecretStoreSpec) (string, error) {
 	return "", errors.New(errNoProjectID)
 }
 
+// staticToken returns true if the token of the auth is not refreshed,
+// which is the case for workload identity.
+func staticToken(auth esv1.GCPSMAuth) bool {
+	return auth.SecretRef == nil && auth.WorkloadIdentity != nil
+}
+
 func isReferentSpec(prov *esv1.GCPSMProvider) bool {
 	if prov.Auth.SecretRef != nil &&
 		prov.Auth.SecretRef.SecretAccessKey.Namespace == nil {
@@ -0,0 +1,79 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestNewClientMutex(t *testing.T) {
+	// a referent ClusterSecretStore without namespace creates a placeholder client,
+	// which needs no credentials.
+	store := &esv1.ClusterSecretStore{
+		TypeMeta: metav1.TypeMeta{Kind: esv1.ClusterSecretStoreKind},
+		Spec: esv1.SecretStoreSpec{Provider: &esv1.SecretStoreProvider{GCPSM: &esv1.GCPSMProvider{
+			ProjectID: "project",
+			Auth: esv1.GCPSMAuth{SecretRef: &esv1.GCPSMAuthSecretRef{
+				SecretAccessKey: esmeta.SecretKeySelector{Name: "gcp", Key: "key.json"},
+			}},
+		}}},
+	}
+	p := &Provider{}
+	newClient := func(ctx context.Context) <-chan esv1.SecretsClient {
+		created := make(chan esv1.SecretsClient, 1)
+		go func() {
+			c, err := p.NewClient(ctx, store, nil, "")
+			assert.NoError(t, err)
+			created <- c
+		}()
+		return created
+	}
+
+	// clients of a single reconcile are used one at a time.
+	first := <-newClient(context.Background())
+	second := newClient(context.Background())
+	select {
+	case <-second:
+		t.Fatal("NewClient() must wait until the previous client is closed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// pooled clients do not take the mutex.
+	pooled := []esv1.SecretsClient{<-newClient(esv1.WithPooledClient(context.Background())), <-newClient(esv1.WithPooledClient(context.Background()))}
+
+	require.NoError(t, first.Close(context.Background()))
+	// closing a client twice must not unlock the mutex twice.
+	_ = first.Close(context.Background())
+	select {
+	case c := <-second:
+		require.NoError(t, c.Close(context.Background()))
+	case <-time.After(5 * time.Second):
+		t.Fatal("NewClient() must continue once the previous client is closed")
+	}
+	for _, c := range pooled {
+		require.NoError(t, c.Close(context.Background()))
+	}
+}
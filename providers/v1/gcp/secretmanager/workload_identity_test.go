@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/iam/credentials/apiv1/credentialspb"
 	"github.com/googleapis/gax-go/v2"
@@ -223,6 +224,7 @@ func TestIDBTokenGen(t *testing.T) {
 
 		bt, err := json.Marshal(&oauth2.Token{
 			AccessToken: "12345",
+			ExpiresIn:   3600,
 		})
 		assert.Nil(t, err)
 		rw.WriteHeader(http.StatusOK)
@@ -235,6 +237,7 @@ func TestIDBTokenGen(t *testing.T) {
 	token, err := gen.Generate(context.Background(), http.DefaultClient, "some-token", "some-idpool", "some-id-provider")
 	assert.Nil(t, err)
 	assert.Equal(t, token.AccessToken, "12345")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute, "the token is not refreshed, so its expiry is reported")
 }
 
 type testCaseMutator func(tc *workloadIdentityTest)
@@ -0,0 +1,409 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/runtime/find"
+)
+
+const (
+	errReadOnly           = "the git provider is read-only"
+	errVersionUnsupported = "remoteRef.version is not supported by the git provider"
+	errTagsUnsupported    = "find.tags is not supported by the git provider"
+	errFileNotFound       = "file %q not found: %w"
+	errPropertyNotFound   = "property %q not found in file %q"
+	errPropertyStructured = "property is only supported for YAML and JSON files, got %q"
+	errNotAMapping        = "value of %q in file %q is not a map"
+	errParseDocument      = "unable to parse file %q: %w"
+	errInvalidKey         = "key %q must not contain \".\" or \"..\" path segments"
+)
+
+// Client reads secrets from the files of a Git repository.
+type Client struct {
+	remote          *remote
+	path            string
+	refreshInterval time.Duration
+	keys            *sopsKeys
+}
+
+var _ esv1.SecretsClient = &Client{}
+
+// GetSecret returns the decrypted content of the file referenced by ref.Key.
+// If ref.Property is set, it is used as dotted path into the decrypted YAML or JSON document.
+func (c *Client) GetSecret(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.Version != "" {
+		return nil, errors.New(errVersionUnsupported)
+	}
+	tree, err := c.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := c.readFile(tree, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return doc.bytes()
+	}
+	node, err := doc.lookup(ref.Property)
+	if err != nil {
+		return nil, err
+	}
+	return nodeBytes(node)
+}
+
+// GetSecretMap returns the top level keys of the decrypted YAML or JSON
+// document referenced by ref.Key, or the keys of the map at ref.Property.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Version != "" {
+		return nil, errors.New(errVersionUnsupported)
+	}
+	tree, err := c.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := c.readFile(tree, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	node, err := doc.lookup(ref.Property)
+	if err != nil {
+		return nil, err
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf(errNotAMapping, ref.Property, ref.Key)
+	}
+	secretData := make(map[string][]byte, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value, err := nodeBytes(node.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+		secretData[node.Content[i].Value] = value
+	}
+	return secretData, nil
+}
+
+// GetAllSecrets returns the decrypted content of all files that match the
+// glob pattern of find.path and the regular expression of find.name.
+// The keys of the returned map are the file paths relative to the store path.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errors.New(errTagsUnsupported)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		if matcher, err = find.New(*ref.Name); err != nil {
+			return nil, err
+		}
+	}
+	tree, err := c.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.path != "" {
+		if tree, err = tree.Tree(c.path); err != nil {
+			return nil, fmt.Errorf(errFileNotFound, c.path, err)
+		}
+	}
+
+	secretData := make(map[string][]byte)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if ref.Path != nil && !matchGlob(*ref.Path, f.Name) {
+			return nil
+		}
+		if matcher != nil && !matcher.MatchName(f.Name) {
+			return nil
+		}
+		doc, err := c.parseFile(f)
+		if err != nil {
+			return err
+		}
+		value, err := doc.bytes()
+		if err != nil {
+			return err
+		}
+		secretData[f.Name] = value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return secretData, nil
+}
+
+// PushSecret is not supported, the repository is only read.
+func (c *Client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1.PushSecretData) error {
+	return errors.New(errReadOnly)
+}
+
+// DeleteSecret is not supported, the repository is only read.
+func (c *Client) DeleteSecret(_ context.Context, _ esv1.PushSecretRemoteRef) error {
+	return errors.New(errReadOnly)
+}
+
+// SecretExists is not supported, the repository is only read.
+func (c *Client) SecretExists(_ context.Context, _ esv1.PushSecretRemoteRef) (bool, error) {
+	return false, errors.New(errReadOnly)
+}
+
+// Validate checks that the configured ref can be fetched from the repository.
+func (c *Client) Validate() (esv1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := c.tree(ctx); err != nil {
+		return esv1.ValidationResultError, err
+	}
+	return esv1.ValidationResultReady, nil
+}
+
+// Close is a no-op, clones are cached independently of the client.
+func (c *Client) Close(_ context.Context) error {
+	return nil
+}
+
+func (c *Client) tree(ctx context.Context) (*object.Tree, error) {
+	return repositories.get(c.remote.cacheKey()).files(ctx, c.remote, c.refreshInterval)
+}
+
+func (c *Client) readFile(tree *object.Tree, key string) (*document, error) {
+	// path.Join resolves ".." segments, which would allow keys to leave the store path.
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return nil, fmt.Errorf(errInvalidKey, key)
+		}
+	}
+	name := path.Join(c.path, strings.TrimPrefix(key, "/"))
+	f, err := tree.File(name)
+	if err != nil {
+		return nil, fmt.Errorf(errFileNotFound, key, err)
+	}
+	return c.parseFile(f)
+}
+
+// document is a file of the repository. Files that are encrypted with SOPS
+// are decrypted, YAML and JSON files are parsed as well.
+type document struct {
+	name    string
+	format  string
+	content []byte
+	root    *yaml.Node
+}
+
+const (
+	formatYAML   = "yaml"
+	formatJSON   = "json"
+	formatDotenv = "dotenv"
+	formatINI    = "ini"
+	formatBinary = "binary"
+)
+
+// parseFile reads and decrypts the file. Like SOPS, the format of the file is
+// derived from its extension. Other files are treated as SOPS binary files
+// if they contain SOPS metadata, and returned as is otherwise.
+func (c *Client) parseFile(f *object.File) (*document, error) {
+	content, err := fileContents(f)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{name: f.Name, content: content}
+
+	var encrypted bool
+	switch strings.ToLower(path.Ext(f.Name)) {
+	case ".yaml", ".yml":
+		doc.format = formatYAML
+	case ".json":
+		doc.format = formatJSON
+	case ".env":
+		doc.format = formatDotenv
+		encrypted = bytes.Contains(content, []byte("sops_mac="))
+	case ".ini":
+		doc.format = formatINI
+		encrypted = bytes.Contains(content, []byte("[sops]"))
+	default:
+		doc.format = formatBinary
+		var probe map[string]json.RawMessage
+		encrypted = json.Unmarshal(content, &probe) == nil && probe[sopsMetadataKey] != nil && probe["data"] != nil
+	}
+
+	structured := doc.format == formatYAML || doc.format == formatJSON
+	if structured {
+		if doc.root, err = parseRoot(f.Name, content); err != nil {
+			return nil, err
+		}
+		encrypted = isSOPSDocument(doc.root)
+	}
+	if !encrypted {
+		return doc, nil
+	}
+	if doc.content, err = sopsDecrypt(content, doc.format, c.keys); err != nil {
+		return nil, fmt.Errorf("unable to decrypt file %q: %w", f.Name, err)
+	}
+	if structured {
+		if doc.root, err = parseRoot(f.Name, doc.content); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// parseRoot parses the YAML or JSON content and returns its root node, or nil
+// if the content is empty.
+func parseRoot(name string, content []byte) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf(errParseDocument, name, err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	return root.Content[0], nil
+}
+
+// bytes returns the decrypted content of the whole document in its original format.
+func (d *document) bytes() ([]byte, error) {
+	return d.content, nil
+}
+
+// lookup returns the node at the dotted path. Dots that are part of a key can
+// be escaped with a backslash, sequence items are addressed by their index.
+func (d *document) lookup(property string) (*yaml.Node, error) {
+	if d.root == nil {
+		return nil, fmt.Errorf(errPropertyStructured, d.name)
+	}
+	node := d.root
+	if property == "" {
+		return node, nil
+	}
+	for _, segment := range splitProperty(property) {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if idx, err := strconv.Atoi(segment); err == nil && idx >= 0 && idx < len(node.Content) {
+				next = node.Content[idx]
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf(errPropertyNotFound, property, d.name)
+		}
+		node = next
+	}
+	return node, nil
+}
+
+// nodeBytes returns scalars as their plain value and maps and lists as JSON.
+func nodeBytes(node *yaml.Node) ([]byte, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return []byte{}, nil
+		}
+		return []byte(node.Value), nil
+	default:
+		return nodeJSON(node)
+	}
+}
+
+func nodeJSON(node *yaml.Node) ([]byte, error) {
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+func splitProperty(property string) []string {
+	var (
+		segments []string
+		current  strings.Builder
+	)
+	for i := 0; i < len(property); i++ {
+		switch {
+		case property[i] == '\\' && i+1 < len(property) && property[i+1] == '.':
+			current.WriteByte('.')
+			i++
+		case property[i] == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(property[i])
+		}
+	}
+	return append(segments, current.String())
+}
+
+// matchGlob matches a slash separated name against a glob pattern. In
+// addition to the syntax of path.Match, "**" matches any number of directories.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+func fileContents(f *object.File) ([]byte, error) {
+	r, err := f.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return io.ReadAll(r)
+}
@@ -0,0 +1,442 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const plainConfig = "replicas: 3\n"
+
+// testRepository is a bare repository on the local filesystem that is
+// populated from a work tree, so it can be cloned like a remote repository.
+type testRepository struct {
+	t    *testing.T
+	url  string
+	work *git.Repository
+	dir  string
+}
+
+func newTestRepository(t *testing.T) *testRepository {
+	t.Helper()
+	// the file transport of go-git runs git-upload-pack.
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	bare := filepath.Join(t.TempDir(), "remote.git")
+	if _, err := git.PlainInit(bare, true); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	work, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := work.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{bare}}); err != nil {
+		t.Fatal(err)
+	}
+	repo := &testRepository{t: t, url: bare, work: work, dir: dir}
+	repo.commit(map[string][]byte{
+		"secrets.yaml":               readTestdata(t, "secrets.yaml"),
+		"notes.txt":                  readTestdata(t, "notes.txt"),
+		"apps/app1/secrets.json":     readTestdata(t, "secrets.json"),
+		"apps/app2/config.yaml":      []byte(plainConfig),
+		"apps/app2/nested/notes.txt": readTestdata(t, "notes.txt"),
+	})
+	return repo
+}
+
+// commit writes the files to the work tree, commits and pushes them.
+func (r *testRepository) commit(files map[string][]byte) {
+	r.t.Helper()
+	wt, err := r.work.Worktree()
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			r.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			r.t.Fatal(err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+	_, err = wt.Commit("update", &git.CommitOptions{
+		Author: &object.Signature{Name: "eso", Email: "eso@example.com", When: time.Now()},
+	})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	r.push()
+}
+
+func (r *testRepository) tag(name string) {
+	r.t.Helper()
+	head, err := r.work.Head()
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	_, err = r.work.CreateTag(name, head.Hash(), &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "eso", Email: "eso@example.com", When: time.Now()},
+		Message: name,
+	})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	r.push()
+}
+
+func (r *testRepository) push() {
+	r.t.Helper()
+	err := r.work.Push(&git.PushOptions{
+		RefSpecs: []config.RefSpec{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		r.t.Fatal(err)
+	}
+}
+
+func newTestClient(t *testing.T, provider *esv1.GitProvider) esv1.SecretsClient {
+	t.Helper()
+	provider.SOPS = &esv1.GitSOPS{
+		AgeSecretRef: &esmeta.SecretKeySelector{Name: "sops", Key: "age.key"},
+	}
+	store := &esv1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "git", Namespace: "default"},
+		Spec: esv1.SecretStoreSpec{
+			Provider: &esv1.SecretStoreProvider{Git: provider},
+		},
+	}
+	kube := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sops", Namespace: "default"},
+		Data:       map[string][]byte{"age.key": readTestdata(t, "age.key")},
+	}).Build()
+	c, err := NewProvider().NewClient(context.Background(), store, kube, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestGetSecret(t *testing.T) {
+	repo := newTestRepository(t)
+	c := newTestClient(t, &esv1.GitProvider{URL: repo.url})
+
+	tests := []struct {
+		name    string
+		ref     esv1.ExternalSecretDataRemoteRef
+		want    string
+		wantErr string
+	}{
+		{
+			name: "property",
+			ref:  esv1.ExternalSecretDataRemoteRef{Key: "secrets.yaml", Property: "database.password"},
+			want: "s3cr3t",
+		},
+		{
+			name: "typed property",
+			ref:  esv1.ExternalSecretDataRemoteRef{Key: "secrets.yaml", Property: "database.port"},
+			want: "5432",
+		},
+		{
+			name: "list item",
+			ref:  esv1.ExternalSecretDataRemoteRef{Key: "secrets.yaml", Property: "hosts.1"},
+			want: "b.example.com",
+		},
+		{
+			name: "map property",
+			ref:  esv1.ExternalSecretDataRemoteRef{Key: "secrets.yaml", Property: "database"},
+			want: `{"password":"s3cr3t","port":5432,"username":"admin"}`,
+		},
+		{
+			name: "json file",
+			ref:  esv1.ExternalSecretDataRemoteRef{Key: "apps/app1/secrets.json"},
+			// decrypted like `sops -d` does, the key order of the file is kept
+			want: "{\n\t\"username\": \"json-user\",\n\t\"password\": \"json-pass\",\n\t\"nested\": {\n\t\t\"key\": \"value\"\n\t}\n}\n",
+		},
+		{
+			name: "binary file",
+			ref:  esv1.ExternalSecretDataRemoteRef{Key: "notes.txt"},
+			want: "line one\nline two\n",
+		},
+		{
+			name: "unencrypted file",
+			ref:  esv1.ExternalSecretDataRemoteRef{Key: "/apps/app2/config.yaml"},
+			want: plainConfig,
+		},
+		{
+			name:    "property of binary file",
+			ref:     esv1.ExternalSecretDataRemoteRef{Key: "notes.txt", Property: "data"},
+			wantErr: "property is only supported for YAML and JSON files",
+		},
+		{
+			name:    "missing property",
+			ref:     esv1.ExternalSecretDataRemoteRef{Key: "secrets.yaml", Property: "database.missing"},
+			wantErr: `property "database.missing" not found`,
+		},
+		{
+			name:    "missing file",
+			ref:     esv1.ExternalSecretDataRemoteRef{Key: "missing.yaml"},
+			wantErr: `file "missing.yaml" not found`,
+		},
+		{
+			name:    "version",
+			ref:     esv1.ExternalSecretDataRemoteRef{Key: "secrets.yaml", Version: "1"},
+			wantErr: errVersionUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetSecret() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetSecret() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("GetSecret() = diff:\n%s", diff)
+			}
+		})
+	}
+
+	whole, err := c.GetSecret(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: "secrets.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(whole), "password: s3cr3t") || strings.Contains(string(whole), "ENC[") {
+		t.Errorf("GetSecret() expected the decrypted document, got:\n%s", whole)
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	repo := newTestRepository(t)
+	c := newTestClient(t, &esv1.GitProvider{URL: repo.url, Path: "apps"})
+
+	got, err := c.GetSecretMap(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: "app1/secrets.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]byte{
+		"username": []byte("json-user"),
+		"password": []byte("json-pass"),
+		"nested":   []byte(`{"key":"value"}`),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetSecretMap() = diff:\n%s", diff)
+	}
+
+	got, err = c.GetSecretMap(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: "app1/secrets.json", Property: "nested"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string][]byte{"key": []byte("value")}, got); diff != "" {
+		t.Errorf("GetSecretMap() = diff:\n%s", diff)
+	}
+
+	if _, err := c.GetSecretMap(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: "app1/secrets.json", Property: "username"}); err == nil {
+		t.Error("GetSecretMap() expected error for scalar property")
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	repo := newTestRepository(t)
+	c := newTestClient(t, &esv1.GitProvider{URL: repo.url, Path: "apps"})
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name string
+		find esv1.ExternalSecretFind
+		want []string
+	}{
+		{
+			name: "all files",
+			want: []string{"app1/secrets.json", "app2/config.yaml", "app2/nested/notes.txt"},
+		},
+		{
+			name: "glob",
+			find: esv1.ExternalSecretFind{Path: ptr("*/*.yaml")},
+			want: []string{"app2/config.yaml"},
+		},
+		{
+			name: "double star glob",
+			find: esv1.ExternalSecretFind{Path: ptr("app2/**")},
+			want: []string{"app2/config.yaml", "app2/nested/notes.txt"},
+		},
+		{
+			name: "glob and name",
+			find: esv1.ExternalSecretFind{Path: ptr("**/*"), Name: &esv1.FindName{RegExp: `\.json$`}},
+			want: []string{"app1/secrets.json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetAllSecrets(context.Background(), tt.find)
+			if err != nil {
+				t.Fatal(err)
+			}
+			keys := make([]string, 0, len(got))
+			for k := range got {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			if diff := cmp.Diff(tt.want, keys); diff != "" {
+				t.Errorf("GetAllSecrets() keys = diff:\n%s", diff)
+			}
+		})
+	}
+
+	got, err := c.GetAllSecrets(context.Background(), esv1.ExternalSecretFind{Path: ptr("app2/nested/*")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got["app2/nested/notes.txt"]) != "line one\nline two\n" {
+		t.Errorf("GetAllSecrets() = %q, want decrypted content", got["app2/nested/notes.txt"])
+	}
+
+	if _, err := c.GetAllSecrets(context.Background(), esv1.ExternalSecretFind{Tags: map[string]string{"a": "b"}}); err == nil {
+		t.Error("GetAllSecrets() expected error for tags")
+	}
+}
+
+func TestCloneCache(t *testing.T) {
+	repo := newTestRepository(t)
+	repo.tag("v1")
+	cached := newTestClient(t, &esv1.GitProvider{URL: repo.url, RefreshInterval: &metav1.Duration{Duration: time.Hour}})
+	ref := esv1.ExternalSecretDataRemoteRef{Key: "apps/app2/config.yaml"}
+	assertSecret := func(c esv1.SecretsClient, want string) {
+		t.Helper()
+		got, err := c.GetSecret(context.Background(), ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("GetSecret() = %q, want %q", got, want)
+		}
+	}
+	assertSecret(cached, plainConfig)
+
+	repo.commit(map[string][]byte{"apps/app2/config.yaml": []byte("replicas: 5\n")})
+	assertSecret(cached, plainConfig)
+
+	// a client without refresh interval fetches the ref again and updates
+	// the clone that is shared with the first client.
+	assertSecret(newTestClient(t, &esv1.GitProvider{URL: repo.url, RefreshInterval: &metav1.Duration{}}), "replicas: 5\n")
+	assertSecret(cached, "replicas: 5\n")
+
+	assertSecret(newTestClient(t, &esv1.GitProvider{URL: repo.url, Ref: "v1"}), plainConfig)
+	assertSecret(newTestClient(t, &esv1.GitProvider{URL: repo.url, Ref: "refs/heads/master"}), "replicas: 5\n")
+
+	c := newTestClient(t, &esv1.GitProvider{URL: repo.url, Ref: "missing"})
+	if _, err := c.Validate(); err == nil {
+		t.Error("Validate() expected error for missing ref")
+	}
+}
+
+func TestConcurrentReads(t *testing.T) {
+	repo := newTestRepository(t)
+	provider := &esv1.GitProvider{URL: repo.url, RefreshInterval: &metav1.Duration{Duration: time.Hour}}
+	keys := []string{"secrets.yaml", "notes.txt", "apps/app1/secrets.json", "apps/app2/config.yaml", "apps/app2/nested/notes.txt"}
+	clients := make([]*Client, 2*len(keys))
+	for i := range clients {
+		clients[i] = newTestClient(t, provider).(*Client)
+	}
+
+	// every goroutine gets the tree of the same fresh clone first, and only
+	// then all of them read from their tree at once.
+	var fetched, done sync.WaitGroup
+	fetched.Add(len(clients))
+	errs := make(chan error, len(clients))
+	for i, c := range clients {
+		key := keys[i%len(keys)]
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			tree, err := c.tree(context.Background())
+			fetched.Done()
+			if err != nil {
+				errs <- err
+				return
+			}
+			fetched.Wait()
+			if _, err := c.readFile(tree, key); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	done.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestPathTraversal(t *testing.T) {
+	repo := newTestRepository(t)
+	c := newTestClient(t, &esv1.GitProvider{URL: repo.url, Path: "apps"})
+	ptr := func(s string) *string { return &s }
+
+	for _, key := range []string{"../secrets.yaml", "app1/../../secrets.yaml", "/../notes.txt", "./app1/secrets.json"} {
+		t.Run(key, func(t *testing.T) {
+			if _, err := c.GetSecret(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: key}); err == nil || !strings.Contains(err.Error(), "path segments") {
+				t.Errorf("GetSecret() error = %v, want invalid key", err)
+			}
+			if _, err := c.GetSecretMap(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: key}); err == nil || !strings.Contains(err.Error(), "path segments") {
+				t.Errorf("GetSecretMap() error = %v, want invalid key", err)
+			}
+		})
+	}
+
+	for _, pattern := range []string{"../*", "../**", "app1/../../*"} {
+		t.Run(pattern, func(t *testing.T) {
+			got, err := c.GetAllSecrets(context.Background(), esv1.ExternalSecretFind{Path: ptr(pattern)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 0 {
+				t.Errorf("GetAllSecrets() returned files outside of the store path: %v", slices.Collect(maps.Keys(got)))
+			}
+		})
+	}
+}
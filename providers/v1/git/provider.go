@@ -0,0 +1,266 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package git implements a read-only provider for files of a Git repository,
+// decrypting files that are encrypted with SOPS.
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	gossh "golang.org/x/crypto/ssh"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+const (
+	defaultRefreshInterval = time.Minute
+	defaultUser            = "git"
+
+	errNilStore         = "nil store"
+	errMissingProvider  = "missing: spec.provider.git"
+	errMissingURL       = "missing: spec.provider.git.url"
+	errInvalidURL       = "invalid spec.provider.git.url: %w"
+	errInvalidPath      = "spec.provider.git.path must be relative and must not leave the repository"
+	errAuthExclusive    = "only one of spec.provider.git.auth.basic or spec.provider.git.auth.ssh may be set"
+	errMissingSOPSKey   = "one of spec.provider.git.sops.ageSecretRef or spec.provider.git.sops.pgpSecretRef must be set"
+	errInvalidSecretRef = "invalid %s: %w"
+	errKnownHosts       = "unable to parse known hosts: %w"
+)
+
+type namedSecretRef struct {
+	name string
+	ref  *esmeta.SecretKeySelector
+}
+
+// Provider creates clients for Git repositories.
+type Provider struct{}
+
+var _ esv1.Provider = &Provider{}
+
+// NewClient resolves the credentials and decryption keys of the store and returns a client for it.
+func (p *Provider) NewClient(ctx context.Context, store esv1.GenericStore, kube client.Client, namespace string) (esv1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(ref *esmeta.SecretKeySelector) (string, error) {
+		if ref == nil {
+			return "", nil
+		}
+		return resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, ref)
+	}
+
+	rem := &remote{
+		url: provider.URL,
+		ref: provider.Ref,
+	}
+	if provider.Auth != nil {
+		if rem.auth, rem.credentials, err = newAuth(provider.Auth, resolve); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &Client{
+		remote:          rem,
+		path:            strings.Trim(provider.Path, "/"),
+		refreshInterval: defaultRefreshInterval,
+	}
+	if provider.RefreshInterval != nil {
+		c.refreshInterval = provider.RefreshInterval.Duration
+	}
+	if provider.SOPS != nil {
+		c.keys = &sopsKeys{}
+		if c.keys.age, err = resolve(provider.SOPS.AgeSecretRef); err != nil {
+			return nil, err
+		}
+		if c.keys.pgp, err = resolve(provider.SOPS.PGPSecretRef); err != nil {
+			return nil, err
+		}
+		if c.keys.pgpPassphrase, err = resolve(provider.SOPS.PGPPassphraseSecretRef); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// newAuth returns the transport auth method of the store and a string that
+// identifies the credentials in the clone cache.
+func newAuth(auth *esv1.GitAuth, resolve func(*esmeta.SecretKeySelector) (string, error)) (transport.AuthMethod, string, error) {
+	switch {
+	case auth.Basic != nil:
+		password, err := resolve(&auth.Basic.PasswordSecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		username := auth.Basic.Username
+		if username == "" {
+			username = defaultUser
+		}
+		return &githttp.BasicAuth{Username: username, Password: password}, strings.Join([]string{"basic", username, password}, "\x00"), nil
+	case auth.SSH != nil:
+		privateKey, err := resolve(&auth.SSH.PrivateKeySecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		passphrase, err := resolve(auth.SSH.PassphraseSecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		knownHosts, err := resolve(&auth.SSH.KnownHostsSecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		user := auth.SSH.User
+		if user == "" {
+			user = defaultUser
+		}
+		keys, err := gitssh.NewPublicKeys(user, []byte(privateKey), passphrase)
+		if err != nil {
+			return nil, "", err
+		}
+		if keys.HostKeyCallback, err = knownHostsCallback(knownHosts); err != nil {
+			return nil, "", err
+		}
+		return keys, strings.Join([]string{"ssh", user, privateKey, knownHosts}, "\x00"), nil
+	}
+	return nil, "", nil
+}
+
+// knownHostsCallback verifies host keys against the given known_hosts entries.
+// The entries are written to a temporary file as the known hosts database can
+// only be loaded from files.
+func knownHostsCallback(knownHosts string) (gossh.HostKeyCallback, error) {
+	f, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, fmt.Errorf(errKnownHosts, err)
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+	if _, err := f.WriteString(knownHosts); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf(errKnownHosts, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf(errKnownHosts, err)
+	}
+	callback, err := gitssh.NewKnownHostsCallback(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf(errKnownHosts, err)
+	}
+	return callback, nil
+}
+
+// ValidateStore checks the store configuration without resolving any secrets.
+func (p *Provider) ValidateStore(store esv1.GenericStore) (admission.Warnings, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if provider.URL == "" {
+		return nil, errors.New(errMissingURL)
+	}
+	if _, err := transport.NewEndpoint(provider.URL); err != nil {
+		return nil, fmt.Errorf(errInvalidURL, err)
+	}
+	if p := strings.Trim(provider.Path, "/"); p != "" && (path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../")) {
+		return nil, errors.New(errInvalidPath)
+	}
+
+	var refs []namedSecretRef
+	if auth := provider.Auth; auth != nil {
+		if auth.Basic != nil && auth.SSH != nil {
+			return nil, errors.New(errAuthExclusive)
+		}
+		if auth.Basic != nil {
+			refs = append(refs, namedSecretRef{"auth.basic.passwordSecretRef", &auth.Basic.PasswordSecretRef})
+		}
+		if auth.SSH != nil {
+			refs = append(refs,
+				namedSecretRef{"auth.ssh.privateKeySecretRef", &auth.SSH.PrivateKeySecretRef},
+				namedSecretRef{"auth.ssh.passphraseSecretRef", auth.SSH.PassphraseSecretRef},
+				namedSecretRef{"auth.ssh.knownHostsSecretRef", &auth.SSH.KnownHostsSecretRef},
+			)
+		}
+	}
+	if sops := provider.SOPS; sops != nil {
+		if sops.AgeSecretRef == nil && sops.PGPSecretRef == nil {
+			return nil, errors.New(errMissingSOPSKey)
+		}
+		refs = append(refs,
+			namedSecretRef{"sops.ageSecretRef", sops.AgeSecretRef},
+			namedSecretRef{"sops.pgpSecretRef", sops.PGPSecretRef},
+			namedSecretRef{"sops.pgpPassphraseSecretRef", sops.PGPPassphraseSecretRef},
+		)
+	}
+	for _, r := range refs {
+		if r.ref == nil {
+			continue
+		}
+		if err := esutils.ValidateReferentSecretSelector(store, *r.ref); err != nil {
+			return nil, fmt.Errorf(errInvalidSecretRef, r.name, err)
+		}
+	}
+	return nil, nil
+}
+
+// Capabilities returns the capabilities of the provider, the repository is only read.
+func (p *Provider) Capabilities() esv1.SecretStoreCapabilities {
+	return esv1.SecretStoreReadOnly
+}
+
+func getProvider(store esv1.GenericStore) (*esv1.GitProvider, error) {
+	if store == nil {
+		return nil, errors.New(errNilStore)
+	}
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil || spec.Provider.Git == nil {
+		return nil, errors.New(errMissingProvider)
+	}
+	return spec.Provider.Git, nil
+}
+
+// NewProvider creates a new Provider instance.
+func NewProvider() esv1.Provider {
+	return &Provider{}
+}
+
+// ProviderSpec returns the provider specification for registration.
+func ProviderSpec() *esv1.SecretStoreProvider {
+	return &esv1.SecretStoreProvider{
+		Git: &esv1.GitProvider{},
+	}
+}
+
+// MaintenanceStatus returns the maintenance status of the provider.
+func MaintenanceStatus() esv1.MaintenanceStatus {
+	return esv1.MaintenanceStatusMaintained
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"testing"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestValidateStore(t *testing.T) {
+	other := "other"
+	tests := []struct {
+		name     string
+		provider *esv1.GitProvider
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			provider: &esv1.GitProvider{URL: "https://example.com/repo.git", Path: "apps/prod/"},
+		},
+		{
+			name: "valid auth and sops",
+			provider: &esv1.GitProvider{
+				URL: "ssh://git@example.com/repo.git",
+				Auth: &esv1.GitAuth{SSH: &esv1.GitSSHAuth{
+					PrivateKeySecretRef: esmeta.SecretKeySelector{Name: "git", Key: "id_ed25519"},
+					KnownHostsSecretRef: esmeta.SecretKeySelector{Name: "git", Key: "known_hosts"},
+				}},
+				SOPS: &esv1.GitSOPS{AgeSecretRef: &esmeta.SecretKeySelector{Name: "sops", Key: "age.key"}},
+			},
+		},
+		{
+			name:     "missing url",
+			provider: &esv1.GitProvider{},
+			wantErr:  true,
+		},
+		{
+			name:     "path outside of repository",
+			provider: &esv1.GitProvider{URL: "https://example.com/repo.git", Path: "../other"},
+			wantErr:  true,
+		},
+		{
+			name: "basic and ssh auth",
+			provider: &esv1.GitProvider{
+				URL: "https://example.com/repo.git",
+				Auth: &esv1.GitAuth{
+					Basic: &esv1.GitBasicAuth{},
+					SSH:   &esv1.GitSSHAuth{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "sops without keys",
+			provider: &esv1.GitProvider{URL: "https://example.com/repo.git", SOPS: &esv1.GitSOPS{}},
+			wantErr:  true,
+		},
+		{
+			name: "secret ref in other namespace",
+			provider: &esv1.GitProvider{
+				URL: "https://example.com/repo.git",
+				Auth: &esv1.GitAuth{Basic: &esv1.GitBasicAuth{
+					PasswordSecretRef: esmeta.SecretKeySelector{Name: "git", Key: "token", Namespace: &other},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &esv1.SecretStore{
+				Spec: esv1.SecretStoreSpec{Provider: &esv1.SecretStoreProvider{Git: tt.provider}},
+			}
+			store.Namespace = "default"
+			_, err := NewProvider().ValidateStore(store)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// cacheExpiry is the time after which unused clones are evicted from the cache,
+// e.g. because the store was deleted or its configuration changed.
+const cacheExpiry = time.Hour
+
+const errRefNotFound = "ref %q not found in repository %q"
+
+// repositories caches shallow clones across clients, so a clone is shared
+// between all ExternalSecrets that use the same store.
+var repositories = &repositoryCache{entries: map[string]*repository{}}
+
+type repositoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*repository
+}
+
+// repository is an in-memory shallow clone of a single ref.
+// Only the storer and the hash of the commit are cached, as the trees of go-git
+// lazily build internal maps and must not be shared between goroutines.
+type repository struct {
+	mu        sync.Mutex
+	lastUsed  time.Time
+	fetchedAt time.Time
+	// hash is the hash the ref pointed to when it was fetched.
+	hash   plumbing.Hash
+	commit plumbing.Hash
+	storer storer.EncodedObjectStorer
+}
+
+// remote describes a ref of a remote repository along with the credentials to fetch it.
+type remote struct {
+	url  string
+	ref  string
+	auth transport.AuthMethod
+	// credentials identifies the credentials, so clones are never shared between
+	// stores that authenticate differently.
+	credentials string
+}
+
+// cacheKey returns the key of the remote in the repository cache.
+func (r *remote) cacheKey() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{r.url, r.ref, r.credentials}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached clone of the remote, creating an empty entry if there is none.
+func (c *repositoryCache) get(key string) *repository {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, repo := range c.entries {
+		if k != key && repo.expired(now) {
+			delete(c.entries, k)
+		}
+	}
+	repo, ok := c.entries[key]
+	if !ok {
+		repo = &repository{}
+		c.entries[key] = repo
+	}
+	return repo
+}
+
+func (r *repository) expired(now time.Time) bool {
+	if !r.mu.TryLock() {
+		return false
+	}
+	defer r.mu.Unlock()
+	return now.Sub(r.lastUsed) > cacheExpiry
+}
+
+// files returns the file tree of the remote ref. The ref is fetched again
+// once the cached clone is older than the refresh interval, but the
+// repository is only cloned again if the ref points to a different commit.
+// Every call returns a new tree, so callers may use it without further locking.
+func (r *repository) files(ctx context.Context, rem *remote, refreshInterval time.Duration) (*object.Tree, error) {
+	objects, commit, err := r.fetch(ctx, rem, refreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	c, err := object.GetCommit(objects, commit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read commit %s of repository %q: %w", commit, rem.url, err)
+	}
+	return c.Tree()
+}
+
+// fetch returns the storer and the commit of the cached clone, and refreshes the clone if needed.
+func (r *repository) fetch(ctx context.Context, rem *remote, refreshInterval time.Duration) (storer.EncodedObjectStorer, plumbing.Hash, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.lastUsed = now
+	if r.storer != nil && now.Sub(r.fetchedAt) < refreshInterval {
+		return r.storer, r.commit, nil
+	}
+
+	ref, err := resolveRef(ctx, rem)
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+	if r.storer != nil && ref.Hash() == r.hash {
+		r.fetchedAt = now
+		return r.storer, r.commit, nil
+	}
+
+	objects, commit, err := clone(ctx, rem, ref.Name())
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+	r.storer, r.commit, r.hash, r.fetchedAt = objects, commit, ref.Hash(), now
+	return objects, commit, nil
+}
+
+// resolveRef lists the refs of the remote and returns the one configured.
+// Short names are looked up as branch first and tag second.
+func resolveRef(ctx context.Context, rem *remote) (*plumbing.Reference, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{rem.url},
+	})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: rem.auth})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list refs of repository %q: %w", rem.url, err)
+	}
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference, len(refs))
+	for _, ref := range refs {
+		byName[ref.Name()] = ref
+	}
+
+	var candidates []plumbing.ReferenceName
+	switch {
+	case rem.ref == "":
+		candidates = []plumbing.ReferenceName{plumbing.HEAD}
+	case strings.HasPrefix(rem.ref, "refs/"):
+		candidates = []plumbing.ReferenceName{plumbing.ReferenceName(rem.ref)}
+	default:
+		candidates = []plumbing.ReferenceName{
+			plumbing.NewBranchReferenceName(rem.ref),
+			plumbing.NewTagReferenceName(rem.ref),
+		}
+	}
+	for _, name := range candidates {
+		ref, ok := byName[name]
+		if !ok {
+			continue
+		}
+		if ref.Type() == plumbing.SymbolicReference {
+			if ref, ok = byName[ref.Target()]; !ok {
+				continue
+			}
+		}
+		return ref, nil
+	}
+	return nil, fmt.Errorf(errRefNotFound, rem.ref, rem.url)
+}
+
+// clone creates a shallow in-memory clone of the ref and returns its storer and the hash of its commit.
+func clone(ctx context.Context, rem *remote, name plumbing.ReferenceName) (storer.EncodedObjectStorer, plumbing.Hash, error) {
+	objects := memory.NewStorage()
+	repo, err := git.CloneContext(ctx, objects, nil, &git.CloneOptions{
+		URL:           rem.url,
+		Auth:          rem.auth,
+		ReferenceName: name,
+		SingleBranch:  true,
+		Depth:         1,
+		Tags:          git.NoTags,
+	})
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("unable to clone repository %q: %w", rem.url, err)
+	}
+	ref, err := repo.Reference(name, true)
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf(errRefNotFound, name.Short(), rem.url)
+	}
+
+	var commit *object.Commit
+	obj, err := repo.Object(plumbing.AnyObject, ref.Hash())
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+	switch o := obj.(type) {
+	case *object.Tag:
+		commit, err = o.Commit()
+	case *object.Commit:
+		commit = o
+	default:
+		err = errors.New("ref does not point to a commit")
+	}
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("unable to resolve commit of ref %q: %w", name.Short(), err)
+	}
+	return objects, commit.Hash, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/stores/dotenv"
+	"github.com/getsops/sops/v3/stores/ini"
+	sopsjson "github.com/getsops/sops/v3/stores/json"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+
+	"github.com/external-secrets/external-secrets/runtime/envelope"
+)
+
+const (
+	sopsMetadataKey = "sops"
+
+	errSOPSNoKeys          = "file is encrypted with SOPS but no decryption keys are configured"
+	errSOPSNoMatchingKey   = "none of the configured keys can decrypt the SOPS data key: %w"
+	errSOPSLoad            = "unable to read SOPS file: %w"
+	errSOPSDecrypt         = "unable to decrypt SOPS values: %w"
+	errSOPSMACMismatch     = "SOPS MAC mismatch, the file has been tampered with"
+	errSOPSUnsupportedKey  = "unsupported SOPS master key %T, only age and OpenPGP keys can be configured"
+	errSOPSEncryptDisabled = "encrypting SOPS data keys is not supported"
+)
+
+// sopsKeys holds the private keys used to decrypt the data key of SOPS files.
+// It is the key service SOPS asks to decrypt the data key, so that the keys
+// are never read from the environment or the filesystem of the controller.
+type sopsKeys struct {
+	age           string
+	pgp           string
+	pgpPassphrase string
+}
+
+var _ keyservice.KeyServiceClient = &sopsKeys{}
+
+// sopsStore returns the SOPS store of the file format.
+func sopsStore(format string) sops.Store {
+	switch format {
+	case formatYAML:
+		return sopsyaml.NewStore(&config.YAMLStoreConfig{})
+	case formatJSON:
+		return sopsjson.NewStore(&config.JSONStoreConfig{Indent: -1})
+	case formatDotenv:
+		return dotenv.NewStore(&config.DotenvStoreConfig{})
+	case formatINI:
+		return ini.NewStore(&config.INIStoreConfig{})
+	default:
+		return sopsjson.NewBinaryStore(&config.JSONBinaryStoreConfig{Indent: -1})
+	}
+}
+
+// sopsDecrypt decrypts the SOPS file and returns its plain content in the
+// same format. Like the sops binary, it verifies the MAC of the file.
+func sopsDecrypt(content []byte, format string, keys *sopsKeys) ([]byte, error) {
+	if keys == nil || (keys.age == "" && keys.pgp == "") {
+		return nil, errors.New(errSOPSNoKeys)
+	}
+	store := sopsStore(format)
+	tree, err := store.LoadEncryptedFile(content)
+	if err != nil {
+		return nil, fmt.Errorf(errSOPSLoad, err)
+	}
+	dataKey, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{keys}, sops.DefaultDecryptionOrder)
+	if err != nil {
+		return nil, fmt.Errorf(errSOPSNoMatchingKey, err)
+	}
+	cipher := aes.NewCipher()
+	computedMAC, err := tree.Decrypt(dataKey, cipher)
+	if err != nil {
+		return nil, fmt.Errorf(errSOPSDecrypt, err)
+	}
+	fileMAC, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil || fileMAC != computedMAC {
+		return nil, errors.New(errSOPSMACMismatch)
+	}
+	return store.EmitPlainFile(tree.Branches)
+}
+
+// isSOPSDocument returns true if the root of a YAML or JSON document has SOPS metadata.
+func isSOPSDocument(root *yaml.Node) bool {
+	if root == nil || root.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == sopsMetadataKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Decrypt decrypts a data key that is wrapped by an age or OpenPGP master key.
+func (k *sopsKeys) Decrypt(_ context.Context, req *keyservice.DecryptRequest, _ ...grpc.CallOption) (*keyservice.DecryptResponse, error) {
+	var (
+		plaintext []byte
+		err       error
+	)
+	switch key := req.GetKey().GetKeyType().(type) {
+	case *keyservice.Key_AgeKey:
+		if k.age == "" {
+			return nil, errors.New("no age identity is configured")
+		}
+		var identities sopsage.ParsedIdentities
+		if err := identities.Import(k.age); err != nil {
+			return nil, err
+		}
+		masterKey := &sopsage.MasterKey{Recipient: key.AgeKey.GetRecipient(), EncryptedKey: string(req.GetCiphertext())}
+		identities.ApplyToMasterKey(masterKey)
+		plaintext, err = masterKey.Decrypt()
+	case *keyservice.Key_PgpKey:
+		if k.pgp == "" {
+			return nil, errors.New("no OpenPGP private key is configured")
+		}
+		plaintext, err = envelope.PGPDecrypt(k.pgp, k.pgpPassphrase, req.GetCiphertext())
+	default:
+		return nil, fmt.Errorf(errSOPSUnsupportedKey, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// Encrypt is not supported, the provider only reads SOPS files.
+func (k *sopsKeys) Encrypt(_ context.Context, _ *keyservice.EncryptRequest, _ ...grpc.CallOption) (*keyservice.EncryptResponse, error) {
+	return nil, errors.New(errSOPSEncryptDisabled)
+}
@@ -0,0 +1,255 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/pgp"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/external-secrets/external-secrets/runtime/envelope"
+)
+
+// The files in testdata are encrypted with sops 3.10.2 using the age
+// identity of testdata/age.key.
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func ageKeys(t *testing.T) *sopsKeys {
+	t.Helper()
+	return &sopsKeys{age: string(readTestdata(t, "age.key"))}
+}
+
+func decryptTestdata(t *testing.T, data []byte, keys *sopsKeys) (map[string]any, error) {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if isSOPSDocument(doc.Content[0]) {
+		var err error
+		if data, err = sopsDecrypt(data, formatYAML, keys); err != nil {
+			return nil, err
+		}
+	}
+	var out map[string]any
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return out, nil
+}
+
+func TestSOPSDecrypt(t *testing.T) {
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secrets := readTestdata(t, "secrets.yaml")
+
+	tests := []struct {
+		name    string
+		data    []byte
+		keys    *sopsKeys
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "yaml",
+			data: secrets,
+			keys: ageKeys(t),
+			want: map[string]any{
+				"database": map[string]any{
+					"username": "admin",
+					"password": "s3cr3t",
+					"port":     5432,
+				},
+				"api_token":               "tok3n",
+				"enabled":                 true,
+				"ratio":                   0.5,
+				"hosts":                   []any{"a.example.com", "b.example.com"},
+				"description_unencrypted": "public value",
+			},
+		},
+		{
+			name: "json",
+			data: readTestdata(t, "secrets.json"),
+			keys: ageKeys(t),
+			want: map[string]any{
+				"username": "json-user",
+				"password": "json-pass",
+				"nested":   map[string]any{"key": "value"},
+			},
+		},
+		{
+			name: "encrypted regex and mac only encrypted",
+			data: readTestdata(t, "partial.yaml"),
+			keys: ageKeys(t),
+			want: map[string]any{
+				"username": "admin",
+				"password": "only-this-is-encrypted",
+			},
+		},
+		{
+			name: "unencrypted file",
+			data: []byte("username: admin\n"),
+			want: map[string]any{"username": "admin"},
+		},
+		{
+			name:    "tampered unencrypted value",
+			data:    bytes.Replace(secrets, []byte("public value"), []byte("changed value"), 1),
+			keys:    ageKeys(t),
+			wantErr: errSOPSMACMismatch,
+		},
+		{
+			name:    "swapped encrypted values",
+			data:    swapLines(t, secrets, "username:", "password:"),
+			keys:    ageKeys(t),
+			wantErr: "unable to decrypt SOPS values",
+		},
+		{
+			name:    "wrong key",
+			data:    secrets,
+			keys:    &sopsKeys{age: other.String()},
+			wantErr: "none of the configured keys can decrypt the SOPS data key",
+		},
+		{
+			name:    "no keys",
+			data:    secrets,
+			wantErr: errSOPSNoKeys,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decryptTestdata(t, tt.data, tt.keys)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("sopsDecrypt() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sopsDecrypt() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("sopsDecrypt() = diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSOPSDecryptPGP(t *testing.T) {
+	entity, err := openpgp.NewEntity("eso", "test", "eso@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var publicKey, privateKey bytes.Buffer
+	armorKey(t, &publicKey, openpgp.PublicKeyType, func(w io.Writer) error { return entity.Serialize(w) })
+	armorKey(t, &privateKey, openpgp.PrivateKeyType, func(w io.Writer) error { return entity.SerializePrivate(w, nil) })
+
+	// replace the age master key of the fixture with an OpenPGP master key
+	// that wraps the same data key.
+	store := sopsyaml.NewStore(&config.YAMLStoreConfig{})
+	tree, err := store.LoadEncryptedFile(readTestdata(t, "secrets.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataKey, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{ageKeys(t)}, sops.DefaultDecryptionOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := envelope.PGPEncrypt(publicKey.String(), dataKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree.Metadata.KeyGroups = []sops.KeyGroup{{&pgp.MasterKey{
+		Fingerprint:  hex.EncodeToString(entity.PrimaryKey.Fingerprint),
+		EncryptedKey: string(enc),
+		CreationDate: time.Now(),
+	}}}
+	data, err := store.EmitEncryptedFile(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := decryptTestdata(t, data, &sopsKeys{pgp: privateKey.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["api_token"] != "tok3n" {
+		t.Errorf("sopsDecrypt() api_token = %v, want tok3n", got["api_token"])
+	}
+	if _, err := decryptTestdata(t, data, ageKeys(t)); err == nil {
+		t.Error("sopsDecrypt() expected error for age identity")
+	}
+}
+
+func swapLines(t *testing.T, data []byte, a, b string) []byte {
+	t.Helper()
+	lines := strings.Split(string(data), "\n")
+	ai, bi := -1, -1
+	for i, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if ai < 0 && strings.HasPrefix(trimmed, a) {
+			ai = i
+		}
+		if bi < 0 && strings.HasPrefix(trimmed, b) {
+			bi = i
+		}
+	}
+	if ai < 0 || bi < 0 {
+		t.Fatalf("lines %q and %q not found", a, b)
+	}
+	av, bv := lines[ai][strings.Index(lines[ai], ":"):], lines[bi][strings.Index(lines[bi], ":"):]
+	lines[ai] = lines[ai][:strings.Index(lines[ai], ":")] + bv
+	lines[bi] = lines[bi][:strings.Index(lines[bi], ":")] + av
+	return []byte(strings.Join(lines, "\n"))
+}
+
+func armorKey(t *testing.T, w io.Writer, blockType string, serialize func(io.Writer) error) {
+	t.Helper()
+	aw, err := pgparmor.Encode(w, blockType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := serialize(aw); err != nil {
+		t.Fatal(err)
+	}
+	if err := aw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
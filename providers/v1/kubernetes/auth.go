@@ -25,8 +25,10 @@ import (
 
 	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -38,6 +40,21 @@ const (
 	errUnableCreateToken = "cannot create service account token: %q"
 )
 
+// RESTConfig returns the rest config to connect to the cluster described by the given provider spec.
+// It allows generators to connect to a cluster the same way the provider does.
+// ctrlClient and ctrlClientset must have the scope of the controller, they are used to
+// resolve secret references and to request ServiceAccount tokens in the given namespace.
+func RESTConfig(ctx context.Context, prov *esv1.KubernetesProvider, ctrlClient kclient.Client, ctrlClientset kubernetes.Interface, storeKind, namespace string) (*rest.Config, error) {
+	c := &Client{
+		ctrlClient:    ctrlClient,
+		ctrlClientset: ctrlClientset.CoreV1(),
+		store:         prov,
+		storeKind:     storeKind,
+		namespace:     namespace,
+	}
+	return c.getAuth(ctx)
+}
+
 func (c *Client) getAuth(ctx context.Context) (*rest.Config, error) {
 	if c.store.AuthRef != nil {
 		cfg, err := c.fetchSecretKey(ctx, *c.store.AuthRef)
@@ -135,6 +152,7 @@ func (c *Client) serviceAccountToken(ctx context.Context, serviceAccountRef *esm
 	if err != nil {
 		return nil, fmt.Errorf(errUnableCreateToken, err)
 	}
+	c.credentialsExpireAt = tr.Status.ExpirationTimestamp.Time
 	return []byte(tr.Status.Token), nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tidwall/gjson"
 	v1 "k8s.io/api/core/v1"
@@ -396,6 +397,12 @@ func (c *Client) findByName(ctx context.Context, ref esv1.ExternalSecretFind) (m
 	return esutils.ConvertKeys(ref.ConversionStrategy, data)
 }
 
+// CredentialsExpireAt implements esv1.CredentialsExpirer. The token of the
+// service account is requested once when the client is created and not renewed.
+func (c *Client) CredentialsExpireAt() time.Time {
+	return c.credentialsExpireAt
+}
+
 // Close implements cleanup operations for the Kubernetes client.
 func (c *Client) Close(_ context.Context) error {
 	return nil
@@ -22,10 +22,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,7 +38,10 @@ import (
 
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1.SecretsClient = &Client{}
+var _ esv1.SecretsWatcher = &Client{}
+var _ esv1.CredentialsExpirer = &Client{}
 var _ esv1.Provider = &Provider{}
+var _ esv1.PoolableClientProvider = &Provider{}
 
 // KClient defines the interface for interacting with Kubernetes Secrets.
 type KClient interface {
@@ -45,6 +50,7 @@ type KClient interface {
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	Create(ctx context.Context, secret *v1.Secret, opts metav1.CreateOptions) (*v1.Secret, error)
 	Update(ctx context.Context, secret *v1.Secret, opts metav1.UpdateOptions) (*v1.Secret, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 }
 
 // RClient defines the interface for performing self subject rules reviews.
@@ -87,6 +93,16 @@ type Client struct {
 	// namespace is the namespace of the
 	// ExternalSecret referencing this provider.
 	namespace string
+
+	// credentialsExpireAt is the time the service account token
+	// of the client expires, if it authenticates with one.
+	credentialsExpireAt time.Time
+}
+
+// PoolableClients implements esv1.PoolableClientProvider. A client is not
+// modified after it is created and reports when its token expires.
+func (p *Provider) PoolableClients() bool {
+	return true
 }
 
 // Capabilities returns the provider's supported capabilities (ReadWrite).
@@ -0,0 +1,78 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/external-secrets/external-secrets/runtime/constants"
+	"github.com/external-secrets/external-secrets/runtime/metrics"
+)
+
+const (
+	errWatchNoClient = "no secret client for namespace %q, a ClusterSecretStore is watched per namespace"
+	errWatchClosed   = "watch of secrets in namespace %q has been closed"
+)
+
+// WatchSecrets watches the secrets of the remote namespace and calls onChange
+// with the name of every secret that is created, updated or deleted.
+// Secrets that exist when the watch starts are not reported.
+func (c *Client) WatchSecrets(ctx context.Context, onChange func(keys []string)) error {
+	if c.userSecretClient == nil {
+		return fmt.Errorf(errWatchNoClient, c.store.RemoteNamespace)
+	}
+	// the resourceVersion of the list starts the watch at the current state
+	list, err := c.userSecretClient.List(ctx, metav1.ListOptions{Limit: 1})
+	metrics.ObserveAPICall(constants.ProviderKubernetes, constants.CallKubernetesListSecrets, err)
+	if err != nil {
+		return err
+	}
+	w, err := c.userSecretClient.Watch(ctx, metav1.ListOptions{ResourceVersion: list.ResourceVersion})
+	metrics.ObserveAPICall(constants.ProviderKubernetes, constants.CallKubernetesWatchSecrets, err)
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf(errWatchClosed, c.store.RemoteNamespace)
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				if secret, ok := event.Object.(*v1.Secret); ok {
+					onChange([]string{secret.Name})
+				}
+			case watch.Error:
+				return apierrors.FromObject(event.Object)
+			}
+		}
+	}
+}
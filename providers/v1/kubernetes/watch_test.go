@@ -0,0 +1,172 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+const watchTimeout = 10 * time.Second
+
+func startWatch(t *testing.T, c *Client) (<-chan string, <-chan error, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	changes := make(chan string, 100)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.WatchSecrets(ctx, func(keys []string) {
+			for _, key := range keys {
+				changes <- key
+			}
+		})
+	}()
+	return changes, errs, cancel
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(watchTimeout):
+		t.Fatal("timed out")
+	}
+	var zero T
+	return zero
+}
+
+func TestWatchSecrets(t *testing.T) {
+	clientset := fake.NewClientset()
+	fakeWatch := watch.NewFake()
+	clientset.PrependWatchReactor("secrets", k8stesting.DefaultWatchReactor(fakeWatch, nil))
+	c := &Client{
+		userSecretClient: clientset.CoreV1().Secrets("default"),
+		store:            &esv1.KubernetesProvider{RemoteNamespace: "default"},
+	}
+	changes, errs, _ := startWatch(t, c)
+
+	fakeWatch.Add(&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "created"}})
+	fakeWatch.Modify(&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "updated"}})
+	fakeWatch.Delete(&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "deleted"}})
+	for _, want := range []string{"created", "updated", "deleted"} {
+		if got := receive(t, changes); got != want {
+			t.Errorf("WatchSecrets() reported %q, want %q", got, want)
+		}
+	}
+
+	fakeWatch.Error(&metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonExpired, Code: 410})
+	if err := receive(t, errs); !apierrors.IsResourceExpired(err) {
+		t.Errorf("WatchSecrets() error = %v, want expired", err)
+	}
+}
+
+func TestWatchSecretsStops(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.PrependWatchReactor("secrets", k8stesting.DefaultWatchReactor(watch.NewFake(), nil))
+	c := &Client{
+		userSecretClient: clientset.CoreV1().Secrets("default"),
+		store:            &esv1.KubernetesProvider{RemoteNamespace: "default"},
+	}
+	_, errs, cancel := startWatch(t, c)
+	cancel()
+	if err := receive(t, errs); err != nil {
+		t.Errorf("WatchSecrets() after cancel error = %v, want nil", err)
+	}
+
+	c.userSecretClient = nil
+	if err := c.WatchSecrets(context.Background(), func([]string) {}); err == nil {
+		t.Error("WatchSecrets() without secret client should fail")
+	}
+}
+
+func TestWatchSecretsEnvtest(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+	testEnv := &envtest.Environment{}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = testEnv.Stop()
+	})
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secrets := clientset.CoreV1().Secrets("default")
+	ctx := context.Background()
+	if _, err := secrets.Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "existing"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Client{
+		userSecretClient: secrets,
+		store:            &esv1.KubernetesProvider{RemoteNamespace: "default"},
+	}
+	changes, errs, cancel := startWatch(t, c)
+
+	// the watch starts asynchronously, update the secret until the change is reported
+	changed := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "changed"}}
+	if changed, err = secrets.Create(ctx, changed, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	deadline := time.After(watchTimeout)
+	for i := 0; !slices.Contains(got, "changed"); i++ {
+		select {
+		case key := <-changes:
+			got = append(got, key)
+		case <-time.After(100 * time.Millisecond):
+			changed.Data = map[string][]byte{"counter": []byte(strconv.Itoa(i))}
+			if changed, err = secrets.Update(ctx, changed, metav1.UpdateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+		case err := <-errs:
+			t.Fatalf("WatchSecrets() error = %v", err)
+		case <-deadline:
+			t.Fatal("timed out waiting for the change of secret changed")
+		}
+	}
+
+	if slices.Contains(got, "existing") {
+		t.Errorf("WatchSecrets() reported the existing secret: %v", got)
+	}
+
+	cancel()
+	if err := receive(t, errs); err != nil {
+		t.Errorf("WatchSecrets() after cancel error = %v, want nil", err)
+	}
+}
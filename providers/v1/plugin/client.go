@@ -0,0 +1,136 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	pluginsdk "github.com/external-secrets/external-secrets/runtime/plugin"
+	pluginv1 "github.com/external-secrets/external-secrets/runtime/plugin/proto/v1"
+)
+
+// Client proxies the calls of a store to a plugin.
+type Client struct {
+	conn    *grpc.ClientConn
+	rpc     pluginv1.SecretsProviderClient
+	store   *pluginsdk.Store
+	timeout time.Duration
+}
+
+var _ esv1.SecretsClient = &Client{}
+
+// GetSecret returns a single secret from the plugin.
+func (c *Client) GetSecret(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := c.rpc.GetSecret(ctx, &pluginv1.GetSecretRequest{
+		Store: pluginsdk.StoreToProto(c.store),
+		Ref:   pluginsdk.RemoteRefToProto(ref),
+	})
+	if err != nil {
+		return nil, pluginsdk.FromStatus(err)
+	}
+	return resp.GetValue(), nil
+}
+
+// GetSecretMap returns multiple key/value pairs of a single secret from the plugin.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := c.rpc.GetSecretMap(ctx, &pluginv1.GetSecretMapRequest{
+		Store: pluginsdk.StoreToProto(c.store),
+		Ref:   pluginsdk.RemoteRefToProto(ref),
+	})
+	if err != nil {
+		return nil, pluginsdk.FromStatus(err)
+	}
+	return resp.GetData(), nil
+}
+
+// GetAllSecrets returns all secrets of the plugin that match the find criteria.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1.ExternalSecretFind) (map[string][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := c.rpc.GetAllSecrets(ctx, &pluginv1.GetAllSecretsRequest{
+		Store: pluginsdk.StoreToProto(c.store),
+		Find:  pluginsdk.FindToProto(ref),
+	})
+	if err != nil {
+		return nil, pluginsdk.FromStatus(err)
+	}
+	return resp.GetData(), nil
+}
+
+// PushSecret writes a single secret with the plugin.
+func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1.PushSecretData) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	_, err := c.rpc.PushSecret(ctx, &pluginv1.PushSecretRequest{
+		Store:  pluginsdk.StoreToProto(c.store),
+		Secret: pluginsdk.SecretToProto(secret),
+		Data:   pluginsdk.PushSecretDataToProto(data),
+	})
+	return pluginsdk.FromStatus(err)
+}
+
+// DeleteSecret deletes a pushed secret with the plugin.
+func (c *Client) DeleteSecret(ctx context.Context, remoteRef esv1.PushSecretRemoteRef) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	_, err := c.rpc.DeleteSecret(ctx, &pluginv1.DeleteSecretRequest{
+		Store: pluginsdk.StoreToProto(c.store),
+		Ref:   pluginsdk.PushRemoteRefToProto(remoteRef),
+	})
+	return pluginsdk.FromStatus(err)
+}
+
+// SecretExists checks with the plugin if a secret exists at the given location.
+func (c *Client) SecretExists(ctx context.Context, remoteRef esv1.PushSecretRemoteRef) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := c.rpc.SecretExists(ctx, &pluginv1.SecretExistsRequest{
+		Store: pluginsdk.StoreToProto(c.store),
+		Ref:   pluginsdk.PushRemoteRefToProto(remoteRef),
+	})
+	if err != nil {
+		return false, pluginsdk.FromStatus(err)
+	}
+	return resp.GetExists(), nil
+}
+
+// Validate asks the plugin to validate the store.
+func (c *Client) Validate() (esv1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	resp, err := c.rpc.Validate(ctx, &pluginv1.ValidateRequest{
+		Store: pluginsdk.StoreToProto(c.store),
+	})
+	if err != nil {
+		return esv1.ValidationResultError, pluginsdk.FromStatus(err)
+	}
+	return pluginsdk.ValidationResultFromProto(resp.GetResult()), nil
+}
+
+// Close closes the connection to the plugin.
+func (c *Client) Close(_ context.Context) error {
+	return c.conn.Close()
+}
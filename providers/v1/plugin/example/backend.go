@@ -0,0 +1,243 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils"
+	"github.com/external-secrets/external-secrets/runtime/find"
+	"github.com/external-secrets/external-secrets/runtime/plugin"
+)
+
+const tokenSecret = "token"
+
+var (
+	errVersionUnsupported = errors.New("versions are not supported by the example plugin")
+	errTagsUnsupported    = errors.New("find.tags is not supported by the example plugin")
+)
+
+// config is the plugin specific spec.provider.plugin.config of a store.
+type config struct {
+	// Prefix is prepended to all keys of the store.
+	Prefix string `json:"prefix"`
+}
+
+// backend keeps secrets in memory. Values that are JSON objects can be
+// accessed by property.
+type backend struct {
+	mu      sync.RWMutex
+	secrets map[string][]byte
+	token   string
+}
+
+func newBackend(secrets map[string][]byte, token string) *backend {
+	if secrets == nil {
+		secrets = make(map[string][]byte)
+	}
+	return &backend{secrets: secrets, token: token}
+}
+
+// newClient is the plugin.ClientFactory of the example plugin. If the backend
+// has a token, stores must pass it with spec.provider.plugin.secretRefs.token.
+func (b *backend) newClient(_ context.Context, store *plugin.Store) (esv1.SecretsClient, error) {
+	if b.token != "" && string(store.Secrets[tokenSecret]) != b.token {
+		return nil, status.Error(codes.PermissionDenied, "invalid token")
+	}
+	var cfg config
+	if err := store.DecodeConfig(&cfg); err != nil {
+		return nil, err
+	}
+	return &client{backend: b, prefix: cfg.Prefix}, nil
+}
+
+type client struct {
+	backend *backend
+	prefix  string
+}
+
+func (c *client) GetSecret(_ context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.Version != "" {
+		return nil, errVersionUnsupported
+	}
+	value, ok := c.backend.get(c.prefix + ref.Key)
+	if !ok {
+		return nil, esv1.NoSecretErr
+	}
+	if ref.Property == "" {
+		return value, nil
+	}
+	doc, err := decode(value)
+	if err != nil {
+		return nil, err
+	}
+	return esutils.GetByteValueFromMap(doc, ref.Property)
+}
+
+func (c *client) GetSecretMap(_ context.Context, ref esv1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Version != "" {
+		return nil, errVersionUnsupported
+	}
+	value, ok := c.backend.get(c.prefix + ref.Key)
+	if !ok {
+		return nil, esv1.NoSecretErr
+	}
+	doc, err := decode(value)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]byte, len(doc))
+	for k, v := range doc {
+		if out[k], err = esutils.GetByteValue(v); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (c *client) GetAllSecrets(_ context.Context, ref esv1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errTagsUnsupported
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		if matcher, err = find.New(*ref.Name); err != nil {
+			return nil, err
+		}
+	}
+	c.backend.mu.RLock()
+	defer c.backend.mu.RUnlock()
+	out := make(map[string][]byte)
+	for key, value := range c.backend.secrets {
+		name, ok := strings.CutPrefix(key, c.prefix)
+		if !ok {
+			continue
+		}
+		if ref.Path != nil && !strings.HasPrefix(name, *ref.Path) {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(name) {
+			continue
+		}
+		out[name] = value
+	}
+	return out, nil
+}
+
+func (c *client) PushSecret(_ context.Context, secret *corev1.Secret, data esv1.PushSecretData) error {
+	value, err := esutils.ExtractSecretData(data, secret)
+	if err != nil {
+		return err
+	}
+	key := c.prefix + data.GetRemoteKey()
+	if data.GetProperty() == "" {
+		c.backend.set(key, value)
+		return nil
+	}
+
+	c.backend.mu.Lock()
+	defer c.backend.mu.Unlock()
+	doc := make(map[string]any)
+	if existing, ok := c.backend.secrets[key]; ok {
+		if doc, err = decode(existing); err != nil {
+			return err
+		}
+	}
+	doc[data.GetProperty()] = string(value)
+	if c.backend.secrets[key], err = json.Marshal(doc); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) DeleteSecret(_ context.Context, ref esv1.PushSecretRemoteRef) error {
+	key := c.prefix + ref.GetRemoteKey()
+	c.backend.mu.Lock()
+	defer c.backend.mu.Unlock()
+	existing, ok := c.backend.secrets[key]
+	if !ok {
+		return nil
+	}
+	if ref.GetProperty() == "" {
+		delete(c.backend.secrets, key)
+		return nil
+	}
+	doc, err := decode(existing)
+	if err != nil {
+		return err
+	}
+	delete(doc, ref.GetProperty())
+	if len(doc) == 0 {
+		delete(c.backend.secrets, key)
+		return nil
+	}
+	c.backend.secrets[key], err = json.Marshal(doc)
+	return err
+}
+
+func (c *client) SecretExists(_ context.Context, ref esv1.PushSecretRemoteRef) (bool, error) {
+	value, ok := c.backend.get(c.prefix + ref.GetRemoteKey())
+	if !ok || ref.GetProperty() == "" {
+		return ok, nil
+	}
+	doc, err := decode(value)
+	if err != nil {
+		return false, err
+	}
+	_, ok = doc[ref.GetProperty()]
+	return ok, nil
+}
+
+func (c *client) Validate() (esv1.ValidationResult, error) {
+	return esv1.ValidationResultReady, nil
+}
+
+func (c *client) Close(_ context.Context) error {
+	return nil
+}
+
+func (b *backend) get(key string) ([]byte, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	value, ok := b.secrets[key]
+	return value, ok
+}
+
+func (b *backend) set(key string, value []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.secrets[key] = value
+}
+
+func decode(value []byte) (map[string]any, error) {
+	doc := make(map[string]any)
+	if err := json.Unmarshal(value, &doc); err != nil {
+		return nil, fmt.Errorf("secret is not a JSON object: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command example is an example provider plugin that keeps secrets in memory.
+// It shows how to implement a plugin with the SDK of the runtime module.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/external-secrets/external-secrets/runtime/plugin"
+)
+
+func main() {
+	var (
+		address  = flag.String("address", "unix:///tmp/eso-example-plugin.sock", "unix domain socket (unix:///path) or TCP address (host:port) to listen on")
+		data     = flag.String("data", "", "JSON file with the initial secrets, e.g. {\"db\": {\"password\": \"s3cr3t\"}}")
+		token    = flag.String("token", "", "token that stores must pass with spec.provider.plugin.secretRefs.token")
+		tlsCert  = flag.String("tls-cert", "", "server certificate for TLS")
+		tlsKey   = flag.String("tls-key", "", "private key of the server certificate")
+		clientCA = flag.String("client-ca", "", "CA certificates to verify client certificates, enables mutual TLS")
+	)
+	flag.Parse()
+
+	secrets, err := loadSecrets(*data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var opts []grpc.ServerOption
+	if *tlsCert != "" {
+		creds, err := serverCredentials(*tlsCert, *tlsKey, *clientCA)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	lis, err := plugin.Listen(*address)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("serving example plugin on %s", *address)
+	if err := plugin.Serve(ctx, lis, newBackend(secrets, *token).newClient, opts...); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadSecrets reads the initial secrets. String values are stored as is,
+// other values as JSON.
+func loadSecrets(path string) (map[string][]byte, error) {
+	secrets := make(map[string][]byte)
+	if path == "" {
+		return secrets, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	for k, v := range doc {
+		var s string
+		if json.Unmarshal(v, &s) == nil {
+			secrets[k] = []byte(s)
+			continue
+		}
+		secrets[k] = v
+	}
+	return secrets, nil
+}
+
+func serverCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if clientCAFile != "" {
+		ca, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = x509.NewCertPool()
+		if !cfg.ClientCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New("unable to parse client CA certificates")
+		}
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(cfg), nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	provider "github.com/external-secrets/external-secrets/providers/v1/plugin"
+	"github.com/external-secrets/external-secrets/runtime/plugin"
+)
+
+// newTestClient serves the example plugin on a unix domain socket and
+// connects to it with the plugin provider.
+func newTestClient(t *testing.T, secrets map[string][]byte, token string) esv1.SecretsClient {
+	t.Helper()
+	// Socket paths are limited in length, t.TempDir can exceed it.
+	dir, err := os.MkdirTemp("", "plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	address := "unix://" + filepath.Join(dir, "plugin.sock")
+
+	lis, err := plugin.Listen(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = plugin.Serve(ctx, lis, newBackend(secrets, token).newClient)
+	}()
+
+	store := &esv1.SecretStore{
+		Spec: esv1.SecretStoreSpec{Provider: &esv1.SecretStoreProvider{Plugin: &esv1.PluginProvider{
+			Address: address,
+			Config:  &apiextensionsv1.JSON{Raw: []byte(`{"prefix":"team-a/"}`)},
+			SecretRefs: map[string]esmeta.SecretKeySelector{
+				tokenSecret: {Name: "plugin", Key: "token"},
+			},
+		}}},
+	}
+	store.Name = "plugin"
+	store.Namespace = "default"
+	kube := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: store.ObjectMeta,
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}).Build()
+
+	c, err := provider.NewProvider().NewClient(ctx, store, kube, store.Namespace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close(context.Background()) })
+	return c
+}
+
+func TestPlugin(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, map[string][]byte{
+		"team-a/db":  []byte(`{"username":"admin","password":"p4ss"}`),
+		"team-a/api": []byte("key"),
+		"team-b/api": []byte("other"),
+	}, "s3cr3t")
+
+	if result, err := c.Validate(); err != nil || result != esv1.ValidationResultReady {
+		t.Fatalf("Validate() = %v, %v", result, err)
+	}
+
+	value, err := c.GetSecret(ctx, esv1.ExternalSecretDataRemoteRef{Key: "db", Property: "password"})
+	if err != nil || string(value) != "p4ss" {
+		t.Errorf("GetSecret() = %q, %v", value, err)
+	}
+	if _, err := c.GetSecret(ctx, esv1.ExternalSecretDataRemoteRef{Key: "missing"}); !errors.Is(err, esv1.NoSecretErr) {
+		t.Errorf("GetSecret() of a missing secret error = %v, want NoSecretErr", err)
+	}
+
+	data, err := c.GetSecretMap(ctx, esv1.ExternalSecretDataRemoteRef{Key: "db"})
+	if err != nil || string(data["username"]) != "admin" || len(data) != 2 {
+		t.Errorf("GetSecretMap() = %v, %v", data, err)
+	}
+
+	all, err := c.GetAllSecrets(ctx, esv1.ExternalSecretFind{Name: &esv1.FindName{RegExp: "^a"}})
+	if err != nil || len(all) != 1 || string(all["api"]) != "key" {
+		t.Errorf("GetAllSecrets() = %v, %v", all, err)
+	}
+
+	secret := &corev1.Secret{Data: map[string][]byte{"token": []byte("t0ken")}}
+	pushData := esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{
+			SecretKey: "token",
+			RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "pushed", Property: "token"},
+		},
+	}
+	if err := c.PushSecret(ctx, secret, pushData); err != nil {
+		t.Fatalf("PushSecret() error = %v", err)
+	}
+	value, err = c.GetSecret(ctx, esv1.ExternalSecretDataRemoteRef{Key: "pushed", Property: "token"})
+	if err != nil || string(value) != "t0ken" {
+		t.Errorf("GetSecret() of the pushed secret = %q, %v", value, err)
+	}
+	exists, err := c.SecretExists(ctx, pushData.Match.RemoteRef)
+	if err != nil || !exists {
+		t.Errorf("SecretExists() = %v, %v", exists, err)
+	}
+	if err := c.DeleteSecret(ctx, pushData.Match.RemoteRef); err != nil {
+		t.Fatalf("DeleteSecret() error = %v", err)
+	}
+	exists, err = c.SecretExists(ctx, esv1alpha1.PushSecretRemoteRef{RemoteKey: "pushed"})
+	if err != nil || exists {
+		t.Errorf("SecretExists() after delete = %v, %v", exists, err)
+	}
+}
+
+func TestPluginInvalidToken(t *testing.T) {
+	c := newTestClient(t, nil, "other")
+	if _, err := c.GetSecret(context.Background(), esv1.ExternalSecretDataRemoteRef{Key: "db"}); err == nil || errors.Is(err, esv1.NoSecretErr) {
+		t.Errorf("GetSecret() with an invalid token error = %v", err)
+	}
+}
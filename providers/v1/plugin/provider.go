@@ -0,0 +1,264 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin implements a provider that proxies all calls to a provider
+// plugin, which runs in a separate process and is called over gRPC.
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+	pluginsdk "github.com/external-secrets/external-secrets/runtime/plugin"
+	pluginv1 "github.com/external-secrets/external-secrets/runtime/plugin/proto/v1"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	unixPrefix     = "unix://"
+
+	errNilStore          = "nil store"
+	errMissingProvider   = "missing: spec.provider.plugin"
+	errMissingAddress    = "missing: spec.provider.plugin.address"
+	errInvalidSocketPath = "spec.provider.plugin.address must be an absolute path for unix domain sockets"
+	errInvalidTimeout    = "spec.provider.plugin.timeout must be greater than 0"
+	errTLSRequired       = "spec.provider.plugin.tls is required for plugins that are not reached over a unix domain socket, unless spec.provider.plugin.insecure is set"
+	errInsecureTLS       = "spec.provider.plugin.insecure and spec.provider.plugin.tls must not be set together"
+	warnInsecure         = "spec.provider.plugin.insecure is set, secrets are sent to the plugin at %s unencrypted"
+	errClientCertKey     = "spec.provider.plugin.tls.certSecretRef and spec.provider.plugin.tls.keySecretRef must be set together"
+	errCANamespace       = "spec.provider.plugin.tls.caProvider.namespace is required for a ClusterSecretStore"
+	errInvalidSecretRef  = "invalid %s: %w"
+	errParseCA           = "unable to parse the CA certificates of spec.provider.plugin.tls"
+	errClientCert        = "unable to load the client certificate: %w"
+	errDial              = "unable to create a connection to the plugin: %w"
+)
+
+// Provider creates clients that proxy calls to a plugin.
+type Provider struct{}
+
+var _ esv1.Provider = &Provider{}
+
+// NewClient resolves the secret references of the store and connects to the plugin.
+func (p *Provider) NewClient(ctx context.Context, store esv1.GenericStore, kube client.Client, namespace string) (esv1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	storeKind := store.GetKind()
+
+	secrets := make(map[string][]byte, len(provider.SecretRefs))
+	for _, name := range slices.Sorted(maps.Keys(provider.SecretRefs)) {
+		ref := provider.SecretRefs[name]
+		value, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &ref)
+		if err != nil {
+			return nil, err
+		}
+		secrets[name] = []byte(value)
+	}
+
+	creds, err := transportCredentials(ctx, provider, kube, storeKind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(provider.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf(errDial, err)
+	}
+
+	c := &Client{
+		conn:    conn,
+		rpc:     pluginv1.NewSecretsProviderClient(conn),
+		timeout: defaultTimeout,
+		store: &pluginsdk.Store{
+			Kind:      storeKind,
+			Name:      store.GetName(),
+			Namespace: namespace,
+			Secrets:   secrets,
+		},
+	}
+	if provider.Timeout != nil {
+		c.timeout = provider.Timeout.Duration
+	}
+	if provider.Config != nil {
+		c.store.Config = provider.Config.Raw
+	}
+	return c, nil
+}
+
+// transportCredentials returns the credentials of the connection to the plugin. Connections
+// without TLS are only allowed to unix domain sockets, or if the store explicitly allows them.
+func transportCredentials(ctx context.Context, provider *esv1.PluginProvider, kube client.Client, storeKind, namespace string) (credentials.TransportCredentials, error) {
+	if provider.TLS == nil {
+		if !strings.HasPrefix(provider.Address, unixPrefix) && !provider.Insecure {
+			return nil, errors.New(errTLSRequired)
+		}
+		return insecure.NewCredentials(), nil
+	}
+	tlsConfig, err := newTLSConfig(ctx, provider.TLS, kube, storeKind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func newTLSConfig(ctx context.Context, spec *esv1.PluginTLS, kube client.Client, storeKind, namespace string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: spec.ServerName,
+	}
+	ca, err := esutils.FetchCACertFromSource(ctx, esutils.CreateCertOpts{
+		CABundle:   spec.CABundle,
+		CAProvider: spec.CAProvider,
+		StoreKind:  storeKind,
+		Namespace:  namespace,
+		Client:     kube,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ca) > 0 {
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New(errParseCA)
+		}
+	}
+
+	if spec.CertSecretRef != nil && spec.KeySecretRef != nil {
+		certRef, keyRef := *spec.CertSecretRef, *spec.KeySecretRef
+		if certRef.Key == "" {
+			certRef.Key = corev1.TLSCertKey
+		}
+		if keyRef.Key == "" {
+			keyRef.Key = corev1.TLSPrivateKeyKey
+		}
+		cert, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &certRef)
+		if err != nil {
+			return nil, err
+		}
+		key, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &keyRef)
+		if err != nil {
+			return nil, err
+		}
+		keyPair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf(errClientCert, err)
+		}
+		cfg.Certificates = []tls.Certificate{keyPair}
+	}
+	return cfg, nil
+}
+
+// ValidateStore checks the store configuration without connecting to the plugin.
+func (p *Provider) ValidateStore(store esv1.GenericStore) (admission.Warnings, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Address == "" {
+		return nil, errors.New(errMissingAddress)
+	}
+	if socket, ok := strings.CutPrefix(provider.Address, unixPrefix); ok && !path.IsAbs(socket) {
+		return nil, errors.New(errInvalidSocketPath)
+	}
+	if provider.Timeout != nil && provider.Timeout.Duration <= 0 {
+		return nil, errors.New(errInvalidTimeout)
+	}
+	var warnings admission.Warnings
+	unixSocket := strings.HasPrefix(provider.Address, unixPrefix)
+	switch {
+	case provider.Insecure && provider.TLS != nil:
+		return nil, errors.New(errInsecureTLS)
+	case provider.TLS == nil && !unixSocket && !provider.Insecure:
+		return nil, errors.New(errTLSRequired)
+	case provider.Insecure && !unixSocket:
+		warnings = append(warnings, fmt.Sprintf(warnInsecure, provider.Address))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(provider.SecretRefs)) {
+		if err := esutils.ValidateReferentSecretSelector(store, provider.SecretRefs[name]); err != nil {
+			return nil, fmt.Errorf(errInvalidSecretRef, "secretRefs."+name, err)
+		}
+	}
+
+	if t := provider.TLS; t != nil {
+		if (t.CertSecretRef == nil) != (t.KeySecretRef == nil) {
+			return nil, errors.New(errClientCertKey)
+		}
+		if t.CertSecretRef != nil {
+			if err := esutils.ValidateReferentSecretSelector(store, *t.CertSecretRef); err != nil {
+				return nil, fmt.Errorf(errInvalidSecretRef, "tls.certSecretRef", err)
+			}
+			if err := esutils.ValidateReferentSecretSelector(store, *t.KeySecretRef); err != nil {
+				return nil, fmt.Errorf(errInvalidSecretRef, "tls.keySecretRef", err)
+			}
+		}
+		if t.CAProvider != nil && store.GetKind() == esv1.ClusterSecretStoreKind && t.CAProvider.Namespace == nil {
+			return nil, errors.New(errCANamespace)
+		}
+	}
+	return warnings, nil
+}
+
+// Capabilities returns ReadWrite, plugins report unsupported operations on call.
+func (p *Provider) Capabilities() esv1.SecretStoreCapabilities {
+	return esv1.SecretStoreReadWrite
+}
+
+func getProvider(store esv1.GenericStore) (*esv1.PluginProvider, error) {
+	if store == nil {
+		return nil, errors.New(errNilStore)
+	}
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil || spec.Provider.Plugin == nil {
+		return nil, errors.New(errMissingProvider)
+	}
+	return spec.Provider.Plugin, nil
+}
+
+// NewProvider creates a new Provider instance.
+func NewProvider() esv1.Provider {
+	return &Provider{}
+}
+
+// ProviderSpec returns the provider specification for registration.
+func ProviderSpec() *esv1.SecretStoreProvider {
+	return &esv1.SecretStoreProvider{
+		Plugin: &esv1.PluginProvider{},
+	}
+}
+
+// MaintenanceStatus returns the maintenance status of the provider.
+func MaintenanceStatus() esv1.MaintenanceStatus {
+	return esv1.MaintenanceStatusMaintained
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestValidateStore(t *testing.T) {
+	other := "other"
+	tests := []struct {
+		name     string
+		kind     string
+		provider *esv1.PluginProvider
+		wantErr  bool
+		wantWarn bool
+	}{
+		{
+			name:     "valid unix socket",
+			provider: &esv1.PluginProvider{Address: "unix:///var/run/eso/plugin.sock"},
+		},
+		{
+			name: "valid tcp with mutual tls",
+			provider: &esv1.PluginProvider{
+				Address: "plugin.eso.svc:8443",
+				Timeout: &metav1.Duration{Duration: 5 * time.Second},
+				TLS: &esv1.PluginTLS{
+					CABundle:      []byte("ca"),
+					CertSecretRef: &esmeta.SecretKeySelector{Name: "plugin-client"},
+					KeySecretRef:  &esmeta.SecretKeySelector{Name: "plugin-client"},
+				},
+				SecretRefs: map[string]esmeta.SecretKeySelector{
+					"token": {Name: "plugin", Key: "token"},
+				},
+			},
+		},
+		{
+			name:     "tcp without tls",
+			provider: &esv1.PluginProvider{Address: "plugin.eso.svc:8443"},
+			wantErr:  true,
+		},
+		{
+			name:     "tcp with explicitly insecure connection",
+			provider: &esv1.PluginProvider{Address: "plugin.eso.svc:8443", Insecure: true},
+			wantWarn: true,
+		},
+		{
+			name: "insecure with tls",
+			provider: &esv1.PluginProvider{
+				Address:  "plugin.eso.svc:8443",
+				Insecure: true,
+				TLS:      &esv1.PluginTLS{CABundle: []byte("ca")},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "missing address",
+			provider: &esv1.PluginProvider{},
+			wantErr:  true,
+		},
+		{
+			name:     "relative socket path",
+			provider: &esv1.PluginProvider{Address: "unix://plugin.sock"},
+			wantErr:  true,
+		},
+		{
+			name:     "zero timeout",
+			provider: &esv1.PluginProvider{Address: "localhost:8443", Insecure: true, Timeout: &metav1.Duration{}},
+			wantErr:  true,
+		},
+		{
+			name: "secret ref in other namespace",
+			provider: &esv1.PluginProvider{
+				Address:  "localhost:8443",
+				Insecure: true,
+				SecretRefs: map[string]esmeta.SecretKeySelector{
+					"token": {Name: "plugin", Key: "token", Namespace: &other},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "client cert without key",
+			provider: &esv1.PluginProvider{
+				Address: "localhost:8443",
+				TLS:     &esv1.PluginTLS{CertSecretRef: &esmeta.SecretKeySelector{Name: "plugin-client"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cluster store ca provider without namespace",
+			kind: esv1.ClusterSecretStoreKind,
+			provider: &esv1.PluginProvider{
+				Address: "localhost:8443",
+				TLS: &esv1.PluginTLS{CAProvider: &esv1.CAProvider{
+					Type: esv1.CAProviderTypeConfigMap, Name: "plugin-ca", Key: "ca.crt",
+				}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := esv1.SecretStoreSpec{Provider: &esv1.SecretStoreProvider{Plugin: tt.provider}}
+			var store esv1.GenericStore = &esv1.SecretStore{Spec: spec}
+			if tt.kind == esv1.ClusterSecretStoreKind {
+				store = &esv1.ClusterSecretStore{Spec: spec}
+			}
+			store.SetNamespace("default")
+			warnings, err := NewProvider().ValidateStore(store)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (len(warnings) > 0) != tt.wantWarn {
+				t.Errorf("ValidateStore() warnings = %v, wantWarn %v", warnings, tt.wantWarn)
+			}
+		})
+	}
+}
@@ -100,3 +100,33 @@ func (c *Cache[T]) Add(version string, key Key, client T) {
 func (c *Cache[T]) Contains(key Key) bool {
 	return c.lru.Contains(key)
 }
+
+// Peek returns the value of the given key regardless of its version,
+// without updating the recentness of the key.
+func (c *Cache[T]) Peek(key Key) (T, bool) {
+	val, ok := c.lru.Peek(key)
+	if !ok {
+		return value[T]{}.Client, false
+	}
+	return val.(value[T]).Client, true
+}
+
+// Remove evicts the given key, the cleanup func is called if it exists.
+func (c *Cache[T]) Remove(key Key) {
+	c.lru.Remove(key)
+}
+
+// Keys returns the keys of the cache, from the oldest to the newest.
+func (c *Cache[T]) Keys() []Key {
+	keys := c.lru.Keys()
+	out := make([]Key, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, key.(Key))
+	}
+	return out
+}
+
+// Len returns the number of values in the cache.
+func (c *Cache[T]) Len() int {
+	return c.lru.Len()
+}
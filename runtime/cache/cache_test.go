@@ -100,3 +100,39 @@ func TestCacheEvict(t *testing.T) {
 	c.Add("", Key{Name: "bar"}, client{})
 	assert.True(t, cleanupCalled)
 }
+
+func TestCachePeek(t *testing.T) {
+	c, err := New[*client](2, nil)
+	if err != nil {
+		t.Fail()
+	}
+	cl := &client{}
+	c.Add("v1", Key{Name: "foo"}, cl)
+	c.Add("v1", Key{Name: "bar"}, &client{})
+
+	cachedVal, ok := c.Peek(Key{Name: "foo"})
+	assert.True(t, ok)
+	assert.Same(t, cl, cachedVal)
+	// peek does not update the recentness of foo
+	assert.Equal(t, []Key{{Name: "foo"}, {Name: "bar"}}, c.Keys())
+
+	_, ok = c.Peek(Key{Name: "does not exist"})
+	assert.False(t, ok)
+}
+
+func TestCacheRemove(t *testing.T) {
+	var cleanupCalled bool
+	c, err := New(2, func(*client) {
+		cleanupCalled = true
+	})
+	if err != nil {
+		t.Fail()
+	}
+	c.Add("", cacheKey, &client{})
+	assert.Equal(t, 1, c.Len())
+
+	c.Remove(cacheKey)
+	assert.True(t, cleanupCalled)
+	assert.Equal(t, 0, c.Len())
+	assert.Empty(t, c.Keys())
+}
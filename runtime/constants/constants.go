@@ -82,6 +82,7 @@ const (
 	CallKubernetesCreateSecret                 = "CreateSecret"
 	CallKubernetesDeleteSecret                 = "DeleteSecret"
 	CallKubernetesUpdateSecret                 = "UpdateSecret"
+	CallKubernetesWatchSecrets                 = "WatchSecrets"
 	CallKubernetesCreateSelfSubjectRulesReview = "CreateSelfSubjectRulesReview"
 
 	ProviderIBMSM                = "IBM/SecretsManager"
@@ -0,0 +1,195 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envelope implements age and OpenPGP encryption of secret values,
+// so values can be stored in a provider in encrypted form.
+package envelope
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+const (
+	errParseAgeIdentities = "unable to parse age identities: %w"
+	errParseAgeRecipients = "unable to parse age recipients: %w"
+	errAgeDecrypt         = "unable to decrypt age ciphertext: %w"
+	errAgeEncrypt         = "unable to encrypt with age: %w"
+	errParsePGPKey        = "unable to parse OpenPGP key: %w"
+	errPGPPassphrase      = "unable to decrypt OpenPGP private key: %w"
+	errPGPDecrypt         = "unable to decrypt OpenPGP message: %w"
+	errPGPEncrypt         = "unable to encrypt OpenPGP message: %w"
+
+	pgpMessageType = "PGP MESSAGE"
+
+	// MaxPlaintextSize is the maximum size of a decrypted value.
+	// It matches the maximum size of a Kubernetes Secret like esutils.MaxDecompressedSize,
+	// which can not be referenced here as esutils depends on this package.
+	MaxPlaintextSize = 1 << 20
+)
+
+var errNoPGPKey = errors.New("no OpenPGP key found")
+
+// AgeDecrypt decrypts an armored or binary age ciphertext with the given identities.
+// The identities are in the format of an age key file, one identity per line.
+func AgeDecrypt(identities string, ciphertext []byte) ([]byte, error) {
+	ids, err := age.ParseIdentities(strings.NewReader(identities))
+	if err != nil {
+		return nil, fmt.Errorf(errParseAgeIdentities, err)
+	}
+
+	var in io.Reader = bytes.NewReader(ciphertext)
+	if bytes.HasPrefix(bytes.TrimSpace(ciphertext), []byte(armor.Header)) {
+		in = armor.NewReader(bytes.NewReader(bytes.TrimSpace(ciphertext)))
+	}
+	r, err := age.Decrypt(in, ids...)
+	if err != nil {
+		return nil, fmt.Errorf(errAgeDecrypt, err)
+	}
+	out, err := readPlaintext(r)
+	if err != nil {
+		return nil, fmt.Errorf(errAgeDecrypt, err)
+	}
+	return out, nil
+}
+
+// AgeEncrypt encrypts the plaintext to the given recipients and returns an armored age ciphertext.
+// The recipients are in the format of an age recipients file, one recipient per line.
+func AgeEncrypt(recipients string, plaintext []byte) ([]byte, error) {
+	rcpts, err := age.ParseRecipients(strings.NewReader(recipients))
+	if err != nil {
+		return nil, fmt.Errorf(errParseAgeRecipients, err)
+	}
+
+	var buf bytes.Buffer
+	aw := armor.NewWriter(&buf)
+	w, err := age.Encrypt(aw, rcpts...)
+	if err != nil {
+		return nil, fmt.Errorf(errAgeEncrypt, err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, fmt.Errorf(errAgeEncrypt, err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf(errAgeEncrypt, err)
+	}
+	if err := aw.Close(); err != nil {
+		return nil, fmt.Errorf(errAgeEncrypt, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// PGPDecrypt decrypts an armored or binary OpenPGP message with the given private key.
+// The passphrase is used to unlock the private key if it is encrypted.
+// Signatures of the message are not verified.
+func PGPDecrypt(privateKey, passphrase string, ciphertext []byte) ([]byte, error) {
+	keyring, err := readPGPKeyRing(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase != "" {
+		for _, entity := range keyring {
+			if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+				return nil, fmt.Errorf(errPGPPassphrase, err)
+			}
+		}
+	}
+
+	var in io.Reader = bytes.NewReader(ciphertext)
+	if block, err := pgparmor.Decode(bytes.NewReader(ciphertext)); err == nil {
+		if block.Type != pgpMessageType {
+			return nil, fmt.Errorf(errPGPDecrypt, fmt.Errorf("unexpected armor type %q", block.Type))
+		}
+		in = block.Body
+	}
+	md, err := openpgp.ReadMessage(in, keyring, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errPGPDecrypt, err)
+	}
+	out, err := readPlaintext(md.UnverifiedBody)
+	if err != nil {
+		return nil, fmt.Errorf(errPGPDecrypt, err)
+	}
+	return out, nil
+}
+
+// PGPEncrypt encrypts the plaintext to the given public keys and returns an armored OpenPGP message.
+func PGPEncrypt(publicKeys string, plaintext []byte) ([]byte, error) {
+	keyring, err := readPGPKeyRing(publicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	aw, err := pgparmor.Encode(&buf, pgpMessageType, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errPGPEncrypt, err)
+	}
+	w, err := openpgp.Encrypt(aw, keyring, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errPGPEncrypt, err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, fmt.Errorf(errPGPEncrypt, err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf(errPGPEncrypt, err)
+	}
+	if err := aw.Close(); err != nil {
+		return nil, fmt.Errorf(errPGPEncrypt, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readPGPKeyRing reads an armored or binary OpenPGP key ring.
+// readPlaintext reads the decrypted value and fails if it exceeds MaxPlaintextSize.
+func readPlaintext(r io.Reader) ([]byte, error) {
+	out, err := io.ReadAll(io.LimitReader(r, MaxPlaintextSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > MaxPlaintextSize {
+		return nil, fmt.Errorf("decrypted value exceeds the maximum size of %d bytes", MaxPlaintextSize)
+	}
+	return out, nil
+}
+
+func readPGPKeyRing(key string) (openpgp.EntityList, error) {
+	var (
+		keyring openpgp.EntityList
+		err     error
+	)
+	if trimmed := strings.TrimSpace(key); strings.HasPrefix(trimmed, "-----BEGIN") {
+		keyring, err = openpgp.ReadArmoredKeyRing(strings.NewReader(trimmed))
+	} else {
+		keyring, err = openpgp.ReadKeyRing(strings.NewReader(key))
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errParsePGPKey, err)
+	}
+	if len(keyring) == 0 {
+		return nil, errNoPGPKey
+	}
+	return keyring, nil
+}
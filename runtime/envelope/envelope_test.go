@@ -0,0 +1,212 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+const plaintext = "very secret value"
+
+func TestAge(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	identities := "# created: 2025-01-01\n" + identity.String() + "\n"
+
+	ciphertext, err := AgeEncrypt(identity.Recipient().String(), []byte(plaintext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(ciphertext), "-----BEGIN AGE ENCRYPTED FILE-----") {
+		t.Fatalf("AgeEncrypt() expected armored output, got %q", ciphertext)
+	}
+	got, err := AgeDecrypt(identities, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != plaintext {
+		t.Errorf("AgeDecrypt() = %q, want %q", got, plaintext)
+	}
+
+	// binary ciphertext
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, identity.Recipient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.WriteString(w, plaintext)
+	_ = w.Close()
+	got, err = AgeDecrypt(identities, buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != plaintext {
+		t.Errorf("AgeDecrypt() = %q, want %q", got, plaintext)
+	}
+
+	if _, err := AgeDecrypt(other.String(), ciphertext); err == nil {
+		t.Error("AgeDecrypt() expected error for wrong identity")
+	}
+	if _, err := AgeDecrypt("invalid", ciphertext); err == nil {
+		t.Error("AgeDecrypt() expected error for invalid identity")
+	}
+	if _, err := AgeEncrypt("invalid", []byte(plaintext)); err == nil {
+		t.Error("AgeEncrypt() expected error for invalid recipient")
+	}
+
+	oversized, err := AgeEncrypt(identity.Recipient().String(), make([]byte, MaxPlaintextSize+1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AgeDecrypt(identities, oversized); err == nil {
+		t.Error("AgeDecrypt() expected error for a value exceeding MaxPlaintextSize")
+	}
+}
+
+func TestPGP(t *testing.T) {
+	entity, err := openpgp.NewEntity("eso", "test", "eso@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := armorKey(t, openpgp.PublicKeyType, func(w io.Writer) error {
+		return entity.Serialize(w)
+	})
+	privateKey := armorKey(t, openpgp.PrivateKeyType, func(w io.Writer) error {
+		return entity.SerializePrivate(w, nil)
+	})
+	if err := entity.EncryptPrivateKeys([]byte("passphrase"), nil); err != nil {
+		t.Fatal(err)
+	}
+	encryptedKey := armorKey(t, openpgp.PrivateKeyType, func(w io.Writer) error {
+		return entity.SerializePrivateWithoutSigning(w, nil)
+	})
+
+	ciphertext, err := PGPEncrypt(publicKey, []byte(plaintext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(ciphertext), "-----BEGIN PGP MESSAGE-----") {
+		t.Fatalf("PGPEncrypt() expected armored output, got %q", ciphertext)
+	}
+	oversized, err := PGPEncrypt(publicKey, make([]byte, MaxPlaintextSize+1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		passphrase string
+		ciphertext []byte
+		wantErr    bool
+	}{
+		{
+			name:       "armored message",
+			key:        privateKey,
+			ciphertext: ciphertext,
+		},
+		{
+			name:       "binary message",
+			key:        privateKey,
+			ciphertext: dearmor(t, ciphertext),
+		},
+		{
+			name:       "encrypted private key",
+			key:        encryptedKey,
+			passphrase: "passphrase",
+			ciphertext: ciphertext,
+		},
+		{
+			name:       "wrong passphrase",
+			key:        encryptedKey,
+			passphrase: "wrong",
+			ciphertext: ciphertext,
+			wantErr:    true,
+		},
+		{
+			name:       "missing passphrase",
+			key:        encryptedKey,
+			ciphertext: ciphertext,
+			wantErr:    true,
+		},
+		{
+			name:       "oversized message",
+			key:        privateKey,
+			ciphertext: oversized,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid key",
+			key:        "invalid",
+			ciphertext: ciphertext,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PGPDecrypt(tt.key, tt.passphrase, tt.ciphertext)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PGPDecrypt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != plaintext {
+				t.Errorf("PGPDecrypt() = %q, want %q", got, plaintext)
+			}
+		})
+	}
+}
+
+func armorKey(t *testing.T, blockType string, serialize func(io.Writer) error) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := pgparmor.Encode(&buf, blockType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func dearmor(t *testing.T, in []byte) []byte {
+	t.Helper()
+	block, err := pgparmor.Decode(bytes.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(block.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
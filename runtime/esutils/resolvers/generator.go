@@ -302,6 +302,50 @@ func clusterGeneratorToVirtual(gen *genv1alpha1.ClusterGenerator) (client.Object
 			},
 			Spec: *gen.Spec.Generator.MFASpec,
 		}, nil
+	case genv1alpha1.GeneratorKindCryptoKey:
+		if gen.Spec.Generator.CryptoKeySpec == nil {
+			return nil, fmt.Errorf("when kind is %s, CryptoKeySpec must be set", gen.Spec.Kind)
+		}
+		return &genv1alpha1.CryptoKey{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+				Kind:       genv1alpha1.CryptoKeyKind,
+			},
+			Spec: *gen.Spec.Generator.CryptoKeySpec,
+		}, nil
+	case genv1alpha1.GeneratorKindGeneratorPipeline:
+		if gen.Spec.Generator.GeneratorPipelineSpec == nil {
+			return nil, fmt.Errorf("when kind is %s, GeneratorPipelineSpec must be set", gen.Spec.Kind)
+		}
+		return &genv1alpha1.GeneratorPipeline{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+				Kind:       genv1alpha1.GeneratorPipelineKind,
+			},
+			Spec: *gen.Spec.Generator.GeneratorPipelineSpec,
+		}, nil
+	case genv1alpha1.GeneratorKindServiceAccountToken:
+		if gen.Spec.Generator.ServiceAccountTokenSpec == nil {
+			return nil, fmt.Errorf("when kind is %s, ServiceAccountTokenSpec must be set", gen.Spec.Kind)
+		}
+		return &genv1alpha1.ServiceAccountToken{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+				Kind:       genv1alpha1.ServiceAccountTokenKind,
+			},
+			Spec: *gen.Spec.Generator.ServiceAccountTokenSpec,
+		}, nil
+	case genv1alpha1.GeneratorKindHarborRobotAccount:
+		if gen.Spec.Generator.HarborRobotAccountSpec == nil {
+			return nil, fmt.Errorf("when kind is %s, HarborRobotAccountSpec must be set", gen.Spec.Kind)
+		}
+		return &genv1alpha1.HarborRobotAccount{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+				Kind:       genv1alpha1.HarborRobotAccountKind,
+			},
+			Spec: *gen.Spec.Generator.HarborRobotAccountSpec,
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown kind %s", gen.Spec.Kind)
 	}
@@ -19,14 +19,18 @@ package esutils
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"crypto/sha3"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"net"
 	"net/url"
@@ -40,6 +44,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/PaesslerAG/jsonpath"
 	"github.com/go-logr/logr"
 	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -96,6 +101,55 @@ func RewriteMap(operations []esv1.ExternalSecretRewrite, in map[string][]byte) (
 	return out, nil
 }
 
+// RewriteMapOrigins rewrites the keys like RewriteMap, and additionally returns
+// the keys of the input map every key of the output map has been derived from.
+func RewriteMapOrigins(operations []esv1.ExternalSecretRewrite, in map[string][]byte) (map[string][]byte, map[string][]string, error) {
+	out := in
+	origins := identityOrigins(in)
+	for i, op := range operations {
+		rewritten, err := handleRewriteOperation(op, out)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed rewrite operation[%v]: %w", i, err)
+		}
+		origins = traceOrigins(out, rewritten, origins, func(m map[string][]byte) (map[string][]byte, error) {
+			return handleRewriteOperation(op, m)
+		})
+		out = rewritten
+	}
+	return out, origins, nil
+}
+
+func identityOrigins(in map[string][]byte) map[string][]string {
+	origins := make(map[string][]string, len(in))
+	for key := range in {
+		origins[key] = []string{key}
+	}
+	return origins
+}
+
+// traceOrigins traces the keys of out back to the origins of the keys of in,
+// by applying the operation that produced out to every key of in on its own.
+// Keys the operation fails for on their own are passed through, e.g. the keys an extract does not read.
+func traceOrigins(in, out map[string][]byte, origins map[string][]string, operation func(map[string][]byte) (map[string][]byte, error)) map[string][]string {
+	traced := make(map[string][]string, len(out))
+	for key, value := range in {
+		single, err := operation(map[string][]byte{key: value})
+		if err != nil {
+			single = map[string][]byte{key: value}
+		}
+		for newKey := range single {
+			if _, ok := out[newKey]; ok {
+				traced[newKey] = append(traced[newKey], origins[key]...)
+			}
+		}
+	}
+	for key, keyOrigins := range traced {
+		slices.Sort(keyOrigins)
+		traced[key] = slices.Compact(keyOrigins)
+	}
+	return traced
+}
+
 func handleRewriteOperation(op esv1.ExternalSecretRewrite, in map[string][]byte) (map[string][]byte, error) {
 	switch {
 	case op.Merge != nil:
@@ -104,6 +158,12 @@ func handleRewriteOperation(op esv1.ExternalSecretRewrite, in map[string][]byte)
 		return RewriteRegexp(*op.Regexp, in)
 	case op.Transform != nil:
 		return RewriteTransform(*op.Transform, in)
+	case op.Flatten != nil:
+		return RewriteFlatten(*op.Flatten, in)
+	case op.Extract != nil:
+		return RewriteExtract(*op.Extract, in)
+	case op.Filter != nil:
+		return RewriteFilter(*op.Filter, in)
 	default:
 		return in, nil
 	}
@@ -167,7 +227,7 @@ func merge(operation esv1.ExternalSecretRewriteMerge, in map[string][]byte) (map
 			return nil, nil, fmt.Errorf("merge failed with key %q not found in input map", key)
 		}
 		var jsonMap map[string]any
-		if err := json.Unmarshal(value, &jsonMap); err != nil {
+		if err := unmarshalJSON(value, &jsonMap); err != nil {
 			return nil, nil, fmt.Errorf("merge failed with failed to unmarshal JSON: %w", err)
 		}
 
@@ -230,6 +290,166 @@ func RewriteTransform(operation esv1.ExternalSecretRewriteTransform, in map[stri
 	return out, nil
 }
 
+// RewriteFlatten replaces JSON object and array values with one key per nested value.
+// The keys of nested values are joined with the separator, array items use their index as key.
+// Values that are no JSON objects or arrays are kept as is.
+func RewriteFlatten(operation esv1.ExternalSecretRewriteFlatten, in map[string][]byte) (map[string][]byte, error) {
+	separator := operation.Separator
+	if separator == "" {
+		separator = "_"
+	}
+	out := make(map[string][]byte, len(in))
+	add := func(key string, value []byte) error {
+		if _, conflict := out[key]; conflict {
+			return fmt.Errorf("flatten failed with conflicting key %q", key)
+		}
+		out[key] = value
+		return nil
+	}
+	for _, key := range slices.Sorted(maps.Keys(in)) {
+		var value any
+		if err := unmarshalJSON(in[key], &value); err != nil || !isNonEmptyContainer(value) {
+			if err := add(key, in[key]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := flatten(key, value, separator, 0, operation.MaxDepth, add); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func flatten(prefix string, value any, separator string, level, maxDepth int, add func(string, []byte) error) error {
+	var children map[string]any
+	switch v := value.(type) {
+	case map[string]any:
+		children = v
+	case []any:
+		children = make(map[string]any, len(v))
+		for i, item := range v {
+			children[strconv.Itoa(i)] = item
+		}
+	}
+	if !isNonEmptyContainer(value) || (maxDepth > 0 && level >= maxDepth) {
+		byteValue, err := GetByteValue(value)
+		if err != nil {
+			return fmt.Errorf("flatten failed with failed to convert value to []byte: %w", err)
+		}
+		return add(prefix, byteValue)
+	}
+	for _, k := range slices.Sorted(maps.Keys(children)) {
+		if err := flatten(prefix+separator+k, children[k], separator, level+1, maxDepth, add); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isNonEmptyContainer(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	}
+	return false
+}
+
+// RewriteExtract adds new keys with the results of JSONPath expressions evaluated against the source value.
+func RewriteExtract(operation esv1.ExternalSecretRewriteExtract, in map[string][]byte) (map[string][]byte, error) {
+	source, ok := in[operation.Source]
+	if !ok {
+		return nil, fmt.Errorf("extract failed with key %q not found in input map", operation.Source)
+	}
+	var data any
+	if err := unmarshalJSON(source, &data); err != nil {
+		return nil, fmt.Errorf("extract failed with failed to unmarshal JSON of key %q: %w", operation.Source, err)
+	}
+	data = exactNumbers(data)
+
+	out := maps.Clone(in)
+	if !operation.KeepSource {
+		delete(out, operation.Source)
+	}
+	for _, key := range slices.Sorted(maps.Keys(operation.Keys)) {
+		path := operation.Keys[key]
+		value, err := jsonpath.Get(path, data)
+		if err != nil {
+			return nil, fmt.Errorf("extract failed with failed to get path %q: %w", path, err)
+		}
+		byteValue, err := GetByteValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("extract failed with failed to convert value of path %q to []byte: %w", path, err)
+		}
+		out[key] = byteValue
+	}
+	return out, nil
+}
+
+// unmarshalJSON unmarshals data like json.Unmarshal, but keeps numbers as json.Number,
+// so that rewrites write them as they are instead of e.g. 1.50 as 1.5.
+func unmarshalJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid data after top-level value")
+	}
+	return nil
+}
+
+// exactNumbers replaces the json.Numbers that float64 represents with the same text by float64,
+// so that JSONPath filters can compare them. Other numbers, e.g. large integers, are kept as they are.
+func exactNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == v.String() {
+			return f
+		}
+		return v
+	case map[string]any:
+		for k, val := range v {
+			v[k] = exactNumbers(val)
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = exactNumbers(val)
+		}
+	}
+	return value
+}
+
+// RewriteFilter keeps the keys that match the include expression and do not match the exclude expression.
+func RewriteFilter(operation esv1.ExternalSecretRewriteFilter, in map[string][]byte) (map[string][]byte, error) {
+	var include, exclude *regexp.Regexp
+	var err error
+	if operation.Include != "" {
+		if include, err = regexp.Compile(operation.Include); err != nil {
+			return nil, fmt.Errorf("filter failed with failed to compile include: %w", err)
+		}
+	}
+	if operation.Exclude != "" {
+		if exclude, err = regexp.Compile(operation.Exclude); err != nil {
+			return nil, fmt.Errorf("filter failed with failed to compile exclude: %w", err)
+		}
+	}
+	out := make(map[string][]byte, len(in))
+	for key, value := range in {
+		if include != nil && !include.MatchString(key) {
+			continue
+		}
+		if exclude != nil && exclude.MatchString(key) {
+			continue
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
 // DecodeMap decodes values from a secretMap.
 func DecodeMap(strategy esv1.ExternalSecretDecodingStrategy, in map[string][]byte) (map[string][]byte, error) {
 	out := make(map[string][]byte, len(in))
@@ -243,7 +463,12 @@ func DecodeMap(strategy esv1.ExternalSecretDecodingStrategy, in map[string][]byt
 	return out, nil
 }
 
+// MaxDecompressedSize is the maximum size of a value after decompression.
+// It matches the maximum size of a Kubernetes Secret and guards against decompression bombs.
+const MaxDecompressedSize = 1 << 20
+
 // Decode decodes the input byte slice according to the provided decoding strategy.
+// Chained strategies like `Base64+Gzip` are applied from left to right.
 func Decode(strategy esv1.ExternalSecretDecodingStrategy, in []byte) ([]byte, error) {
 	switch strategy {
 	case esv1.ExternalSecretDecodeBase64:
@@ -258,6 +483,20 @@ func Decode(strategy esv1.ExternalSecretDecodingStrategy, in []byte) ([]byte, er
 			return nil, err
 		}
 		return out, nil
+	case esv1.ExternalSecretDecodeHex:
+		return hex.DecodeString(string(bytes.TrimSpace(in)))
+	case esv1.ExternalSecretDecodeGzip:
+		r, err := gzip.NewReader(bytes.NewReader(in))
+		if err != nil {
+			return nil, err
+		}
+		return decompress(r)
+	case esv1.ExternalSecretDecodeZlib:
+		r, err := zlib.NewReader(bytes.NewReader(in))
+		if err != nil {
+			return nil, err
+		}
+		return decompress(r)
 	case esv1.ExternalSecretDecodeNone:
 		return in, nil
 	// default when stored version is v1alpha1
@@ -273,9 +512,97 @@ func Decode(strategy esv1.ExternalSecretDecodingStrategy, in []byte) ([]byte, er
 			return out, nil
 		}
 		return out, nil
-	default:
+	}
+	steps := strings.Split(string(strategy), "+")
+	if len(steps) < 2 {
 		return nil, fmt.Errorf("decoding strategy %v is not supported", strategy)
 	}
+	var err error
+	for _, step := range steps {
+		if step == string(esv1.ExternalSecretDecodeAuto) {
+			return nil, fmt.Errorf("decoding strategy %v is not supported", strategy)
+		}
+		in, err = Decode(esv1.ExternalSecretDecodingStrategy(step), in)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", step, err)
+		}
+	}
+	return in, nil
+}
+
+func decompress(r io.ReadCloser) ([]byte, error) {
+	defer func() {
+		_ = r.Close()
+	}()
+	out, err := io.ReadAll(io.LimitReader(r, MaxDecompressedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > MaxDecompressedSize {
+		return nil, fmt.Errorf("decompressed value exceeds the maximum size of %d bytes", MaxDecompressedSize)
+	}
+	return out, nil
+}
+
+// EncodeMap encodes the values of the given keys according to the provided encoding strategy.
+// All values are encoded if no key is given.
+func EncodeMap(strategy esv1alpha1.PushSecretEncodingStrategy, in map[string][]byte, keys ...string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		if len(keys) > 0 && !slices.Contains(keys, k) {
+			out[k] = v
+			continue
+		}
+		val, err := Encode(strategy, v)
+		if err != nil {
+			return nil, fmt.Errorf("failure encoding key %v: %w", k, err)
+		}
+		out[k] = val
+	}
+	return out, nil
+}
+
+// Encode encodes the input byte slice according to the provided encoding strategy.
+// It is the inverse of Decode, chained strategies like `Base64+Gzip` are applied from right to left.
+func Encode(strategy esv1alpha1.PushSecretEncodingStrategy, in []byte) ([]byte, error) {
+	switch strategy {
+	case esv1alpha1.PushSecretEncodeNone, "":
+		return in, nil
+	case esv1alpha1.PushSecretEncodeBase64:
+		return []byte(base64.StdEncoding.EncodeToString(in)), nil
+	case esv1alpha1.PushSecretEncodeBase64URL:
+		return []byte(base64.URLEncoding.EncodeToString(in)), nil
+	case esv1alpha1.PushSecretEncodeHex:
+		return []byte(hex.EncodeToString(in)), nil
+	case esv1alpha1.PushSecretEncodeGzip:
+		var buf bytes.Buffer
+		return compress(&buf, gzip.NewWriter(&buf), in)
+	case esv1alpha1.PushSecretEncodeZlib:
+		var buf bytes.Buffer
+		return compress(&buf, zlib.NewWriter(&buf), in)
+	}
+	steps := strings.Split(string(strategy), "+")
+	if len(steps) < 2 {
+		return nil, fmt.Errorf("encoding strategy %v is not supported", strategy)
+	}
+	var err error
+	for _, step := range slices.Backward(steps) {
+		in, err = Encode(esv1alpha1.PushSecretEncodingStrategy(step), in)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", step, err)
+		}
+	}
+	return in, nil
+}
+
+func compress(buf *bytes.Buffer, w io.WriteCloser, in []byte) ([]byte, error) {
+	if _, err := w.Write(in); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 // ValidateKeys checks if the keys in the secret map are valid keys for a Kubernetes secret.
@@ -315,6 +642,20 @@ func ConvertKeys(strategy esv1.ExternalSecretConversionStrategy, in map[string][
 	return out, nil
 }
 
+// ConvertKeysOrigins converts the keys like ConvertKeys, and additionally returns
+// the key of the input map every key of the output map has been converted from.
+func ConvertKeysOrigins(strategy esv1.ExternalSecretConversionStrategy, in map[string][]byte) (map[string][]byte, map[string][]string, error) {
+	out, err := ConvertKeys(strategy, in)
+	if err != nil {
+		return nil, nil, err
+	}
+	origins := make(map[string][]string, len(in))
+	for key := range in {
+		origins[convert(strategy, key)] = []string{key}
+	}
+	return out, origins, nil
+}
+
 func convert(strategy esv1.ExternalSecretConversionStrategy, str string) string {
 	rs := []rune(str)
 	newName := make([]string, len(rs))
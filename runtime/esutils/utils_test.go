@@ -399,6 +399,116 @@ func TestDecode(t *testing.T) {
 				"b64url":     []byte(base64DecodedValue),
 			},
 		},
+		{
+			name: "hex decoded",
+			args: args{
+				strategy: esv1.ExternalSecretDecodeHex,
+				in: map[string][]byte{
+					"foo": []byte("626172\n"),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte("bar"),
+			},
+		},
+		{
+			name: "invalid hex",
+			args: args{
+				strategy: esv1.ExternalSecretDecodeHex,
+				in: map[string][]byte{
+					"foo": []byte("xyz"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gzip decompressed",
+			args: args{
+				strategy: esv1.ExternalSecretDecodeGzip,
+				in: map[string][]byte{
+					"foo": compressed(t, esv1alpha1.PushSecretEncodeGzip, []byte(base64DecodedValue)),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte(base64DecodedValue),
+			},
+		},
+		{
+			name: "zlib decompressed",
+			args: args{
+				strategy: esv1.ExternalSecretDecodeZlib,
+				in: map[string][]byte{
+					"foo": compressed(t, esv1alpha1.PushSecretEncodeZlib, []byte(base64DecodedValue)),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte(base64DecodedValue),
+			},
+		},
+		{
+			name: "base64 decoded and gzip decompressed",
+			args: args{
+				strategy: esv1.ExternalSecretDecodeBase64Gzip,
+				in: map[string][]byte{
+					"foo": compressed(t, esv1alpha1.PushSecretEncodeBase64Gzip, []byte(base64DecodedValue)),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte(base64DecodedValue),
+			},
+		},
+		{
+			name: "base64 decoded and zlib decompressed",
+			args: args{
+				strategy: esv1.ExternalSecretDecodeBase64Zlib,
+				in: map[string][]byte{
+					"foo": compressed(t, esv1alpha1.PushSecretEncodeBase64Zlib, []byte(base64DecodedValue)),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte(base64DecodedValue),
+			},
+		},
+		{
+			name: "invalid gzip",
+			args: args{
+				strategy: esv1.ExternalSecretDecodeGzip,
+				in: map[string][]byte{
+					"foo": []byte("foo"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gzip exceeding the maximum size",
+			args: args{
+				strategy: esv1.ExternalSecretDecodeGzip,
+				in: map[string][]byte{
+					"foo": compressed(t, esv1alpha1.PushSecretEncodeGzip, make([]byte, MaxDecompressedSize+1)),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auto in chain is not supported",
+			args: args{
+				strategy: "Auto+Gzip",
+				in: map[string][]byte{
+					"foo": []byte("foo"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported strategy",
+			args: args{
+				strategy: "Rot13",
+				in: map[string][]byte{
+					"foo": []byte("foo"),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -413,6 +523,57 @@ func TestDecode(t *testing.T) {
 		})
 	}
 }
+func compressed(t *testing.T, strategy esv1alpha1.PushSecretEncodingStrategy, in []byte) []byte {
+	t.Helper()
+	out, err := Encode(strategy, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		encoding esv1alpha1.PushSecretEncodingStrategy
+		decoding esv1.ExternalSecretDecodingStrategy
+	}{
+		{esv1alpha1.PushSecretEncodeNone, esv1.ExternalSecretDecodeNone},
+		{esv1alpha1.PushSecretEncodeBase64, esv1.ExternalSecretDecodeBase64},
+		{esv1alpha1.PushSecretEncodeBase64URL, esv1.ExternalSecretDecodeBase64URL},
+		{esv1alpha1.PushSecretEncodeHex, esv1.ExternalSecretDecodeHex},
+		{esv1alpha1.PushSecretEncodeGzip, esv1.ExternalSecretDecodeGzip},
+		{esv1alpha1.PushSecretEncodeZlib, esv1.ExternalSecretDecodeZlib},
+		{esv1alpha1.PushSecretEncodeBase64Gzip, esv1.ExternalSecretDecodeBase64Gzip},
+		{esv1alpha1.PushSecretEncodeBase64Zlib, esv1.ExternalSecretDecodeBase64Zlib},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.encoding), func(t *testing.T) {
+			in := map[string][]byte{
+				"foo": []byte(base64DecodedValue),
+				"bar": []byte("untouched"),
+			}
+			encoded, err := EncodeMap(tt.encoding, in, "foo")
+			if err != nil {
+				t.Fatalf("EncodeMap() error = %v", err)
+			}
+			if string(encoded["bar"]) != "untouched" {
+				t.Errorf("EncodeMap() encoded key bar = %q", encoded["bar"])
+			}
+			got, err := Decode(tt.decoding, encoded["foo"])
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if string(got) != base64DecodedValue {
+				t.Errorf("Decode(Encode()) = %q, want %q", got, base64DecodedValue)
+			}
+		})
+	}
+
+	if _, err := Encode("Rot13", []byte("foo")); err == nil {
+		t.Error("Encode() expected error for unsupported strategy")
+	}
+}
+
 func TestValidate(t *testing.T) {
 	err := NetworkValidate("http://google.com", 10*time.Second)
 	if err != nil {
@@ -648,6 +809,33 @@ func TestRewrite(t *testing.T) {
 				"APP_KEY": []byte("bar"),
 			},
 		},
+		{
+			name: "using extract, flatten and filter",
+			args: args{
+				operations: []esv1.ExternalSecretRewrite{
+					{
+						Extract: &esv1.ExternalSecretRewriteExtract{
+							Source: "app",
+							Keys:   map[string]string{"db": "$.database"},
+						},
+					},
+					{
+						Flatten: &esv1.ExternalSecretRewriteFlatten{},
+					},
+					{
+						Filter: &esv1.ExternalSecretRewriteFilter{
+							Exclude: "^db_debug$",
+						},
+					},
+				},
+				in: map[string][]byte{
+					"app": []byte(`{"database": {"host": "db", "debug": true}, "other": "value"}`),
+				},
+			},
+			want: map[string][]byte{
+				"db_host": []byte("db"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -830,6 +1018,304 @@ func TestRewriteMerge(t *testing.T) {
 		})
 	}
 }
+func TestRewriteFlatten(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation esv1.ExternalSecretRewriteFlatten
+		in        map[string][]byte
+		want      map[string][]byte
+		wantErr   bool
+	}{
+		{
+			name:      "nested objects and arrays",
+			operation: esv1.ExternalSecretRewriteFlatten{},
+			in: map[string][]byte{
+				"db":    []byte(`{"host": "db.example.com", "port": 5432, "replicas": ["a", "b"], "tls": {"enabled": true, "ca": null}}`),
+				"plain": []byte("not json"),
+				"num":   []byte("123"),
+				"empty": []byte("{}"),
+			},
+			want: map[string][]byte{
+				"db_host":        []byte("db.example.com"),
+				"db_port":        []byte("5432"),
+				"db_replicas_0":  []byte("a"),
+				"db_replicas_1":  []byte("b"),
+				"db_tls_enabled": []byte("true"),
+				"db_tls_ca":      []byte(nil),
+				"plain":          []byte("not json"),
+				"num":            []byte("123"),
+				"empty":          []byte("{}"),
+			},
+		},
+		{
+			name:      "numbers are kept as they are",
+			operation: esv1.ExternalSecretRewriteFlatten{},
+			in: map[string][]byte{
+				"db": []byte(`{"id": 12345678901234567890, "price": 1.50, "scale": 1e3, "port": 5432, "ratio": 0.25, "nested": {"id": 9007199254740993}}`),
+			},
+			want: map[string][]byte{
+				"db_id":        []byte("12345678901234567890"),
+				"db_price":     []byte("1.50"),
+				"db_scale":     []byte("1e3"),
+				"db_port":      []byte("5432"),
+				"db_ratio":     []byte("0.25"),
+				"db_nested_id": []byte("9007199254740993"),
+			},
+		},
+		{
+			name:      "separator and max depth",
+			operation: esv1.ExternalSecretRewriteFlatten{Separator: ".", MaxDepth: 1},
+			in: map[string][]byte{
+				"db": []byte(`{"host": "db.example.com", "tls": {"enabled": true}}`),
+			},
+			want: map[string][]byte{
+				"db.host": []byte("db.example.com"),
+				"db.tls":  []byte(`{"enabled":true}`),
+			},
+		},
+		{
+			name:      "conflicting keys",
+			operation: esv1.ExternalSecretRewriteFlatten{},
+			in: map[string][]byte{
+				"db":      []byte(`{"host": "db.example.com"}`),
+				"db_host": []byte("other"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RewriteFlatten(tt.operation, tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RewriteFlatten() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RewriteFlatten() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteExtract(t *testing.T) {
+	in := map[string][]byte{
+		"creds": []byte(`{"user": {"name": "admin", "roles": ["a", "b"]}, "port": 8080}`),
+		"other": []byte("value"),
+	}
+	tests := []struct {
+		name      string
+		operation esv1.ExternalSecretRewriteExtract
+		in        map[string][]byte
+		want      map[string][]byte
+		wantErr   bool
+	}{
+		{
+			name: "extract fields",
+			operation: esv1.ExternalSecretRewriteExtract{
+				Source: "creds",
+				Keys: map[string]string{
+					"username": "$.user.name",
+					"port":     "$.port",
+					"roles":    "$.user.roles",
+					"role":     "$.user.roles[0]",
+				},
+			},
+			want: map[string][]byte{
+				"username": []byte("admin"),
+				"port":     []byte("8080"),
+				"roles":    []byte(`["a","b"]`),
+				"role":     []byte("a"),
+				"other":    []byte("value"),
+			},
+		},
+		{
+			name: "numbers are kept as they are",
+			in: map[string][]byte{
+				"numbers": []byte(`{"id": 12345678901234567890, "price": 1.50, "ids": [12345678901234567890, 1], "servers": [{"name": "web", "port": 8080}, {"name": "db", "port": 5432}]}`),
+			},
+			operation: esv1.ExternalSecretRewriteExtract{
+				Source: "numbers",
+				Keys: map[string]string{
+					"id":     "$.id",
+					"price":  "$.price",
+					"ids":    "$.ids",
+					"filter": "$.servers[?(@.port == 8080)].name",
+				},
+			},
+			want: map[string][]byte{
+				"id":     []byte("12345678901234567890"),
+				"price":  []byte("1.50"),
+				"ids":    []byte(`[12345678901234567890,1]`),
+				"filter": []byte(`["web"]`),
+			},
+		},
+		{
+			name: "keep source",
+			operation: esv1.ExternalSecretRewriteExtract{
+				Source:     "creds",
+				Keys:       map[string]string{"username": "$.user.name"},
+				KeepSource: true,
+			},
+			want: map[string][]byte{
+				"username": []byte("admin"),
+				"creds":    in["creds"],
+				"other":    []byte("value"),
+			},
+		},
+		{
+			name: "missing path",
+			operation: esv1.ExternalSecretRewriteExtract{
+				Source: "creds",
+				Keys:   map[string]string{"password": "$.password"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing source",
+			operation: esv1.ExternalSecretRewriteExtract{
+				Source: "missing",
+				Keys:   map[string]string{"username": "$.user.name"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "source is no JSON",
+			operation: esv1.ExternalSecretRewriteExtract{
+				Source: "other",
+				Keys:   map[string]string{"username": "$.user.name"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := in
+			if tt.in != nil {
+				input = tt.in
+			}
+			got, err := RewriteExtract(tt.operation, input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RewriteExtract() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RewriteExtract() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteMapOrigins(t *testing.T) {
+	in := map[string][]byte{
+		"db/user":     []byte(`{"name": "admin"}`),
+		"db/password": []byte(`{"value": "secret"}`),
+		"cache":       []byte(`{"token": "t"}`),
+	}
+	operations := []esv1.ExternalSecretRewrite{
+		{Regexp: &esv1.ExternalSecretRewriteRegexp{Source: "db/(.*)", Target: "$1"}},
+		{Merge: &esv1.ExternalSecretRewriteMerge{Strategy: esv1.ExternalSecretRewriteMergeStrategyJSON, Into: "all"}},
+		{Extract: &esv1.ExternalSecretRewriteExtract{Source: "user", Keys: map[string]string{"username": "$.name"}}},
+		{Filter: &esv1.ExternalSecretRewriteFilter{Exclude: "^password$"}},
+	}
+	wantOut, err := RewriteMap(operations, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, origins, err := RewriteMapOrigins(operations, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, wantOut) {
+		t.Errorf("RewriteMapOrigins() = %v, want %v", out, wantOut)
+	}
+	want := map[string][]string{
+		"username": {"db/user"},
+		"cache":    {"cache"},
+		"all":      {"cache", "db/password", "db/user"},
+	}
+	if !reflect.DeepEqual(origins, want) {
+		t.Errorf("RewriteMapOrigins() origins = %v, want %v", origins, want)
+	}
+
+	if _, _, err := RewriteMapOrigins([]esv1.ExternalSecretRewrite{{Regexp: &esv1.ExternalSecretRewriteRegexp{Source: "("}}}, in); err == nil {
+		t.Error("RewriteMapOrigins() error = nil, want an error")
+	}
+}
+
+func TestConvertKeysOrigins(t *testing.T) {
+	in := map[string][]byte{keyWithInvalidChars: []byte("a"), "valid": []byte("b")}
+	out, origins, err := ConvertKeysOrigins(esv1.ExternalSecretConversionUnicode, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, map[string][]byte{keyWithEncodedInvalidChars: []byte("a"), "valid": []byte("b")}) {
+		t.Errorf("ConvertKeysOrigins() = %v", out)
+	}
+	want := map[string][]string{keyWithEncodedInvalidChars: {keyWithInvalidChars}, "valid": {"valid"}}
+	if !reflect.DeepEqual(origins, want) {
+		t.Errorf("ConvertKeysOrigins() origins = %v, want %v", origins, want)
+	}
+}
+
+func TestRewriteFilter(t *testing.T) {
+	in := map[string][]byte{
+		"app_user":     []byte("a"),
+		"app_password": []byte("b"),
+		"app_debug":    []byte("c"),
+		"other":        []byte("d"),
+	}
+	tests := []struct {
+		name      string
+		operation esv1.ExternalSecretRewriteFilter
+		want      map[string][]byte
+		wantErr   bool
+	}{
+		{
+			name:      "include",
+			operation: esv1.ExternalSecretRewriteFilter{Include: "^app_"},
+			want: map[string][]byte{
+				"app_user":     []byte("a"),
+				"app_password": []byte("b"),
+				"app_debug":    []byte("c"),
+			},
+		},
+		{
+			name:      "exclude",
+			operation: esv1.ExternalSecretRewriteFilter{Exclude: "debug"},
+			want: map[string][]byte{
+				"app_user":     []byte("a"),
+				"app_password": []byte("b"),
+				"other":        []byte("d"),
+			},
+		},
+		{
+			name:      "include and exclude",
+			operation: esv1.ExternalSecretRewriteFilter{Include: "^app_", Exclude: "debug"},
+			want: map[string][]byte{
+				"app_user":     []byte("a"),
+				"app_password": []byte("b"),
+			},
+		},
+		{
+			name:      "invalid regexp",
+			operation: esv1.ExternalSecretRewriteFilter{Include: "("},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RewriteFilter(tt.operation, in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RewriteFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RewriteFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestReverse(t *testing.T) {
 	type args struct {
 		strategy esv1alpha1.PushSecretConversionStrategy
@@ -0,0 +1,243 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	pluginv1 "github.com/external-secrets/external-secrets/runtime/plugin/proto/v1"
+)
+
+const errPlugin = "plugin error: %s"
+
+// RemoteRefToProto converts the remote ref of an ExternalSecret.
+// Decoding and conversion strategies are applied by the controller and not sent.
+func RemoteRefToProto(ref esv1.ExternalSecretDataRemoteRef) *pluginv1.RemoteRef {
+	return &pluginv1.RemoteRef{
+		Key:            ref.Key,
+		Property:       ref.Property,
+		Version:        ref.Version,
+		MetadataPolicy: string(ref.MetadataPolicy),
+	}
+}
+
+// RemoteRefFromProto converts the remote ref of a request.
+func RemoteRefFromProto(ref *pluginv1.RemoteRef) esv1.ExternalSecretDataRemoteRef {
+	return esv1.ExternalSecretDataRemoteRef{
+		Key:            ref.GetKey(),
+		Property:       ref.GetProperty(),
+		Version:        ref.GetVersion(),
+		MetadataPolicy: esv1.ExternalSecretMetadataPolicy(ref.GetMetadataPolicy()),
+	}
+}
+
+// FindToProto converts the find criteria of an ExternalSecret.
+func FindToProto(find esv1.ExternalSecretFind) *pluginv1.Find {
+	out := &pluginv1.Find{
+		Path: find.Path,
+		Tags: find.Tags,
+	}
+	if find.Name != nil {
+		out.NameRegexp = &find.Name.RegExp
+	}
+	return out
+}
+
+// FindFromProto converts the find criteria of a request.
+func FindFromProto(find *pluginv1.Find) esv1.ExternalSecretFind {
+	out := esv1.ExternalSecretFind{
+		Path: find.Path,
+		Tags: find.GetTags(),
+	}
+	if find.NameRegexp != nil {
+		out.Name = &esv1.FindName{RegExp: find.GetNameRegexp()}
+	}
+	return out
+}
+
+// SecretToProto converts the Secret of a PushSecret.
+func SecretToProto(secret *corev1.Secret) *pluginv1.Secret {
+	if secret == nil {
+		return nil
+	}
+	return &pluginv1.Secret{
+		Name:        secret.Name,
+		Namespace:   secret.Namespace,
+		Type:        string(secret.Type),
+		Data:        secret.Data,
+		Labels:      secret.Labels,
+		Annotations: secret.Annotations,
+	}
+}
+
+// SecretFromProto converts the Secret of a request.
+func SecretFromProto(secret *pluginv1.Secret) *corev1.Secret {
+	if secret == nil {
+		return nil
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.GetName(),
+			Namespace:   secret.GetNamespace(),
+			Labels:      secret.GetLabels(),
+			Annotations: secret.GetAnnotations(),
+		},
+		Type: corev1.SecretType(secret.GetType()),
+		Data: secret.GetData(),
+	}
+}
+
+// PushSecretDataToProto converts the data of a PushSecret.
+func PushSecretDataToProto(data esv1.PushSecretData) *pluginv1.PushSecretData {
+	out := &pluginv1.PushSecretData{
+		SecretKey: data.GetSecretKey(),
+		RemoteKey: data.GetRemoteKey(),
+		Property:  data.GetProperty(),
+	}
+	if metadata := data.GetMetadata(); metadata != nil {
+		out.Metadata = metadata.Raw
+	}
+	return out
+}
+
+// PushSecretDataFromProto converts the data of a request.
+func PushSecretDataFromProto(data *pluginv1.PushSecretData) esv1.PushSecretData {
+	out := pushSecretData{
+		secretKey: data.GetSecretKey(),
+		remoteKey: data.GetRemoteKey(),
+		property:  data.GetProperty(),
+	}
+	if len(data.GetMetadata()) > 0 {
+		out.metadata = &apiextensionsv1.JSON{Raw: data.GetMetadata()}
+	}
+	return out
+}
+
+// PushRemoteRefToProto converts the remote ref of a PushSecret.
+func PushRemoteRefToProto(ref esv1.PushSecretRemoteRef) *pluginv1.PushRemoteRef {
+	return &pluginv1.PushRemoteRef{
+		RemoteKey: ref.GetRemoteKey(),
+		Property:  ref.GetProperty(),
+	}
+}
+
+// PushRemoteRefFromProto converts the remote ref of a request.
+func PushRemoteRefFromProto(ref *pluginv1.PushRemoteRef) esv1.PushSecretRemoteRef {
+	return pushRemoteRef{
+		remoteKey: ref.GetRemoteKey(),
+		property:  ref.GetProperty(),
+	}
+}
+
+// ValidationResultToProto converts the result of a validation.
+func ValidationResultToProto(result esv1.ValidationResult) pluginv1.ValidationResult {
+	switch result {
+	case esv1.ValidationResultReady:
+		return pluginv1.ValidationResult_VALIDATION_RESULT_READY
+	case esv1.ValidationResultError:
+		return pluginv1.ValidationResult_VALIDATION_RESULT_ERROR
+	default:
+		return pluginv1.ValidationResult_VALIDATION_RESULT_UNKNOWN
+	}
+}
+
+// ValidationResultFromProto converts the result of a validation response.
+func ValidationResultFromProto(result pluginv1.ValidationResult) esv1.ValidationResult {
+	switch result {
+	case pluginv1.ValidationResult_VALIDATION_RESULT_READY:
+		return esv1.ValidationResultReady
+	case pluginv1.ValidationResult_VALIDATION_RESULT_ERROR:
+		return esv1.ValidationResultError
+	default:
+		return esv1.ValidationResultUnknown
+	}
+}
+
+// ToStatus converts an error of a SecretsClient to a gRPC status error.
+// NoSecretError is reported with the code NotFound.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, esv1.NoSecretErr) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// FromStatus converts a gRPC status error of a plugin to an error of a SecretsClient.
+// The code NotFound is converted to NoSecretError.
+func FromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	if st.Code() == codes.NotFound {
+		return esv1.NoSecretErr
+	}
+	return fmt.Errorf(errPlugin, st.Message())
+}
+
+type pushSecretData struct {
+	metadata  *apiextensionsv1.JSON
+	secretKey string
+	remoteKey string
+	property  string
+}
+
+func (d pushSecretData) GetMetadata() *apiextensionsv1.JSON {
+	return d.metadata
+}
+
+func (d pushSecretData) GetSecretKey() string {
+	return d.secretKey
+}
+
+func (d pushSecretData) GetRemoteKey() string {
+	return d.remoteKey
+}
+
+func (d pushSecretData) GetProperty() string {
+	return d.property
+}
+
+type pushRemoteRef struct {
+	remoteKey string
+	property  string
+}
+
+func (r pushRemoteRef) GetRemoteKey() string {
+	return r.remoteKey
+}
+
+func (r pushRemoteRef) GetProperty() string {
+	return r.property
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+func TestStatusRoundTrip(t *testing.T) {
+	if err := FromStatus(ToStatus(fmt.Errorf("lookup: %w", esv1.NoSecretErr))); !errors.Is(err, esv1.NoSecretErr) {
+		t.Errorf("FromStatus(ToStatus(NoSecretErr)) = %v, want NoSecretErr", err)
+	}
+	if err := FromStatus(ToStatus(errors.New("denied"))); err == nil || err.Error() != "plugin error: denied" {
+		t.Errorf("FromStatus(ToStatus(err)) = %v", err)
+	}
+	if err := FromStatus(ToStatus(nil)); err != nil {
+		t.Errorf("FromStatus(ToStatus(nil)) = %v, want nil", err)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	path := "apps/"
+	find := esv1.ExternalSecretFind{
+		Path: &path,
+		Name: &esv1.FindName{RegExp: "^db"},
+		Tags: map[string]string{"team": "a"},
+	}
+	if got := FindFromProto(FindToProto(find)); !reflect.DeepEqual(got, find) {
+		t.Errorf("FindFromProto(FindToProto()) = %+v, want %+v", got, find)
+	}
+
+	ref := esv1.ExternalSecretDataRemoteRef{
+		Key:            "db",
+		Property:       "password",
+		Version:        "2",
+		MetadataPolicy: esv1.ExternalSecretMetadataPolicyFetch,
+	}
+	if got := RemoteRefFromProto(RemoteRefToProto(ref)); !reflect.DeepEqual(got, ref) {
+		t.Errorf("RemoteRefFromProto(RemoteRefToProto()) = %+v, want %+v", got, ref)
+	}
+
+	data := pushSecretData{
+		metadata:  &apiextensionsv1.JSON{Raw: []byte(`{"labels":{"a":"b"}}`)},
+		secretKey: "token",
+		remoteKey: "pushed",
+		property:  "token",
+	}
+	if got := PushSecretDataFromProto(PushSecretDataToProto(data)); !reflect.DeepEqual(got, data) {
+		t.Errorf("PushSecretDataFromProto(PushSecretDataToProto()) = %+v, want %+v", got, data)
+	}
+
+	for _, result := range []esv1.ValidationResult{esv1.ValidationResultReady, esv1.ValidationResultUnknown, esv1.ValidationResultError} {
+		if got := ValidationResultFromProto(ValidationResultToProto(result)); got != result {
+			t.Errorf("ValidationResultFromProto(ValidationResultToProto(%v)) = %v", result, got)
+		}
+	}
+}
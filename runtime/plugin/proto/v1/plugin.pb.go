@@ -0,0 +1,1401 @@
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: plugin.proto
+
+// Package externalsecrets.plugin.v1 is the contract between the external-secrets
+// controller and provider plugins that run in a separate process.
+//
+// The calls mirror the methods of the SecretsClient interface of in-tree providers.
+// Every request carries the store it is made for, so plugins don't need to keep state
+// between calls. A secret that does not exist is reported with the status code NOT_FOUND.
+
+package pluginv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ValidationResult mirrors the validation results of in-tree providers.
+type ValidationResult int32
+
+const (
+	ValidationResult_VALIDATION_RESULT_UNSPECIFIED ValidationResult = 0
+	ValidationResult_VALIDATION_RESULT_READY       ValidationResult = 1
+	ValidationResult_VALIDATION_RESULT_UNKNOWN     ValidationResult = 2
+	ValidationResult_VALIDATION_RESULT_ERROR       ValidationResult = 3
+)
+
+// Enum value maps for ValidationResult.
+var (
+	ValidationResult_name = map[int32]string{
+		0: "VALIDATION_RESULT_UNSPECIFIED",
+		1: "VALIDATION_RESULT_READY",
+		2: "VALIDATION_RESULT_UNKNOWN",
+		3: "VALIDATION_RESULT_ERROR",
+	}
+	ValidationResult_value = map[string]int32{
+		"VALIDATION_RESULT_UNSPECIFIED": 0,
+		"VALIDATION_RESULT_READY":       1,
+		"VALIDATION_RESULT_UNKNOWN":     2,
+		"VALIDATION_RESULT_ERROR":       3,
+	}
+)
+
+func (x ValidationResult) Enum() *ValidationResult {
+	p := new(ValidationResult)
+	*p = x
+	return p
+}
+
+func (x ValidationResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ValidationResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_plugin_proto_enumTypes[0].Descriptor()
+}
+
+func (ValidationResult) Type() protoreflect.EnumType {
+	return &file_plugin_proto_enumTypes[0]
+}
+
+func (x ValidationResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ValidationResult.Descriptor instead.
+func (ValidationResult) EnumDescriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{0}
+}
+
+// Store identifies the store a call is made for.
+type Store struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Kind of the store, SecretStore or ClusterSecretStore.
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Name of the store.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Namespace of the resource the call is made for.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Config of spec.provider.plugin.config as JSON.
+	Config []byte `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	// Resolved values of spec.provider.plugin.secretRefs.
+	Secrets       map[string][]byte `protobuf:"bytes,5,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Store) Reset() {
+	*x = Store{}
+	mi := &file_plugin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Store) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Store) ProtoMessage() {}
+
+func (x *Store) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Store.ProtoReflect.Descriptor instead.
+func (*Store) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Store) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Store) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Store) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Store) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Store) GetSecrets() map[string][]byte {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+// RemoteRef references a secret of the provider.
+type RemoteRef struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Key      string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Property string                 `protobuf:"bytes,2,opt,name=property,proto3" json:"property,omitempty"`
+	Version  string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// MetadataPolicy is either None or Fetch.
+	MetadataPolicy string `protobuf:"bytes,4,opt,name=metadata_policy,json=metadataPolicy,proto3" json:"metadata_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoteRef) Reset() {
+	*x = RemoteRef{}
+	mi := &file_plugin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteRef) ProtoMessage() {}
+
+func (x *RemoteRef) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteRef.ProtoReflect.Descriptor instead.
+func (*RemoteRef) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RemoteRef) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RemoteRef) GetProperty() string {
+	if x != nil {
+		return x.Property
+	}
+	return ""
+}
+
+func (x *RemoteRef) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RemoteRef) GetMetadataPolicy() string {
+	if x != nil {
+		return x.MetadataPolicy
+	}
+	return ""
+}
+
+type GetSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	Ref           *RemoteRef             `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretRequest) Reset() {
+	*x = GetSecretRequest{}
+	mi := &file_plugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretRequest) ProtoMessage() {}
+
+func (x *GetSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSecretRequest) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *GetSecretRequest) GetRef() *RemoteRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+type GetSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretResponse) Reset() {
+	*x = GetSecretResponse{}
+	mi := &file_plugin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretResponse) ProtoMessage() {}
+
+func (x *GetSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretResponse.ProtoReflect.Descriptor instead.
+func (*GetSecretResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSecretResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type GetSecretMapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	Ref           *RemoteRef             `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretMapRequest) Reset() {
+	*x = GetSecretMapRequest{}
+	mi := &file_plugin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretMapRequest) ProtoMessage() {}
+
+func (x *GetSecretMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretMapRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretMapRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSecretMapRequest) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *GetSecretMapRequest) GetRef() *RemoteRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+type GetSecretMapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          map[string][]byte      `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretMapResponse) Reset() {
+	*x = GetSecretMapResponse{}
+	mi := &file_plugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretMapResponse) ProtoMessage() {}
+
+func (x *GetSecretMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretMapResponse.ProtoReflect.Descriptor instead.
+func (*GetSecretMapResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSecretMapResponse) GetData() map[string][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Find selects secrets of the provider.
+type Find struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path is the root path to start the find operation, if set.
+	Path *string `protobuf:"bytes,1,opt,name=path,proto3,oneof" json:"path,omitempty"`
+	// NameRegexp is the regular expression that names must match, if set.
+	NameRegexp *string `protobuf:"bytes,2,opt,name=name_regexp,json=nameRegexp,proto3,oneof" json:"name_regexp,omitempty"`
+	// Tags that secrets must have.
+	Tags          map[string]string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Find) Reset() {
+	*x = Find{}
+	mi := &file_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Find) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Find) ProtoMessage() {}
+
+func (x *Find) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Find.ProtoReflect.Descriptor instead.
+func (*Find) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Find) GetPath() string {
+	if x != nil && x.Path != nil {
+		return *x.Path
+	}
+	return ""
+}
+
+func (x *Find) GetNameRegexp() string {
+	if x != nil && x.NameRegexp != nil {
+		return *x.NameRegexp
+	}
+	return ""
+}
+
+func (x *Find) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type GetAllSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	Find          *Find                  `protobuf:"bytes,2,opt,name=find,proto3" json:"find,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllSecretsRequest) Reset() {
+	*x = GetAllSecretsRequest{}
+	mi := &file_plugin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllSecretsRequest) ProtoMessage() {}
+
+func (x *GetAllSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllSecretsRequest.ProtoReflect.Descriptor instead.
+func (*GetAllSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAllSecretsRequest) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *GetAllSecretsRequest) GetFind() *Find {
+	if x != nil {
+		return x.Find
+	}
+	return nil
+}
+
+type GetAllSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          map[string][]byte      `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllSecretsResponse) Reset() {
+	*x = GetAllSecretsResponse{}
+	mi := &file_plugin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllSecretsResponse) ProtoMessage() {}
+
+func (x *GetAllSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllSecretsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetAllSecretsResponse) GetData() map[string][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Secret is the Kubernetes Secret that is pushed.
+type Secret struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Data          map[string][]byte      `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations   map[string]string      `protobuf:"bytes,6,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Secret) Reset() {
+	*x = Secret{}
+	mi := &file_plugin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Secret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Secret) ProtoMessage() {}
+
+func (x *Secret) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Secret.ProtoReflect.Descriptor instead.
+func (*Secret) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Secret) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Secret) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Secret) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Secret) GetData() map[string][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Secret) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Secret) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+// PushSecretData selects what is pushed where.
+type PushSecretData struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SecretKey is the key of the Secret to push. If empty, the whole Secret is pushed.
+	SecretKey string `protobuf:"bytes,1,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	RemoteKey string `protobuf:"bytes,2,opt,name=remote_key,json=remoteKey,proto3" json:"remote_key,omitempty"`
+	Property  string `protobuf:"bytes,3,opt,name=property,proto3" json:"property,omitempty"`
+	// Metadata of the PushSecret as JSON, if set.
+	Metadata      []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushSecretData) Reset() {
+	*x = PushSecretData{}
+	mi := &file_plugin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushSecretData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushSecretData) ProtoMessage() {}
+
+func (x *PushSecretData) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushSecretData.ProtoReflect.Descriptor instead.
+func (*PushSecretData) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PushSecretData) GetSecretKey() string {
+	if x != nil {
+		return x.SecretKey
+	}
+	return ""
+}
+
+func (x *PushSecretData) GetRemoteKey() string {
+	if x != nil {
+		return x.RemoteKey
+	}
+	return ""
+}
+
+func (x *PushSecretData) GetProperty() string {
+	if x != nil {
+		return x.Property
+	}
+	return ""
+}
+
+func (x *PushSecretData) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type PushSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	Secret        *Secret                `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Data          *PushSecretData        `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushSecretRequest) Reset() {
+	*x = PushSecretRequest{}
+	mi := &file_plugin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushSecretRequest) ProtoMessage() {}
+
+func (x *PushSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushSecretRequest.ProtoReflect.Descriptor instead.
+func (*PushSecretRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PushSecretRequest) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *PushSecretRequest) GetSecret() *Secret {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *PushSecretRequest) GetData() *PushSecretData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type PushSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushSecretResponse) Reset() {
+	*x = PushSecretResponse{}
+	mi := &file_plugin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushSecretResponse) ProtoMessage() {}
+
+func (x *PushSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushSecretResponse.ProtoReflect.Descriptor instead.
+func (*PushSecretResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{12}
+}
+
+// PushRemoteRef references a pushed secret of the provider.
+type PushRemoteRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RemoteKey     string                 `protobuf:"bytes,1,opt,name=remote_key,json=remoteKey,proto3" json:"remote_key,omitempty"`
+	Property      string                 `protobuf:"bytes,2,opt,name=property,proto3" json:"property,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushRemoteRef) Reset() {
+	*x = PushRemoteRef{}
+	mi := &file_plugin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushRemoteRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushRemoteRef) ProtoMessage() {}
+
+func (x *PushRemoteRef) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushRemoteRef.ProtoReflect.Descriptor instead.
+func (*PushRemoteRef) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PushRemoteRef) GetRemoteKey() string {
+	if x != nil {
+		return x.RemoteKey
+	}
+	return ""
+}
+
+func (x *PushRemoteRef) GetProperty() string {
+	if x != nil {
+		return x.Property
+	}
+	return ""
+}
+
+type DeleteSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	Ref           *PushRemoteRef         `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecretRequest) Reset() {
+	*x = DeleteSecretRequest{}
+	mi := &file_plugin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretRequest) ProtoMessage() {}
+
+func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteSecretRequest) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *DeleteSecretRequest) GetRef() *PushRemoteRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+type DeleteSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecretResponse) Reset() {
+	*x = DeleteSecretResponse{}
+	mi := &file_plugin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretResponse) ProtoMessage() {}
+
+func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSecretResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{15}
+}
+
+type SecretExistsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	Ref           *PushRemoteRef         `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretExistsRequest) Reset() {
+	*x = SecretExistsRequest{}
+	mi := &file_plugin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretExistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretExistsRequest) ProtoMessage() {}
+
+func (x *SecretExistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretExistsRequest.ProtoReflect.Descriptor instead.
+func (*SecretExistsRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SecretExistsRequest) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *SecretExistsRequest) GetRef() *PushRemoteRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+type SecretExistsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exists        bool                   `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretExistsResponse) Reset() {
+	*x = SecretExistsResponse{}
+	mi := &file_plugin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretExistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretExistsResponse) ProtoMessage() {}
+
+func (x *SecretExistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretExistsResponse.ProtoReflect.Descriptor instead.
+func (*SecretExistsResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SecretExistsResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_plugin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ValidateRequest) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+type ValidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        ValidationResult       `protobuf:"varint,1,opt,name=result,proto3,enum=externalsecrets.plugin.v1.ValidationResult" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_plugin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ValidateResponse) GetResult() ValidationResult {
+	if x != nil {
+		return x.Result
+	}
+	return ValidationResult_VALIDATION_RESULT_UNSPECIFIED
+}
+
+var File_plugin_proto protoreflect.FileDescriptor
+
+const file_plugin_proto_rawDesc = "" +
+	"\n" +
+	"\fplugin.proto\x12\x19externalsecrets.plugin.v1\"\xea\x01\n" +
+	"\x05Store\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06config\x18\x04 \x01(\fR\x06config\x12G\n" +
+	"\asecrets\x18\x05 \x03(\v2-.externalsecrets.plugin.v1.Store.SecretsEntryR\asecrets\x1a:\n" +
+	"\fSecretsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"|\n" +
+	"\tRemoteRef\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\bproperty\x18\x02 \x01(\tR\bproperty\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12'\n" +
+	"\x0fmetadata_policy\x18\x04 \x01(\tR\x0emetadataPolicy\"\x82\x01\n" +
+	"\x10GetSecretRequest\x126\n" +
+	"\x05store\x18\x01 \x01(\v2 .externalsecrets.plugin.v1.StoreR\x05store\x126\n" +
+	"\x03ref\x18\x02 \x01(\v2$.externalsecrets.plugin.v1.RemoteRefR\x03ref\")\n" +
+	"\x11GetSecretResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\"\x85\x01\n" +
+	"\x13GetSecretMapRequest\x126\n" +
+	"\x05store\x18\x01 \x01(\v2 .externalsecrets.plugin.v1.StoreR\x05store\x126\n" +
+	"\x03ref\x18\x02 \x01(\v2$.externalsecrets.plugin.v1.RemoteRefR\x03ref\"\x9e\x01\n" +
+	"\x14GetSecretMapResponse\x12M\n" +
+	"\x04data\x18\x01 \x03(\v29.externalsecrets.plugin.v1.GetSecretMapResponse.DataEntryR\x04data\x1a7\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\xd6\x01\n" +
+	"\x04Find\x12\x17\n" +
+	"\x04path\x18\x01 \x01(\tH\x00R\x04path\x88\x01\x01\x12$\n" +
+	"\vname_regexp\x18\x02 \x01(\tH\x01R\n" +
+	"nameRegexp\x88\x01\x01\x12=\n" +
+	"\x04tags\x18\x03 \x03(\v2).externalsecrets.plugin.v1.Find.TagsEntryR\x04tags\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05_pathB\x0e\n" +
+	"\f_name_regexp\"\x83\x01\n" +
+	"\x14GetAllSecretsRequest\x126\n" +
+	"\x05store\x18\x01 \x01(\v2 .externalsecrets.plugin.v1.StoreR\x05store\x123\n" +
+	"\x04find\x18\x02 \x01(\v2\x1f.externalsecrets.plugin.v1.FindR\x04find\"\xa0\x01\n" +
+	"\x15GetAllSecretsResponse\x12N\n" +
+	"\x04data\x18\x01 \x03(\v2:.externalsecrets.plugin.v1.GetAllSecretsResponse.DataEntryR\x04data\x1a7\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\xe0\x03\n" +
+	"\x06Secret\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12?\n" +
+	"\x04data\x18\x04 \x03(\v2+.externalsecrets.plugin.v1.Secret.DataEntryR\x04data\x12E\n" +
+	"\x06labels\x18\x05 \x03(\v2-.externalsecrets.plugin.v1.Secret.LabelsEntryR\x06labels\x12T\n" +
+	"\vannotations\x18\x06 \x03(\v22.externalsecrets.plugin.v1.Secret.AnnotationsEntryR\vannotations\x1a7\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x86\x01\n" +
+	"\x0ePushSecretData\x12\x1d\n" +
+	"\n" +
+	"secret_key\x18\x01 \x01(\tR\tsecretKey\x12\x1d\n" +
+	"\n" +
+	"remote_key\x18\x02 \x01(\tR\tremoteKey\x12\x1a\n" +
+	"\bproperty\x18\x03 \x01(\tR\bproperty\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\fR\bmetadata\"\xc5\x01\n" +
+	"\x11PushSecretRequest\x126\n" +
+	"\x05store\x18\x01 \x01(\v2 .externalsecrets.plugin.v1.StoreR\x05store\x129\n" +
+	"\x06secret\x18\x02 \x01(\v2!.externalsecrets.plugin.v1.SecretR\x06secret\x12=\n" +
+	"\x04data\x18\x03 \x01(\v2).externalsecrets.plugin.v1.PushSecretDataR\x04data\"\x14\n" +
+	"\x12PushSecretResponse\"J\n" +
+	"\rPushRemoteRef\x12\x1d\n" +
+	"\n" +
+	"remote_key\x18\x01 \x01(\tR\tremoteKey\x12\x1a\n" +
+	"\bproperty\x18\x02 \x01(\tR\bproperty\"\x89\x01\n" +
+	"\x13DeleteSecretRequest\x126\n" +
+	"\x05store\x18\x01 \x01(\v2 .externalsecrets.plugin.v1.StoreR\x05store\x12:\n" +
+	"\x03ref\x18\x02 \x01(\v2(.externalsecrets.plugin.v1.PushRemoteRefR\x03ref\"\x16\n" +
+	"\x14DeleteSecretResponse\"\x89\x01\n" +
+	"\x13SecretExistsRequest\x126\n" +
+	"\x05store\x18\x01 \x01(\v2 .externalsecrets.plugin.v1.StoreR\x05store\x12:\n" +
+	"\x03ref\x18\x02 \x01(\v2(.externalsecrets.plugin.v1.PushRemoteRefR\x03ref\".\n" +
+	"\x14SecretExistsResponse\x12\x16\n" +
+	"\x06exists\x18\x01 \x01(\bR\x06exists\"I\n" +
+	"\x0fValidateRequest\x126\n" +
+	"\x05store\x18\x01 \x01(\v2 .externalsecrets.plugin.v1.StoreR\x05store\"W\n" +
+	"\x10ValidateResponse\x12C\n" +
+	"\x06result\x18\x01 \x01(\x0e2+.externalsecrets.plugin.v1.ValidationResultR\x06result*\x8e\x01\n" +
+	"\x10ValidationResult\x12!\n" +
+	"\x1dVALIDATION_RESULT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17VALIDATION_RESULT_READY\x10\x01\x12\x1d\n" +
+	"\x19VALIDATION_RESULT_UNKNOWN\x10\x02\x12\x1b\n" +
+	"\x17VALIDATION_RESULT_ERROR\x10\x032\x90\x06\n" +
+	"\x0fSecretsProvider\x12f\n" +
+	"\tGetSecret\x12+.externalsecrets.plugin.v1.GetSecretRequest\x1a,.externalsecrets.plugin.v1.GetSecretResponse\x12o\n" +
+	"\fGetSecretMap\x12..externalsecrets.plugin.v1.GetSecretMapRequest\x1a/.externalsecrets.plugin.v1.GetSecretMapResponse\x12r\n" +
+	"\rGetAllSecrets\x12/.externalsecrets.plugin.v1.GetAllSecretsRequest\x1a0.externalsecrets.plugin.v1.GetAllSecretsResponse\x12i\n" +
+	"\n" +
+	"PushSecret\x12,.externalsecrets.plugin.v1.PushSecretRequest\x1a-.externalsecrets.plugin.v1.PushSecretResponse\x12o\n" +
+	"\fDeleteSecret\x12..externalsecrets.plugin.v1.DeleteSecretRequest\x1a/.externalsecrets.plugin.v1.DeleteSecretResponse\x12o\n" +
+	"\fSecretExists\x12..externalsecrets.plugin.v1.SecretExistsRequest\x1a/.externalsecrets.plugin.v1.SecretExistsResponse\x12c\n" +
+	"\bValidate\x12*.externalsecrets.plugin.v1.ValidateRequest\x1a+.externalsecrets.plugin.v1.ValidateResponseBOZMgithub.com/external-secrets/external-secrets/runtime/plugin/proto/v1;pluginv1b\x06proto3"
+
+var (
+	file_plugin_proto_rawDescOnce sync.Once
+	file_plugin_proto_rawDescData []byte
+)
+
+func file_plugin_proto_rawDescGZIP() []byte {
+	file_plugin_proto_rawDescOnce.Do(func() {
+		file_plugin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_plugin_proto_rawDesc), len(file_plugin_proto_rawDesc)))
+	})
+	return file_plugin_proto_rawDescData
+}
+
+var file_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_plugin_proto_goTypes = []any{
+	(ValidationResult)(0),         // 0: externalsecrets.plugin.v1.ValidationResult
+	(*Store)(nil),                 // 1: externalsecrets.plugin.v1.Store
+	(*RemoteRef)(nil),             // 2: externalsecrets.plugin.v1.RemoteRef
+	(*GetSecretRequest)(nil),      // 3: externalsecrets.plugin.v1.GetSecretRequest
+	(*GetSecretResponse)(nil),     // 4: externalsecrets.plugin.v1.GetSecretResponse
+	(*GetSecretMapRequest)(nil),   // 5: externalsecrets.plugin.v1.GetSecretMapRequest
+	(*GetSecretMapResponse)(nil),  // 6: externalsecrets.plugin.v1.GetSecretMapResponse
+	(*Find)(nil),                  // 7: externalsecrets.plugin.v1.Find
+	(*GetAllSecretsRequest)(nil),  // 8: externalsecrets.plugin.v1.GetAllSecretsRequest
+	(*GetAllSecretsResponse)(nil), // 9: externalsecrets.plugin.v1.GetAllSecretsResponse
+	(*Secret)(nil),                // 10: externalsecrets.plugin.v1.Secret
+	(*PushSecretData)(nil),        // 11: externalsecrets.plugin.v1.PushSecretData
+	(*PushSecretRequest)(nil),     // 12: externalsecrets.plugin.v1.PushSecretRequest
+	(*PushSecretResponse)(nil),    // 13: externalsecrets.plugin.v1.PushSecretResponse
+	(*PushRemoteRef)(nil),         // 14: externalsecrets.plugin.v1.PushRemoteRef
+	(*DeleteSecretRequest)(nil),   // 15: externalsecrets.plugin.v1.DeleteSecretRequest
+	(*DeleteSecretResponse)(nil),  // 16: externalsecrets.plugin.v1.DeleteSecretResponse
+	(*SecretExistsRequest)(nil),   // 17: externalsecrets.plugin.v1.SecretExistsRequest
+	(*SecretExistsResponse)(nil),  // 18: externalsecrets.plugin.v1.SecretExistsResponse
+	(*ValidateRequest)(nil),       // 19: externalsecrets.plugin.v1.ValidateRequest
+	(*ValidateResponse)(nil),      // 20: externalsecrets.plugin.v1.ValidateResponse
+	nil,                           // 21: externalsecrets.plugin.v1.Store.SecretsEntry
+	nil,                           // 22: externalsecrets.plugin.v1.GetSecretMapResponse.DataEntry
+	nil,                           // 23: externalsecrets.plugin.v1.Find.TagsEntry
+	nil,                           // 24: externalsecrets.plugin.v1.GetAllSecretsResponse.DataEntry
+	nil,                           // 25: externalsecrets.plugin.v1.Secret.DataEntry
+	nil,                           // 26: externalsecrets.plugin.v1.Secret.LabelsEntry
+	nil,                           // 27: externalsecrets.plugin.v1.Secret.AnnotationsEntry
+}
+var file_plugin_proto_depIdxs = []int32{
+	21, // 0: externalsecrets.plugin.v1.Store.secrets:type_name -> externalsecrets.plugin.v1.Store.SecretsEntry
+	1,  // 1: externalsecrets.plugin.v1.GetSecretRequest.store:type_name -> externalsecrets.plugin.v1.Store
+	2,  // 2: externalsecrets.plugin.v1.GetSecretRequest.ref:type_name -> externalsecrets.plugin.v1.RemoteRef
+	1,  // 3: externalsecrets.plugin.v1.GetSecretMapRequest.store:type_name -> externalsecrets.plugin.v1.Store
+	2,  // 4: externalsecrets.plugin.v1.GetSecretMapRequest.ref:type_name -> externalsecrets.plugin.v1.RemoteRef
+	22, // 5: externalsecrets.plugin.v1.GetSecretMapResponse.data:type_name -> externalsecrets.plugin.v1.GetSecretMapResponse.DataEntry
+	23, // 6: externalsecrets.plugin.v1.Find.tags:type_name -> externalsecrets.plugin.v1.Find.TagsEntry
+	1,  // 7: externalsecrets.plugin.v1.GetAllSecretsRequest.store:type_name -> externalsecrets.plugin.v1.Store
+	7,  // 8: externalsecrets.plugin.v1.GetAllSecretsRequest.find:type_name -> externalsecrets.plugin.v1.Find
+	24, // 9: externalsecrets.plugin.v1.GetAllSecretsResponse.data:type_name -> externalsecrets.plugin.v1.GetAllSecretsResponse.DataEntry
+	25, // 10: externalsecrets.plugin.v1.Secret.data:type_name -> externalsecrets.plugin.v1.Secret.DataEntry
+	26, // 11: externalsecrets.plugin.v1.Secret.labels:type_name -> externalsecrets.plugin.v1.Secret.LabelsEntry
+	27, // 12: externalsecrets.plugin.v1.Secret.annotations:type_name -> externalsecrets.plugin.v1.Secret.AnnotationsEntry
+	1,  // 13: externalsecrets.plugin.v1.PushSecretRequest.store:type_name -> externalsecrets.plugin.v1.Store
+	10, // 14: externalsecrets.plugin.v1.PushSecretRequest.secret:type_name -> externalsecrets.plugin.v1.Secret
+	11, // 15: externalsecrets.plugin.v1.PushSecretRequest.data:type_name -> externalsecrets.plugin.v1.PushSecretData
+	1,  // 16: externalsecrets.plugin.v1.DeleteSecretRequest.store:type_name -> externalsecrets.plugin.v1.Store
+	14, // 17: externalsecrets.plugin.v1.DeleteSecretRequest.ref:type_name -> externalsecrets.plugin.v1.PushRemoteRef
+	1,  // 18: externalsecrets.plugin.v1.SecretExistsRequest.store:type_name -> externalsecrets.plugin.v1.Store
+	14, // 19: externalsecrets.plugin.v1.SecretExistsRequest.ref:type_name -> externalsecrets.plugin.v1.PushRemoteRef
+	1,  // 20: externalsecrets.plugin.v1.ValidateRequest.store:type_name -> externalsecrets.plugin.v1.Store
+	0,  // 21: externalsecrets.plugin.v1.ValidateResponse.result:type_name -> externalsecrets.plugin.v1.ValidationResult
+	3,  // 22: externalsecrets.plugin.v1.SecretsProvider.GetSecret:input_type -> externalsecrets.plugin.v1.GetSecretRequest
+	5,  // 23: externalsecrets.plugin.v1.SecretsProvider.GetSecretMap:input_type -> externalsecrets.plugin.v1.GetSecretMapRequest
+	8,  // 24: externalsecrets.plugin.v1.SecretsProvider.GetAllSecrets:input_type -> externalsecrets.plugin.v1.GetAllSecretsRequest
+	12, // 25: externalsecrets.plugin.v1.SecretsProvider.PushSecret:input_type -> externalsecrets.plugin.v1.PushSecretRequest
+	15, // 26: externalsecrets.plugin.v1.SecretsProvider.DeleteSecret:input_type -> externalsecrets.plugin.v1.DeleteSecretRequest
+	17, // 27: externalsecrets.plugin.v1.SecretsProvider.SecretExists:input_type -> externalsecrets.plugin.v1.SecretExistsRequest
+	19, // 28: externalsecrets.plugin.v1.SecretsProvider.Validate:input_type -> externalsecrets.plugin.v1.ValidateRequest
+	4,  // 29: externalsecrets.plugin.v1.SecretsProvider.GetSecret:output_type -> externalsecrets.plugin.v1.GetSecretResponse
+	6,  // 30: externalsecrets.plugin.v1.SecretsProvider.GetSecretMap:output_type -> externalsecrets.plugin.v1.GetSecretMapResponse
+	9,  // 31: externalsecrets.plugin.v1.SecretsProvider.GetAllSecrets:output_type -> externalsecrets.plugin.v1.GetAllSecretsResponse
+	13, // 32: externalsecrets.plugin.v1.SecretsProvider.PushSecret:output_type -> externalsecrets.plugin.v1.PushSecretResponse
+	16, // 33: externalsecrets.plugin.v1.SecretsProvider.DeleteSecret:output_type -> externalsecrets.plugin.v1.DeleteSecretResponse
+	18, // 34: externalsecrets.plugin.v1.SecretsProvider.SecretExists:output_type -> externalsecrets.plugin.v1.SecretExistsResponse
+	20, // 35: externalsecrets.plugin.v1.SecretsProvider.Validate:output_type -> externalsecrets.plugin.v1.ValidateResponse
+	29, // [29:36] is the sub-list for method output_type
+	22, // [22:29] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_plugin_proto_init() }
+func file_plugin_proto_init() {
+	if File_plugin_proto != nil {
+		return
+	}
+	file_plugin_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_plugin_proto_rawDesc), len(file_plugin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_plugin_proto_goTypes,
+		DependencyIndexes: file_plugin_proto_depIdxs,
+		EnumInfos:         file_plugin_proto_enumTypes,
+		MessageInfos:      file_plugin_proto_msgTypes,
+	}.Build()
+	File_plugin_proto = out.File
+	file_plugin_proto_goTypes = nil
+	file_plugin_proto_depIdxs = nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin is the SDK to implement provider plugins, which run in a
+// separate process and are called by the controller over gRPC.
+//
+// A plugin implements the esv1.SecretsClient interface like in-tree providers
+// do, so it can reuse the helpers of the runtime module, and serves it with
+// Serve:
+//
+//	lis, err := plugin.Listen("unix:///var/run/eso/my-plugin.sock")
+//	...
+//	err = plugin.Serve(ctx, lis, func(ctx context.Context, store *plugin.Store) (esv1.SecretsClient, error) {
+//		return newClient(store)
+//	})
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	pluginv1 "github.com/external-secrets/external-secrets/runtime/plugin/proto/v1"
+)
+
+const unixPrefix = "unix://"
+
+// Store is the store a call is made for.
+type Store struct {
+	// Kind of the store, SecretStore or ClusterSecretStore.
+	Kind string
+	// Name of the store.
+	Name string
+	// Namespace of the resource the call is made for.
+	Namespace string
+	// Config of spec.provider.plugin.config as JSON.
+	Config []byte
+	// Secrets are the resolved values of spec.provider.plugin.secretRefs.
+	Secrets map[string][]byte
+}
+
+// DecodeConfig unmarshals the config of the store into v.
+func (s *Store) DecodeConfig(v any) error {
+	if len(s.Config) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(s.Config, v); err != nil {
+		return fmt.Errorf("unable to decode plugin config: %w", err)
+	}
+	return nil
+}
+
+// ClientFactory creates the client that serves a single call for the store.
+// The client is closed once the call has been served.
+type ClientFactory func(ctx context.Context, store *Store) (esv1.SecretsClient, error)
+
+type server struct {
+	pluginv1.UnimplementedSecretsProviderServer
+	newClient ClientFactory
+}
+
+// NewServer returns the gRPC service of a plugin that serves the calls with the clients of newClient.
+func NewServer(newClient ClientFactory) pluginv1.SecretsProviderServer {
+	return &server{newClient: newClient}
+}
+
+// Listen listens on a unix domain socket, e.g. unix:///var/run/eso/my-plugin.sock,
+// or on a TCP address, e.g. :8443. A stale socket file is removed.
+func Listen(address string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(address, unixPrefix); ok {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("unable to remove stale socket: %w", err)
+		}
+		return net.Listen("unix", path)
+	}
+	return net.Listen("tcp", address)
+}
+
+// Serve serves the plugin on the listener until ctx is done.
+// Use grpc.Creds to serve with TLS.
+func Serve(ctx context.Context, lis net.Listener, newClient ClientFactory, opts ...grpc.ServerOption) error {
+	srv := grpc.NewServer(opts...)
+	pluginv1.RegisterSecretsProviderServer(srv, NewServer(newClient))
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+	return srv.Serve(lis)
+}
+
+// withClient creates the client for the store of a request, calls fn and
+// closes the client again.
+func (s *server) withClient(ctx context.Context, store *pluginv1.Store, fn func(esv1.SecretsClient) error) error {
+	client, err := s.newClient(ctx, StoreFromProto(store))
+	if err != nil {
+		return ToStatus(err)
+	}
+	defer func() {
+		_ = client.Close(ctx)
+	}()
+	return ToStatus(fn(client))
+}
+
+func (s *server) GetSecret(ctx context.Context, req *pluginv1.GetSecretRequest) (*pluginv1.GetSecretResponse, error) {
+	resp := &pluginv1.GetSecretResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(c esv1.SecretsClient) (err error) {
+		resp.Value, err = c.GetSecret(ctx, RemoteRefFromProto(req.GetRef()))
+		return err
+	})
+	return resp, err
+}
+
+func (s *server) GetSecretMap(ctx context.Context, req *pluginv1.GetSecretMapRequest) (*pluginv1.GetSecretMapResponse, error) {
+	resp := &pluginv1.GetSecretMapResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(c esv1.SecretsClient) (err error) {
+		resp.Data, err = c.GetSecretMap(ctx, RemoteRefFromProto(req.GetRef()))
+		return err
+	})
+	return resp, err
+}
+
+func (s *server) GetAllSecrets(ctx context.Context, req *pluginv1.GetAllSecretsRequest) (*pluginv1.GetAllSecretsResponse, error) {
+	resp := &pluginv1.GetAllSecretsResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(c esv1.SecretsClient) (err error) {
+		resp.Data, err = c.GetAllSecrets(ctx, FindFromProto(req.GetFind()))
+		return err
+	})
+	return resp, err
+}
+
+func (s *server) PushSecret(ctx context.Context, req *pluginv1.PushSecretRequest) (*pluginv1.PushSecretResponse, error) {
+	err := s.withClient(ctx, req.GetStore(), func(c esv1.SecretsClient) error {
+		return c.PushSecret(ctx, SecretFromProto(req.GetSecret()), PushSecretDataFromProto(req.GetData()))
+	})
+	return &pluginv1.PushSecretResponse{}, err
+}
+
+func (s *server) DeleteSecret(ctx context.Context, req *pluginv1.DeleteSecretRequest) (*pluginv1.DeleteSecretResponse, error) {
+	err := s.withClient(ctx, req.GetStore(), func(c esv1.SecretsClient) error {
+		return c.DeleteSecret(ctx, PushRemoteRefFromProto(req.GetRef()))
+	})
+	return &pluginv1.DeleteSecretResponse{}, err
+}
+
+func (s *server) SecretExists(ctx context.Context, req *pluginv1.SecretExistsRequest) (*pluginv1.SecretExistsResponse, error) {
+	resp := &pluginv1.SecretExistsResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(c esv1.SecretsClient) (err error) {
+		resp.Exists, err = c.SecretExists(ctx, PushRemoteRefFromProto(req.GetRef()))
+		return err
+	})
+	return resp, err
+}
+
+func (s *server) Validate(ctx context.Context, req *pluginv1.ValidateRequest) (*pluginv1.ValidateResponse, error) {
+	resp := &pluginv1.ValidateResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(c esv1.SecretsClient) error {
+		result, err := c.Validate()
+		resp.Result = ValidationResultToProto(result)
+		return err
+	})
+	return resp, err
+}
+
+// StoreFromProto converts the store of a request.
+func StoreFromProto(store *pluginv1.Store) *Store {
+	return &Store{
+		Kind:      store.GetKind(),
+		Name:      store.GetName(),
+		Namespace: store.GetNamespace(),
+		Config:    store.GetConfig(),
+		Secrets:   store.GetSecrets(),
+	}
+}
+
+// StoreToProto converts the store of a call.
+func StoreToProto(store *Store) *pluginv1.Store {
+	return &pluginv1.Store{
+		Kind:      store.Kind,
+		Name:      store.Name,
+		Namespace: store.Namespace,
+		Config:    store.Config,
+		Secrets:   store.Secrets,
+	}
+}
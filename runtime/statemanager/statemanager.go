@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -56,6 +57,8 @@ type QueueItem struct {
 	Commit   func() error
 }
 
+type managerContextKey struct{}
+
 var gcGracePeriod time.Duration
 
 func init() {
@@ -78,6 +81,22 @@ func New(ctx context.Context, client client.Client, scheme *runtime.Scheme, name
 	}
 }
 
+// WithManager returns a copy of ctx which carries the manager, so that generators
+// which run other generators, e.g. the GeneratorPipeline, enqueue the rollback of
+// their steps into the same transaction.
+func WithManager(ctx context.Context, m *Manager) context.Context {
+	if m == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, managerContextKey{}, m)
+}
+
+// FromContext returns the manager carried by ctx, if any.
+func FromContext(ctx context.Context) (*Manager, bool) {
+	m, ok := ctx.Value(managerContextKey{}).(*Manager)
+	return m, ok
+}
+
 // Rollback will rollback the enqueued operations.
 func (m *Manager) Rollback() error {
 	var errs []error
@@ -106,6 +125,27 @@ func (m *Manager) Commit() error {
 	return errors.Join(errs...)
 }
 
+// Generate calls the generator implementation. If the generator implements
+// genapi.StatefulGenerator the state of the given latest GeneratorState is passed along.
+func Generate(ctx context.Context, gen genapi.Generator, resource *apiextensions.JSON, latest *genapi.GeneratorState, kube client.Client, namespace string) (map[string][]byte, genapi.GeneratorProviderState, error) {
+	statefulGen, ok := gen.(genapi.StatefulGenerator)
+	if !ok {
+		return gen.Generate(ctx, resource, kube, namespace)
+	}
+	var previous genapi.GeneratorProviderState
+	if latest != nil {
+		previous = latest.Spec.State
+	}
+	return statefulGen.GenerateWithState(ctx, resource, previous, kube, namespace)
+}
+
+// EnqueueRollback enqueues an operation which is only run on Rollback.
+func (m *Manager) EnqueueRollback(rollback func() error) {
+	m.queue = append(m.queue, QueueItem{
+		Rollback: rollback,
+	})
+}
+
 // EnqueueFlagLatestStateForGC will flag the latest state for garbage collection after Commit.
 // It will be cleaned up later by the garbage collector.
 func (m *Manager) EnqueueFlagLatestStateForGC(stateKey string) {
@@ -125,6 +165,28 @@ func (m *Manager) EnqueueMoveStateToGC(stateKey string) {
 	})
 }
 
+// RotationPolicy controls how long superseded generator states are kept
+// before they are cleaned up.
+type RotationPolicy struct {
+	// Keep is the number of superseded states that are kept alive in addition
+	// to the latest one.
+	Keep int
+	// TTL is the time a superseded state is kept alive.
+	// If zero, the generator gc grace period is used.
+	TTL time.Duration
+}
+
+// EnqueueRotateState will flag superseded states for GC after Commit according to the given policy.
+// Unlike EnqueueMoveStateToGC it keeps policy.Keep superseded states alive for policy.TTL,
+// so consumers can switch over to the new credential.
+func (m *Manager) EnqueueRotateState(stateKey string, policy RotationPolicy) {
+	m.queue = append(m.queue, QueueItem{
+		Commit: func() error {
+			return m.rotateState(stateKey, policy)
+		},
+	})
+}
+
 // EnqueueSetLatest sets the latest state for the given key.
 // It will commit the state on success or move the state to GC on failure.
 func (m *Manager) EnqueueSetLatest(ctx context.Context, stateKey, namespace string, resource *apiextensions.JSON, gen genapi.Generator, state genapi.GeneratorProviderState) {
@@ -225,6 +287,61 @@ func (m *Manager) disposeState(key string) error {
 	return errors.Join(errs...)
 }
 
+func (m *Manager) rotateState(key string, policy RotationPolicy) error {
+	allStates, err := m.GetAllStates(key)
+	if err != nil {
+		return err
+	}
+
+	latest := getLatest(allStates)
+	if latest == nil {
+		return nil
+	}
+
+	ttl := policy.TTL
+	if ttl <= 0 {
+		ttl = gcGracePeriod
+	}
+	now := time.Now()
+
+	// Superseded states are ordered newest first: the first policy.Keep states
+	// are kept alive for the TTL, all others are cleaned up after the grace period.
+	// States that are already flagged keep their deadline unless it has to be shortened.
+	superseded := make([]genapi.GeneratorState, 0, len(allStates))
+	for _, state := range allStates {
+		if state.Name == latest.Name {
+			continue
+		}
+		// expired states, e.g. from a rollback, are about to be deleted and don't count.
+		if deadline := state.Spec.GarbageCollectionDeadline; deadline != nil && !deadline.After(now) {
+			continue
+		}
+		superseded = append(superseded, state)
+	}
+	sort.SliceStable(superseded, func(i, j int) bool {
+		return superseded[i].CreationTimestamp.After(superseded[j].CreationTimestamp.Time)
+	})
+
+	var errs []error
+	for i, state := range superseded {
+		deadline := now.Add(gcGracePeriod)
+		if i < policy.Keep {
+			deadline = now.Add(ttl)
+		}
+		current := state.Spec.GarbageCollectionDeadline
+		if current != nil && (i < policy.Keep || !current.After(deadline)) {
+			continue
+		}
+		state.Spec.GarbageCollectionDeadline = &metav1.Time{
+			Time: deadline,
+		}
+		if err := m.client.Update(m.ctx, &state); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // GetAllStates retrieves all the stored states for the given key.
 func (m *Manager) GetAllStates(key string) ([]genapi.GeneratorState, error) {
 	var stateList genapi.GeneratorStateList
@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statemanager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	genapi "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+const (
+	testNamespace = "default"
+	testStateKey  = "0"
+)
+
+func TestRotateState(t *testing.T) {
+	now := time.Now()
+	ttl := time.Hour
+
+	tests := []struct {
+		name string
+		// deadlines of the existing states, newest first. nil means not flagged.
+		deadlines []*time.Time
+		policy    RotationPolicy
+		// expected deadlines relative to now, newest first. -1 means not flagged.
+		expected []time.Duration
+	}{
+		{
+			name:      "keeps the latest state",
+			deadlines: []*time.Time{nil},
+			policy:    RotationPolicy{Keep: 1, TTL: ttl},
+			expected:  []time.Duration{-1},
+		},
+		{
+			name:      "superseded states within keep get the ttl",
+			deadlines: []*time.Time{nil, nil, nil},
+			policy:    RotationPolicy{Keep: 1, TTL: ttl},
+			expected:  []time.Duration{-1, ttl, gcGracePeriod},
+		},
+		{
+			name:      "keep zero behaves like the grace period",
+			deadlines: []*time.Time{nil, nil},
+			policy:    RotationPolicy{TTL: ttl},
+			expected:  []time.Duration{-1, gcGracePeriod},
+		},
+		{
+			name:      "zero ttl falls back to the grace period",
+			deadlines: []*time.Time{nil, nil},
+			policy:    RotationPolicy{Keep: 1},
+			expected:  []time.Duration{-1, gcGracePeriod},
+		},
+		{
+			name:      "existing deadlines are shortened when out of keep",
+			deadlines: []*time.Time{nil, ptrTime(now.Add(ttl)), ptrTime(now.Add(ttl))},
+			policy:    RotationPolicy{Keep: 1, TTL: ttl},
+			expected:  []time.Duration{-1, ttl, gcGracePeriod},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, kube := newTestManager(t)
+			for i, deadline := range tt.deadlines {
+				state, err := m.createGeneratorState(nil, nil, testNamespace, testStateKey)
+				require.NoError(t, err)
+				state.GenerateName = ""
+				state.Name = fmt.Sprintf("state-%d", i)
+				state.CreationTimestamp = metav1.NewTime(now.Add(-time.Duration(i) * time.Minute))
+				if deadline != nil {
+					state.Spec.GarbageCollectionDeadline = &metav1.Time{Time: *deadline}
+				}
+				require.NoError(t, kube.Create(context.Background(), state))
+			}
+
+			m.EnqueueRotateState(testStateKey, tt.policy)
+			require.NoError(t, m.Commit())
+
+			for i, expected := range tt.expected {
+				var state genapi.GeneratorState
+				require.NoError(t, kube.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: fmt.Sprintf("state-%d", i)}, &state))
+				if expected < 0 {
+					assert.Nil(t, state.Spec.GarbageCollectionDeadline, "state-%d", i)
+					continue
+				}
+				require.NotNil(t, state.Spec.GarbageCollectionDeadline, "state-%d", i)
+				assert.WithinDuration(t, now.Add(expected), state.Spec.GarbageCollectionDeadline.Time, 10*time.Second, "state-%d", i)
+			}
+		})
+	}
+}
+
+func newTestManager(t *testing.T) (*Manager, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, genapi.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).Build()
+	owner := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "owner",
+			Namespace: testNamespace,
+			UID:       "owner-uid",
+		},
+	}
+	return New(context.Background(), kube, scheme, testNamespace, owner), kube
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func TestManagerContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	assert.Equal(t, context.Background(), WithManager(context.Background(), nil))
+
+	m := New(context.Background(), nil, nil, testNamespace, nil)
+	got, ok := FromContext(WithManager(context.Background(), m))
+	require.True(t, ok)
+	assert.Same(t, m, got)
+
+	var rolledBack int
+	m.EnqueueRollback(func() error {
+		rolledBack++
+		return nil
+	})
+	require.NoError(t, m.Commit())
+	assert.Zero(t, rolledBack, "commit must not run rollbacks")
+	require.NoError(t, m.Rollback())
+	assert.Equal(t, 1, rolledBack)
+}
@@ -0,0 +1,387 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cel implements a template engine that evaluates each template as
+// Common Expression Language (CEL) expression over the secret data.
+package cel
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+	"github.com/google/cel-go/interpreter"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/runtime/feature"
+	v2 "github.com/external-secrets/external-secrets/runtime/template/v2"
+)
+
+const (
+	// defaultCostLimit matches the per expression cost limit of Kubernetes validation rules.
+	defaultCostLimit = 1000000
+	// expressionSizeLimit is the maximum number of code points of an expression.
+	expressionSizeLimit = 100000
+	// helperCallCost is the base cost of calling a helper, which e.g. parses certificates or decrypts data.
+	helperCallCost = 100
+	// programCacheSize is the number of compiled programs that are kept across reconciles.
+	programCacheSize = 1024
+
+	dataVariable = "data"
+
+	errCompile        = "unable to compile expression at key %s: %w"
+	errEvaluate       = "unable to evaluate expression at key %s: %w"
+	errValuesResult   = "expression must evaluate to string or bytes, got %s"
+	errKeysResult     = "expression must evaluate to a map of string keys, got %s"
+	errKeysValue      = "value of key %q must be string or bytes, got %s"
+	errApplyTarget    = "failed to apply to target: %w"
+	errUnknownScope   = "unknown scope '%v': expected 'Values' or 'KeysAndValues'"
+	errUnsupportedArg = "unsupported argument type %s of function %s"
+	errUnsupportedRet = "unsupported return type %s of function %s"
+)
+
+// helpers are the functions of the v2 engine that are available in expressions.
+// They are called with the same arguments as in Go templates, e.g. pkcs12key(data.archive).
+var helpers = []string{
+	"pkcs12key", "pkcs12keyPass", "pkcs12cert", "pkcs12certPass",
+	"pemToPkcs12", "pemToPkcs12Pass", "fullPemToPkcs12", "fullPemToPkcs12Pass",
+	"pemTruststoreToPKCS12", "pemTruststoreToPKCS12Pass",
+	"jksKey", "jksKeyAlias", "jksCert", "jksCertAlias", "pemToJKS", "pemToJKSPass", "pemTruststoreToJKS",
+	"filterPEM", "filterCertChain",
+	"x509Parse", "x509SortChain", "x509VerifyChain", "pemPublicKey",
+	"jwkPublicKeyPem", "jwkPrivateKeyPem",
+	"toYaml", "fromYaml", "toToml", "fromToml", "toIni", "fromIni",
+	"toDotenv", "fromDotenv", "toProperties", "fromProperties",
+	"rsaDecrypt", "ageDecrypt", "pgpDecrypt", "pgpDecryptPass",
+}
+
+var costLimit uint64
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+
+	programs = mustLRU(programCacheSize)
+)
+
+// programKey identifies a compiled program. The scope is checked at compile time
+// and the cost limit is part of the program, so both are part of the key.
+type programKey struct {
+	expr      string
+	scope     esapi.TemplateScope
+	costLimit uint64
+}
+
+func mustLRU(size int) *lru.Cache {
+	c, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func init() {
+	fs := pflag.NewFlagSet("template-cel", pflag.ExitOnError)
+	fs.Uint64Var(&costLimit, "template-cel-cost-limit", defaultCostLimit, "maximum cost of evaluating a single CEL template expression")
+	feature.Register(feature.Feature{
+		Flags: fs,
+	})
+	esapi.RegisterTemplateValidator(esapi.TemplateEngineCEL, Validate)
+}
+
+// Execute evaluates the expressions of tpl with the secret data and applies the
+// results to the target of the object. With scope Values each expression must
+// evaluate to a string or bytes, with scope KeysAndValues to a map of keys to values.
+func Execute(tpl, data map[string][]byte, scope esapi.TemplateScope, target string, obj client.Object) error {
+	if tpl == nil {
+		return nil
+	}
+	if scope != esapi.TemplateScopeValues && scope != esapi.TemplateScopeKeysAndValues {
+		return fmt.Errorf(errUnknownScope, scope)
+	}
+	vars := map[string]any{dataVariable: stringData(data)}
+	for _, k := range slices.Sorted(maps.Keys(tpl)) {
+		prg, err := program(string(tpl[k]), scope)
+		if err != nil {
+			return fmt.Errorf(errCompile, k, err)
+		}
+		out, _, err := prg.Eval(vars)
+		if err != nil {
+			return fmt.Errorf(errEvaluate, k, err)
+		}
+		if scope == esapi.TemplateScopeValues {
+			val, err := valueBytes(out)
+			if err != nil {
+				return fmt.Errorf(errEvaluate, k, err)
+			}
+			if err := v2.ApplyToTarget(k, val, target, obj); err != nil {
+				return fmt.Errorf(errApplyTarget, err)
+			}
+			continue
+		}
+		if err := applyMap(out, target, obj); err != nil {
+			return fmt.Errorf(errEvaluate, k, err)
+		}
+	}
+	return nil
+}
+
+// Validate compiles the expression and checks its result type for the scope.
+func Validate(expr string, scope esapi.TemplateScope) error {
+	_, err := compile(expr, scope)
+	return err
+}
+
+func applyMap(out ref.Val, target string, obj client.Object) error {
+	native, err := out.ConvertToNative(reflect.TypeFor[map[string]any]())
+	if err != nil {
+		return fmt.Errorf(errKeysResult, out.Type().TypeName())
+	}
+	m := native.(map[string]any)
+	switch strings.ToLower(target) {
+	case "annotations", "labels", "data":
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			var val []byte
+			switch v := m[k].(type) {
+			case string:
+				val = []byte(v)
+			case []byte:
+				val = v
+			default:
+				return fmt.Errorf(errKeysValue, k, reflect.TypeOf(m[k]))
+			}
+			if err := v2.ApplyToTarget(k, val, target, obj); err != nil {
+				return fmt.Errorf(errApplyTarget, err)
+			}
+		}
+		return nil
+	}
+	// other targets receive the structure of the map as is.
+	return v2.ApplyParsedToPath(m, target, obj)
+}
+
+func valueBytes(out ref.Val) ([]byte, error) {
+	switch v := out.Value().(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	return nil, fmt.Errorf(errValuesResult, out.Type().TypeName())
+}
+
+// program returns the compiled program of the expression, which is cached, as
+// the templates of an ExternalSecret are evaluated again on every reconcile.
+func program(expr string, scope esapi.TemplateScope) (cel.Program, error) {
+	key := programKey{expr: expr, scope: scope, costLimit: costLimit}
+	if prg, ok := programs.Get(key); ok {
+		return prg.(cel.Program), nil
+	}
+	ast, err := compile(expr, scope)
+	if err != nil {
+		return nil, err
+	}
+	prg, err := env.Program(ast, cel.CostLimit(costLimit), cel.CostTrackerOptions(helperCostTrackers()...))
+	if err != nil {
+		return nil, err
+	}
+	programs.Add(key, prg)
+	return prg, nil
+}
+
+func compile(expr string, scope esapi.TemplateScope) (*cel.Ast, error) {
+	e, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := e.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	switch out := ast.OutputType(); {
+	case out.IsExactType(types.DynType):
+	case scope == esapi.TemplateScopeValues && !out.IsExactType(types.StringType) && !out.IsExactType(types.BytesType):
+		return nil, fmt.Errorf(errValuesResult, out)
+	case scope == esapi.TemplateScopeKeysAndValues && out.Kind() != types.MapKind:
+		return nil, fmt.Errorf(errKeysResult, out)
+	}
+	return ast, nil
+}
+
+// newEnv creates the environment once, declaring the data variable, the CEL
+// string and encoder extensions and the helpers of the v2 engine.
+func newEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		opts := []cel.EnvOption{
+			cel.Variable(dataVariable, cel.MapType(cel.StringType, cel.StringType)),
+			cel.ParserExpressionSizeLimit(expressionSizeLimit),
+			ext.Strings(),
+			ext.Encoders(),
+		}
+		funcs := v2.FuncMap()
+		for _, name := range helpers {
+			opt, err := function(name, funcs[name])
+			if err != nil {
+				envErr = err
+				return
+			}
+			opts = append(opts, opt)
+		}
+		env, envErr = cel.NewEnv(opts...)
+	})
+	return env, envErr
+}
+
+// function declares a Go function of the v2 engine as CEL function. Arguments
+// and results may be strings, booleans and maps, a trailing error is returned
+// as evaluation error.
+func function(name string, fn any) (cel.EnvOption, error) {
+	fv := reflect.ValueOf(fn)
+	ft := fv.Type()
+	args := make([]*cel.Type, ft.NumIn())
+	for i := range ft.NumIn() {
+		t, err := celType(ft.In(i))
+		if err != nil {
+			return nil, fmt.Errorf(errUnsupportedArg, ft.In(i), name)
+		}
+		args[i] = t
+	}
+	if ft.NumOut() == 0 || ft.NumOut() > 2 {
+		return nil, fmt.Errorf(errUnsupportedRet, ft, name)
+	}
+	result, err := celType(ft.Out(0))
+	if err != nil {
+		return nil, fmt.Errorf(errUnsupportedRet, ft.Out(0), name)
+	}
+
+	binding := func(values ...ref.Val) ref.Val {
+		in := make([]reflect.Value, len(values))
+		for i, v := range values {
+			native, err := toNative(v, ft.In(i))
+			if err != nil {
+				return types.WrapErr(err)
+			}
+			in[i] = reflect.ValueOf(native)
+		}
+		out := fv.Call(in)
+		if len(out) == 2 && !out[1].IsNil() {
+			return types.WrapErr(fmt.Errorf("%s: %w", name, out[1].Interface().(error)))
+		}
+		return types.DefaultTypeAdapter.NativeToValue(out[0].Interface())
+	}
+	return cel.Function(name, cel.Overload(overloadID(name), args, result, cel.FunctionBinding(binding))), nil
+}
+
+func overloadID(name string) string {
+	return name + "_overload"
+}
+
+// helperCostTrackers charges the helpers by the size of their arguments and result,
+// as CEL does not know their cost and would charge each call with a cost of one.
+func helperCostTrackers() []interpreter.CostTrackerOption {
+	opts := make([]interpreter.CostTrackerOption, 0, len(helpers))
+	for _, name := range helpers {
+		opts = append(opts, interpreter.OverloadCostTracker(overloadID(name), helperCost))
+	}
+	return opts
+}
+
+func helperCost(args []ref.Val, result ref.Val) *uint64 {
+	size := valueSize(result)
+	for _, arg := range args {
+		size += valueSize(arg)
+	}
+	cost := helperCallCost + uint64(math.Ceil(float64(size)*common.StringTraversalCostFactor))
+	return &cost
+}
+
+// valueSize returns the number of bytes of strings and bytes in the value.
+func valueSize(v ref.Val) uint64 {
+	switch v := v.(type) {
+	case types.String:
+		return uint64(len(v))
+	case types.Bytes:
+		return uint64(len(v))
+	case traits.Mapper:
+		var size uint64
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			k := it.Next()
+			size += valueSize(k) + valueSize(v.Get(k))
+		}
+		return size
+	case traits.Lister:
+		var size uint64
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			size += valueSize(it.Next())
+		}
+		return size
+	}
+	return 1
+}
+
+// toNative converts a CEL value to the argument type of a Go function.
+// Dynamic arguments receive maps and lists as native Go values.
+func toNative(v ref.Val, t reflect.Type) (any, error) {
+	if t.Kind() != reflect.Interface {
+		return v.ConvertToNative(t)
+	}
+	switch v.Type() {
+	case types.MapType:
+		return v.ConvertToNative(reflect.TypeFor[map[string]any]())
+	case types.ListType:
+		return v.ConvertToNative(reflect.TypeFor[[]any]())
+	}
+	return v.Value(), nil
+}
+
+func celType(t reflect.Type) (*cel.Type, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return cel.StringType, nil
+	case reflect.Bool:
+		return cel.BoolType, nil
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return cel.MapType(cel.StringType, cel.DynType), nil
+		}
+	case reflect.Interface:
+		return cel.DynType, nil
+	}
+	return nil, errors.New("unsupported type")
+}
+
+func stringData(data map[string][]byte) map[string]string {
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		out[k] = string(v)
+	}
+	return out
+}
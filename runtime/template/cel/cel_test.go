@@ -0,0 +1,280 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+)
+
+func TestExecute(t *testing.T) {
+	cert, err := os.ReadFile("../v2/_testdata/foo.crt")
+	require.NoError(t, err)
+	key, err := os.ReadFile("../v2/_testdata/foo.key")
+	require.NoError(t, err)
+
+	tbl := []struct {
+		name    string
+		tpl     map[string][]byte
+		data    map[string][]byte
+		scope   esapi.TemplateScope
+		target  string
+		want    map[string][]byte
+		wantErr string
+	}{
+		{
+			name: "string expressions",
+			tpl: map[string][]byte{
+				"user":  []byte(`data.username`),
+				"dsn":   []byte(`"postgres://" + data.username + ":" + data.password + "@db"`),
+				"upper": []byte(`data.username.upperAscii()`),
+			},
+			data:  map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t")},
+			scope: esapi.TemplateScopeValues,
+			want: map[string][]byte{
+				"user":  []byte("admin"),
+				"dsn":   []byte("postgres://admin:s3cr3t@db"),
+				"upper": []byte("ADMIN"),
+			},
+		},
+		{
+			name:  "bytes expression",
+			tpl:   map[string][]byte{"raw": []byte(`base64.decode(data.encoded)`)},
+			data:  map[string][]byte{"encoded": []byte("aGVsbG8=")},
+			scope: esapi.TemplateScopeValues,
+			want:  map[string][]byte{"raw": []byte("hello")},
+		},
+		{
+			name:  "conditional with default",
+			tpl:   map[string][]byte{"port": []byte(`"port" in data ? data.port : "5432"`)},
+			data:  map[string][]byte{},
+			scope: esapi.TemplateScopeValues,
+			want:  map[string][]byte{"port": []byte("5432")},
+		},
+		{
+			name:  "yaml helpers",
+			tpl:   map[string][]byte{"host": []byte(`fromYaml(data.config).database.host`)},
+			data:  map[string][]byte{"config": []byte("database:\n  host: db.local\n")},
+			scope: esapi.TemplateScopeValues,
+			want:  map[string][]byte{"host": []byte("db.local")},
+		},
+		{
+			name:  "certificate helpers",
+			tpl:   map[string][]byte{"cert": []byte(`filterPEM("CERTIFICATE", data.bundle)`)},
+			data:  map[string][]byte{"bundle": append(append([]byte{}, key...), cert...)},
+			scope: esapi.TemplateScopeValues,
+			want:  map[string][]byte{"cert": cert},
+		},
+		{
+			name:  "keys and values",
+			tpl:   map[string][]byte{"tpl": []byte(`{"user": data.username, "user_b64": base64.encode(bytes(data.username))}`)},
+			data:  map[string][]byte{"username": []byte("admin")},
+			scope: esapi.TemplateScopeKeysAndValues,
+			want:  map[string][]byte{"user": []byte("admin"), "user_b64": []byte("YWRtaW4=")},
+		},
+		{
+			name:    "missing key",
+			tpl:     map[string][]byte{"user": []byte(`data.username`)},
+			data:    map[string][]byte{},
+			scope:   esapi.TemplateScopeValues,
+			wantErr: "no such key: username",
+		},
+		{
+			name:    "helper error",
+			tpl:     map[string][]byte{"key": []byte(`pkcs12key(data.archive)`)},
+			data:    map[string][]byte{"archive": []byte("invalid")},
+			scope:   esapi.TemplateScopeValues,
+			wantErr: "pkcs12key",
+		},
+		{
+			name:    "non string result",
+			tpl:     map[string][]byte{"count": []byte(`fromYaml(data.config).count`)},
+			data:    map[string][]byte{"config": []byte("count: 3\n")},
+			scope:   esapi.TemplateScopeValues,
+			wantErr: "expression must evaluate to string or bytes",
+		},
+		{
+			name:    "non string value in map",
+			tpl:     map[string][]byte{"tpl": []byte(`{"count": fromYaml(data.config).count}`)},
+			data:    map[string][]byte{"config": []byte("count: 3\n")},
+			scope:   esapi.TemplateScopeKeysAndValues,
+			wantErr: `value of key "count" must be string or bytes`,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			sec := &corev1.Secret{Data: make(map[string][]byte)}
+			target := row.target
+			if target == "" {
+				target = esapi.TemplateTargetData
+			}
+			err := Execute(row.tpl, row.data, row.scope, target, sec)
+			if row.wantErr != "" {
+				require.ErrorContains(t, err, row.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, row.want, sec.Data)
+		})
+	}
+}
+
+func TestExecuteTargets(t *testing.T) {
+	data := map[string][]byte{"username": []byte("admin")}
+
+	sec := &corev1.Secret{}
+	require.NoError(t, Execute(map[string][]byte{"user": []byte(`data.username`)}, data, esapi.TemplateScopeValues, esapi.TemplateTargetLabels, sec))
+	require.NoError(t, Execute(map[string][]byte{"tpl": []byte(`{"owner": data.username}`)}, data, esapi.TemplateScopeKeysAndValues, esapi.TemplateTargetAnnotations, sec))
+	assert.Equal(t, map[string]string{"user": "admin"}, sec.Labels)
+	assert.Equal(t, map[string]string{"owner": "admin"}, sec.Annotations)
+
+	obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "v1", "kind": "Config"}}
+	tpl := map[string][]byte{"tpl": []byte(`{"database": {"user": data.username, "replicas": 2}}`)}
+	require.NoError(t, Execute(tpl, data, esapi.TemplateScopeKeysAndValues, "spec.config", obj))
+	user, _, err := unstructured.NestedString(obj.Object, "spec", "config", "database", "user")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", user)
+	replicas, _, err := unstructured.NestedFieldNoCopy(obj.Object, "spec", "config", "database", "replicas")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, replicas)
+}
+
+func TestExecuteCostLimit(t *testing.T) {
+	limit := costLimit
+	costLimit = 100
+	defer func() {
+		costLimit = limit
+	}()
+	tpl := map[string][]byte{"big": []byte(`[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(a, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(b, data.value)).map(l, l.join("")).join("")`)}
+	err := Execute(tpl, map[string][]byte{"value": []byte("x")}, esapi.TemplateScopeValues, esapi.TemplateTargetData, &corev1.Secret{})
+	require.ErrorContains(t, err, "cost limit exceeded")
+}
+
+func TestExecuteHelperCost(t *testing.T) {
+	limit := costLimit
+	costLimit = 1000
+	defer func() {
+		costLimit = limit
+	}()
+	tpl := map[string][]byte{"yaml": []byte(`toYaml(data)`)}
+	data := map[string][]byte{"value": []byte("x")}
+	require.NoError(t, Execute(tpl, data, esapi.TemplateScopeValues, esapi.TemplateTargetData, &corev1.Secret{}))
+
+	// the helpers are charged by the size of their arguments and result
+	data = map[string][]byte{"value": bytes.Repeat([]byte("x"), 10000)}
+	err := Execute(tpl, data, esapi.TemplateScopeValues, esapi.TemplateTargetData, &corev1.Secret{})
+	require.ErrorContains(t, err, "cost limit exceeded")
+
+	// and each call is charged
+	tpl = map[string][]byte{"yaml": []byte(`[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(i, toYaml({"i": i})).join("")`)}
+	err = Execute(tpl, map[string][]byte{}, esapi.TemplateScopeValues, esapi.TemplateTargetData, &corev1.Secret{})
+	require.ErrorContains(t, err, "cost limit exceeded")
+}
+
+func TestProgramCache(t *testing.T) {
+	expr := `data.value + "-cached"`
+	prg, err := program(expr, esapi.TemplateScopeValues)
+	require.NoError(t, err)
+	cached, err := program(expr, esapi.TemplateScopeValues)
+	require.NoError(t, err)
+	assert.Same(t, prg, cached)
+
+	// the scope is checked at compile time, so it is part of the key
+	_, err = program(expr, esapi.TemplateScopeKeysAndValues)
+	require.Error(t, err)
+	_, err = program(`data.value +`, esapi.TemplateScopeValues)
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	tbl := []struct {
+		name    string
+		expr    string
+		scope   esapi.TemplateScope
+		wantErr string
+	}{
+		{
+			name:  "string",
+			expr:  `data.username + "@example.com"`,
+			scope: esapi.TemplateScopeValues,
+		},
+		{
+			name:  "dynamic result",
+			expr:  `fromYaml(data.config).host`,
+			scope: esapi.TemplateScopeValues,
+		},
+		{
+			name:  "map",
+			expr:  `{"user": data.username}`,
+			scope: esapi.TemplateScopeKeysAndValues,
+		},
+		{
+			name:    "syntax error",
+			expr:    `data.username +`,
+			scope:   esapi.TemplateScopeValues,
+			wantErr: "Syntax error",
+		},
+		{
+			name:    "undeclared variable",
+			expr:    `secret.username`,
+			scope:   esapi.TemplateScopeValues,
+			wantErr: "undeclared reference to 'secret'",
+		},
+		{
+			name:    "wrong argument type",
+			expr:    `pkcs12key(1)`,
+			scope:   esapi.TemplateScopeValues,
+			wantErr: "found no matching overload for 'pkcs12key'",
+		},
+		{
+			name:    "int result",
+			expr:    `size(data)`,
+			scope:   esapi.TemplateScopeValues,
+			wantErr: "expression must evaluate to string or bytes",
+		},
+		{
+			name:    "string result for keys and values",
+			expr:    `data.username`,
+			scope:   esapi.TemplateScopeKeysAndValues,
+			wantErr: "expression must evaluate to a map",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			err := Validate(row.expr, row.scope)
+			if row.wantErr != "" {
+				require.ErrorContains(t, err, row.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidatorRegistered(t *testing.T) {
+	validate, ok := esapi.GetTemplateValidator(esapi.TemplateEngineCEL)
+	require.True(t, ok)
+	require.Error(t, validate(`data.`, esapi.TemplateScopeValues))
+}
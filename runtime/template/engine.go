@@ -19,10 +19,13 @@ package template
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/runtime/template/cel"
 	v2 "github.com/external-secrets/external-secrets/runtime/template/v2"
 )
 
@@ -31,10 +34,21 @@ type ExecFunc func(tpl, data map[string][]byte, scope esapi.TemplateScope, targe
 
 // EngineForVersion returns the appropriate template engine for the given version.
 func EngineForVersion(version esapi.TemplateEngineVersion) (ExecFunc, error) {
-	// We want to leave this for new versions
-	switch version { //nolint:gocritic
+	switch version {
 	case esapi.TemplateEngineV2:
 		return v2.Execute, nil
+	case esapi.TemplateEngineCEL:
+		return cel.Execute, nil
 	}
 	return nil, fmt.Errorf("unsupported template engine version: %s", version)
 }
+
+// ApplyRendered sets already rendered values at the target of the object without executing them.
+func ApplyRendered(data map[string][]byte, target string, obj client.Object) error {
+	for _, k := range slices.Sorted(maps.Keys(data)) {
+		if err := v2.ApplyToTarget(k, data[k], target, obj); err != nil {
+			return fmt.Errorf("failed to apply to target: %w", err)
+		}
+	}
+	return nil
+}
@@ -25,6 +25,8 @@ import (
 	"errors"
 	"fmt"
 	"hash"
+
+	"github.com/external-secrets/external-secrets/runtime/envelope"
 )
 
 var (
@@ -70,6 +72,30 @@ func rsaDecrypt(scheme, hash, in, privateKey string) (string, error) {
 	}
 }
 
+// ageDecrypt decrypts an armored or binary age ciphertext with the given identities.
+func ageDecrypt(identities, in string) (string, error) {
+	out, err := envelope.AgeDecrypt(identities, []byte(in))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// pgpDecrypt decrypts an armored or binary OpenPGP message with an unencrypted private key.
+func pgpDecrypt(privateKey, in string) (string, error) {
+	return pgpDecryptPass(privateKey, "", in)
+}
+
+// pgpDecryptPass decrypts an armored or binary OpenPGP message with a private key
+// that is protected by the given passphrase.
+func pgpDecryptPass(privateKey, passphrase, in string) (string, error) {
+	out, err := envelope.PGPDecrypt(privateKey, passphrase, []byte(in))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
 func getHash(hash string) hash.Hash {
 	switch hash {
 	case "None":
@@ -17,15 +17,22 @@ limitations under the License.
 package template
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"testing"
 
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/external-secrets/external-secrets/runtime/envelope"
 )
 
 func generateRSAPrivateKeyPEM(t testing.TB) (string, *rsa.PrivateKey) {
@@ -84,3 +91,53 @@ func TestRsaDecrypt_RSAOAEP_DecryptionError(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, errRSADecrypt)
 }
+
+func TestAgeDecrypt(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	ciphertext, err := envelope.AgeEncrypt(identity.Recipient().String(), []byte("secret"))
+	require.NoError(t, err)
+
+	out, err := ageDecrypt(identity.String(), string(ciphertext))
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", out)
+
+	_, err = ageDecrypt("invalid", string(ciphertext))
+	assert.Error(t, err)
+}
+
+func TestPgpDecrypt(t *testing.T) {
+	entity, err := openpgp.NewEntity("eso", "", "eso@example.com", nil)
+	require.NoError(t, err)
+	publicKey := armorPGPKey(t, openpgp.PublicKeyType, entity.Serialize)
+	privateKey := armorPGPKey(t, openpgp.PrivateKeyType, func(w io.Writer) error {
+		return entity.SerializePrivate(w, nil)
+	})
+	require.NoError(t, entity.EncryptPrivateKeys([]byte("passphrase"), nil))
+	encryptedKey := armorPGPKey(t, openpgp.PrivateKeyType, func(w io.Writer) error {
+		return entity.SerializePrivateWithoutSigning(w, nil)
+	})
+	ciphertext, err := envelope.PGPEncrypt(publicKey, []byte("secret"))
+	require.NoError(t, err)
+
+	out, err := pgpDecrypt(privateKey, string(ciphertext))
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", out)
+
+	out, err = pgpDecryptPass(encryptedKey, "passphrase", string(ciphertext))
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", out)
+
+	_, err = pgpDecryptPass(encryptedKey, "wrong", string(ciphertext))
+	assert.Error(t, err)
+}
+
+func armorPGPKey(t *testing.T, blockType string, serialize func(io.Writer) error) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, blockType, nil)
+	require.NoError(t, err)
+	require.NoError(t, serialize(w))
+	require.NoError(t, w.Close())
+	return buf.String()
+}
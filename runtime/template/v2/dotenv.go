@@ -0,0 +1,202 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	errDotenvKey      = "invalid dotenv key %q"
+	errDotenvLine     = "line %d: expected KEY=VALUE"
+	errDotenvUnclosed = "line %d: unterminated quoted value"
+)
+
+var (
+	dotenvKey       = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.]*$`)
+	dotenvSafeValue = regexp.MustCompile(`^[a-zA-Z0-9_./:@,+%=-]*$`)
+)
+
+// toDotenv renders a map as dotenv file. Keys are sorted, values that contain
+// characters with a special meaning to shells are double-quoted and escaped.
+// It will always return a string, even on error (empty string).
+//
+// This is designed to be called from a template.
+func toDotenv(v map[string]any) string {
+	var sb strings.Builder
+	for _, k := range sortedKeys(v) {
+		if !dotenvKey.MatchString(k) {
+			// Swallow errors inside of a template.
+			return ""
+		}
+		val, ok := scalarString(v[k])
+		if !ok {
+			return ""
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(quoteDotenv(val))
+		sb.WriteByte('\n')
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func quoteDotenv(val string) string {
+	if dotenvSafeValue.MatchString(val) {
+		return val
+	}
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"`", "\\`",
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+	return `"` + r.Replace(val) + `"`
+}
+
+// fromDotenv converts a dotenv file into a map[string]any.
+// It supports comments, `export` prefixes, single-quoted values which are taken
+// literally and double-quoted values with escape sequences, which may span multiple lines.
+//
+// Because its intended use is within templates it tolerates errors.
+// It will insert the returned error message string into m["Error"]
+// in the returned map.
+func fromDotenv(str string) map[string]any {
+	m := map[string]any{}
+	if err := parseDotenv(str, m); err != nil {
+		m["Error"] = err.Error()
+	}
+	return m
+}
+
+func parseDotenv(str string, m map[string]any) error {
+	lines := strings.Split(strings.ReplaceAll(str, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf(errDotenvLine, lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if !dotenvKey.MatchString(key) {
+			return fmt.Errorf(errDotenvKey, key)
+		}
+		rest = strings.TrimLeft(rest, " \t")
+
+		switch {
+		case strings.HasPrefix(rest, "'"):
+			// single-quoted values are taken literally.
+			val := rest[1:]
+			end := strings.Index(val, "'")
+			for end < 0 && i+1 < len(lines) {
+				i++
+				val += "\n" + lines[i]
+				end = strings.Index(val, "'")
+			}
+			if end < 0 {
+				return fmt.Errorf(errDotenvUnclosed, lineNo)
+			}
+			m[key] = val[:end]
+		case strings.HasPrefix(rest, `"`):
+			val := rest[1:]
+			end := closingQuote(val)
+			for end < 0 && i+1 < len(lines) {
+				i++
+				val += "\n" + lines[i]
+				end = closingQuote(val)
+			}
+			if end < 0 {
+				return fmt.Errorf(errDotenvUnclosed, lineNo)
+			}
+			m[key] = unescapeDotenv(val[:end])
+		default:
+			// unquoted values end at an inline comment.
+			if idx := strings.Index(rest, " #"); idx >= 0 {
+				rest = rest[:idx]
+			}
+			m[key] = strings.TrimSpace(rest)
+		}
+	}
+	return nil
+}
+
+// closingQuote returns the index of the first unescaped double quote.
+func closingQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDotenv(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+// scalarString formats a scalar template value as string.
+func scalarString(v any) (string, bool) {
+	switch val := v.(type) {
+	case nil:
+		return "", true
+	case string:
+		return val, true
+	case []byte:
+		return string(val), true
+	case map[string]any, []any:
+		return "", false
+	default:
+		return fmt.Sprint(val), true
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,196 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDotenv(t *testing.T) {
+	values := map[string]any{
+		"DB_HOST":     "db.example.com:5432",
+		"DB_PASSWORD": `p@ss "word" $HOME` + "`id`\\",
+		"MULTILINE":   "line1\nline2",
+		"EMPTY":       "",
+		"PORT":        5432,
+	}
+	out := toDotenv(values)
+	want := `DB_HOST=db.example.com:5432
+DB_PASSWORD="p@ss \"word\" \$HOME\` + "`id\\`" + `\\"
+EMPTY=
+MULTILINE="line1\nline2"
+PORT=5432`
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("toDotenv() = diff:\n%s", diff)
+	}
+
+	values["PORT"] = "5432"
+	if diff := cmp.Diff(values, fromDotenv(out)); diff != "" {
+		t.Errorf("fromDotenv(toDotenv()) = diff:\n%s", diff)
+	}
+
+	parsed := fromDotenv(`
+# comment
+export TOKEN=abc # inline comment
+LITERAL='no \n $escapes'
+QUOTED="multi
+line"
+URL=https://example.com/#anchor
+`)
+	want2 := map[string]any{
+		"TOKEN":   "abc",
+		"LITERAL": `no \n $escapes`,
+		"QUOTED":  "multi\nline",
+		"URL":     "https://example.com/#anchor",
+	}
+	if diff := cmp.Diff(want2, parsed); diff != "" {
+		t.Errorf("fromDotenv() = diff:\n%s", diff)
+	}
+
+	if toDotenv(map[string]any{"invalid key": "x"}) != "" {
+		t.Error("toDotenv() expected empty output for invalid key")
+	}
+	if _, ok := fromDotenv(`KEY="unterminated`)["Error"]; !ok {
+		t.Error("fromDotenv() expected error for unterminated value")
+	}
+}
+
+func TestINI(t *testing.T) {
+	values := map[string]any{
+		"global": "value",
+		"client": map[string]any{
+			"user":     "app",
+			"password": ` secret;with#comment "chars" `,
+		},
+		"mysqld": map[string]any{
+			"port": 3306,
+		},
+	}
+	out := toINI(values)
+	want := `global = value
+
+[client]
+password = " secret;with#comment \"chars\" "
+user = app
+
+[mysqld]
+port = 3306`
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("toINI() = diff:\n%s", diff)
+	}
+
+	values["mysqld"] = map[string]any{"port": "3306"}
+	if diff := cmp.Diff(values, fromINI(out)); diff != "" {
+		t.Errorf("fromINI(toINI()) = diff:\n%s", diff)
+	}
+
+	parsed := fromINI(`
+; comment
+[mysqld]
+skip-name-resolve
+bind-address = 0.0.0.0 ; inline comment
+`)
+	want2 := map[string]any{
+		"mysqld": map[string]any{
+			"skip-name-resolve": "",
+			"bind-address":      "0.0.0.0",
+		},
+	}
+	if diff := cmp.Diff(want2, parsed); diff != "" {
+		t.Errorf("fromINI() = diff:\n%s", diff)
+	}
+	if _, ok := fromINI("[broken")["Error"]; !ok {
+		t.Error("fromINI() expected error for invalid section")
+	}
+}
+
+func TestProperties(t *testing.T) {
+	values := map[string]any{
+		"spring.datasource.url":      "jdbc:postgresql://db:5432/app",
+		"spring.datasource.password": " p=ss#wörd!",
+		"key with spaces":            "multi\nline",
+	}
+	out := toProperties(values)
+	want := `key\ with\ spaces=multi\nline
+spring.datasource.password=\ p\=ss\#w\u00F6rd\!
+spring.datasource.url=jdbc\:postgresql\://db\:5432/app`
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("toProperties() = diff:\n%s", diff)
+	}
+	if diff := cmp.Diff(values, fromProperties(out)); diff != "" {
+		t.Errorf("fromProperties(toProperties()) = diff:\n%s", diff)
+	}
+
+	parsed := fromProperties(`
+# comment
+! another comment
+colon: value
+space value with spaces
+continued = first, \
+            second
+emoji = 😀
+`)
+	want2 := map[string]any{
+		"colon":     "value",
+		"space":     "value with spaces",
+		"continued": "first, second",
+		"emoji":     "😀",
+	}
+	if diff := cmp.Diff(want2, parsed); diff != "" {
+		t.Errorf("fromProperties() = diff:\n%s", diff)
+	}
+	if _, ok := fromProperties(`key = \uZZZZ`)["Error"]; !ok {
+		t.Error("fromProperties() expected error for invalid unicode escape")
+	}
+}
+
+func TestTOML(t *testing.T) {
+	parsed := fromTOML(`
+title = "app"
+
+[database]
+user = "app"
+ports = [5432, 5433]
+`)
+	want := map[string]any{
+		"title": "app",
+		"database": map[string]any{
+			"user":  "app",
+			"ports": []any{int64(5432), int64(5433)},
+		},
+	}
+	if diff := cmp.Diff(want, parsed); diff != "" {
+		t.Errorf("fromTOML() = diff:\n%s", diff)
+	}
+
+	out := toTOML(map[string]any{
+		"title":    `quoted "title"`,
+		"database": map[string]any{"user": "app"},
+	})
+	wantOut := `title = "quoted \"title\""
+
+[database]
+  user = "app"`
+	if diff := cmp.Diff(wantOut, out); diff != "" {
+		t.Errorf("toTOML() = diff:\n%s", diff)
+	}
+	if _, ok := fromTOML("invalid = ")["Error"]; !ok {
+		t.Error("fromTOML() expected error for invalid document")
+	}
+}
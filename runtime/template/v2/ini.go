@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	errINISection = "line %d: invalid section header"
+	errINIQuote   = "line %d: unterminated quoted value"
+)
+
+// toINI renders a map as INI file. Scalar values are written as global keys
+// before the first section, nested maps are written as sections.
+// Keys are sorted, values with leading or trailing whitespace, comment characters
+// or quotes are double-quoted and escaped.
+// It will always return a string, even on error (empty string).
+//
+// This is designed to be called from a template.
+func toINI(v map[string]any) string {
+	var sb strings.Builder
+	var sections []string
+	for _, k := range sortedKeys(v) {
+		if _, ok := v[k].(map[string]any); ok {
+			sections = append(sections, k)
+			continue
+		}
+		if !writeINIKey(&sb, k, v[k]) {
+			// Swallow errors inside of a template.
+			return ""
+		}
+	}
+	for i, name := range sections {
+		if i > 0 || sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		if strings.ContainsAny(name, "[]\n") {
+			return ""
+		}
+		sb.WriteString("[" + name + "]\n")
+		section := v[name].(map[string]any)
+		for _, k := range sortedKeys(section) {
+			if !writeINIKey(&sb, k, section[k]) {
+				return ""
+			}
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func writeINIKey(sb *strings.Builder, key string, v any) bool {
+	val, ok := scalarString(v)
+	if !ok || key == "" || strings.ContainsAny(key, "=[];#\n") || strings.TrimSpace(key) != key {
+		return false
+	}
+	sb.WriteString(key)
+	sb.WriteString(" = ")
+	sb.WriteString(quoteINI(val))
+	sb.WriteByte('\n')
+	return true
+}
+
+func quoteINI(val string) string {
+	if strings.TrimSpace(val) == val && !strings.ContainsAny(val, ";#\"\\\n\r") {
+		return val
+	}
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+	)
+	return `"` + r.Replace(val) + `"`
+}
+
+// fromINI converts an INI file into a map[string]any.
+// Keys before the first section are global keys, sections are nested maps.
+// Lines starting with `;` or `#` are comments. Double-quoted values support escape sequences.
+//
+// Because its intended use is within templates it tolerates errors.
+// It will insert the returned error message string into m["Error"]
+// in the returned map.
+func fromINI(str string) map[string]any {
+	m := map[string]any{}
+	if err := parseINI(str, m); err != nil {
+		m["Error"] = err.Error()
+	}
+	return m
+}
+
+func parseINI(str string, m map[string]any) error {
+	current := m
+	for i, line := range strings.Split(strings.ReplaceAll(str, "\r\n", "\n"), "\n") {
+		lineNo := i + 1
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return fmt.Errorf(errINISection, lineNo)
+			}
+			name := strings.TrimSpace(line[1:end])
+			section, ok := m[name].(map[string]any)
+			if !ok {
+				section = map[string]any{}
+				m[name] = section
+			}
+			current = section
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			// MySQL style flags like `skip-name-resolve` have no value.
+			current[line] = ""
+			continue
+		}
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+		if strings.HasPrefix(val, `"`) {
+			end := closingQuote(val[1:])
+			if end < 0 {
+				return fmt.Errorf(errINIQuote, lineNo)
+			}
+			val = unescapeDotenv(val[1 : end+1])
+		} else if idx := strings.Index(val, " ;"); idx >= 0 {
+			val = strings.TrimSpace(val[:idx])
+		} else if idx := strings.Index(val, " #"); idx >= 0 {
+			val = strings.TrimSpace(val[:idx])
+		}
+		current[key] = val
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+)
+
+const (
+	errDecodeJKS        = "unable to decode jks: %w"
+	errJCEKS            = "jceks keystores are not supported, convert the keystore to jks or pkcs12 with keytool -importkeystore"
+	errJKSEntry         = "unable to read jks entry %q: %w"
+	errJKSNoPrivateKey  = "jks does not contain a private key entry"
+	errJKSNoCertificate = "jks does not contain a certificate"
+	errJKSNoAlias       = "jks does not contain an entry with alias %q"
+	errDecodeKeyPEM     = "unable to decode private key pem"
+
+	jksCertificateType  = "X.509"
+	jksTruststorePrefix = "ca-"
+)
+
+// errDecodeCertPEM is returned if PEM data contains no certificate.
+var errDecodeCertPEM = errors.New("unable to decode certificate pem")
+
+// jceksMagic starts a JCEKS keystore, which uses proprietary key protection the keystore library does not implement.
+var jceksMagic = []byte{0xce, 0xce, 0xce, 0xce}
+
+func pemToJKS(cert, key, alias, password string) (string, error) {
+	return pemToJKSPass(cert, key, alias, password, password)
+}
+
+// pemToJKSPass creates a JKS keystore with a single private key entry.
+// The first certificate is used as leaf, all other certificates complete the chain.
+func pemToJKSPass(cert, key, alias, storePassword, keyPassword string) (string, error) {
+	certs, err := parsePEMCertificates([]byte(cert))
+	if err != nil {
+		return "", err
+	}
+	keyPem, _ := pem.Decode([]byte(key))
+	if keyPem == nil {
+		return "", errors.New(errDecodeKeyPEM)
+	}
+	parsedKey, err := parsePrivateKey(keyPem.Bytes)
+	if err != nil {
+		return "", err
+	}
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(parsedKey)
+	if err != nil {
+		return "", err
+	}
+
+	chain := make([]keystore.Certificate, 0, len(certs))
+	for _, c := range certs {
+		chain = append(chain, keystore.Certificate{Type: jksCertificateType, Content: c.Raw})
+	}
+	ks := keystore.New(keystore.WithOrderedAliases())
+	if err := ks.SetPrivateKeyEntry(alias, keystore.PrivateKeyEntry{
+		CreationTime:     time.Now(),
+		PrivateKey:       pkcs8Key,
+		CertificateChain: chain,
+	}, []byte(keyPassword)); err != nil {
+		return "", err
+	}
+	return storeJKS(ks, storePassword)
+}
+
+// pemTruststoreToJKS creates a JKS truststore with a trusted certificate entry
+// for every certificate. The entries are named `ca-0`, `ca-1`, ...
+func pemTruststoreToJKS(cert, password string) (string, error) {
+	certs, err := parsePEMCertificates([]byte(cert))
+	if err != nil {
+		return "", err
+	}
+	ks := keystore.New(keystore.WithOrderedAliases())
+	for i, c := range certs {
+		if err := ks.SetTrustedCertificateEntry(fmt.Sprintf("%s%d", jksTruststorePrefix, i), keystore.TrustedCertificateEntry{
+			CreationTime: time.Now(),
+			Certificate:  keystore.Certificate{Type: jksCertificateType, Content: c.Raw},
+		}); err != nil {
+			return "", err
+		}
+	}
+	return storeJKS(ks, password)
+}
+
+func storeJKS(ks keystore.KeyStore, password string) (string, error) {
+	var buf bytes.Buffer
+	if err := ks.Store(&buf, []byte(password)); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func loadJKS(password, input string) (keystore.KeyStore, error) {
+	ks := keystore.New(keystore.WithOrderedAliases())
+	if bytes.HasPrefix([]byte(input), jceksMagic) {
+		return ks, errors.New(errJCEKS)
+	}
+	if err := ks.Load(bytes.NewReader([]byte(input)), []byte(password)); err != nil {
+		return ks, fmt.Errorf(errDecodeJKS, err)
+	}
+	return ks, nil
+}
+
+// firstPrivateKeyAlias returns the first alias of a private key entry in alphabetical order.
+func firstPrivateKeyAlias(ks keystore.KeyStore) (string, bool) {
+	for _, alias := range ks.Aliases() {
+		if ks.IsPrivateKeyEntry(alias) {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
+func jksKey(password, input string) (string, error) {
+	ks, err := loadJKS(password, input)
+	if err != nil {
+		return "", err
+	}
+	alias, ok := firstPrivateKeyAlias(ks)
+	if !ok {
+		return "", errors.New(errJKSNoPrivateKey)
+	}
+	return jksPrivateKey(ks, alias, password)
+}
+
+func jksKeyAlias(alias, storePassword, keyPassword, input string) (string, error) {
+	ks, err := loadJKS(storePassword, input)
+	if err != nil {
+		return "", err
+	}
+	return jksPrivateKey(ks, alias, keyPassword)
+}
+
+func jksPrivateKey(ks keystore.KeyStore, alias, password string) (string, error) {
+	entry, err := ks.GetPrivateKeyEntry(alias, []byte(password))
+	if err != nil {
+		return "", fmt.Errorf(errJKSEntry, alias, err)
+	}
+	return pemEncode(entry.PrivateKey, pemTypeKey)
+}
+
+// jksCert returns the certificate chain of the first private key entry.
+// If the keystore has no private key entries, all trusted certificates are returned.
+func jksCert(password, input string) (string, error) {
+	ks, err := loadJKS(password, input)
+	if err != nil {
+		return "", err
+	}
+	if alias, ok := firstPrivateKeyAlias(ks); ok {
+		return jksCertificates(ks, alias)
+	}
+	var pemData []byte
+	for _, alias := range ks.Aliases() {
+		entry, err := ks.GetTrustedCertificateEntry(alias)
+		if err != nil {
+			return "", fmt.Errorf(errJKSEntry, alias, err)
+		}
+		pemData = append(pemData, pem.EncodeToMemory(&pem.Block{
+			Type:  pemTypeCertificate,
+			Bytes: entry.Certificate.Content,
+		})...)
+	}
+	if len(pemData) == 0 {
+		return "", errors.New(errJKSNoCertificate)
+	}
+	return string(pemData), nil
+}
+
+func jksCertAlias(alias, password, input string) (string, error) {
+	ks, err := loadJKS(password, input)
+	if err != nil {
+		return "", err
+	}
+	return jksCertificates(ks, alias)
+}
+
+// jksCertificates returns the certificate chain of a private key entry
+// or the certificate of a trusted certificate entry.
+func jksCertificates(ks keystore.KeyStore, alias string) (string, error) {
+	var certs []keystore.Certificate
+	switch {
+	case ks.IsPrivateKeyEntry(alias):
+		chain, err := ks.GetPrivateKeyEntryCertificateChain(alias)
+		if err != nil {
+			return "", fmt.Errorf(errJKSEntry, alias, err)
+		}
+		certs = chain
+	case ks.IsTrustedCertificateEntry(alias):
+		entry, err := ks.GetTrustedCertificateEntry(alias)
+		if err != nil {
+			return "", fmt.Errorf(errJKSEntry, alias, err)
+		}
+		certs = []keystore.Certificate{entry.Certificate}
+	default:
+		return "", fmt.Errorf(errJKSNoAlias, alias)
+	}
+
+	var pemData []byte
+	for _, c := range certs {
+		pemData = append(pemData, pem.EncodeToMemory(&pem.Block{
+			Type:  pemTypeCertificate,
+			Bytes: c.Content,
+		})...)
+	}
+	// try to order certificate chain like pkcs12cert does.
+	ordered, err := fetchCertChains(pemData)
+	if err != nil {
+		return string(pemData), nil
+	}
+	return string(ordered), nil
+}
+
+// parsePEMCertificates parses all certificates of a PEM bundle, skipping other blocks.
+func parsePEMCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+		data = rest
+		if block.Type != pemTypeCertificate {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errDecodeCertPEM
+	}
+	return certs, nil
+}
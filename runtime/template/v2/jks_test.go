@@ -0,0 +1,194 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	leafCertPath         = "_testdata/foo.crt"
+	leafKeyPath          = "_testdata/foo.key"
+	intermediateCertPath = "_testdata/intermediate-ca.crt"
+	rootCertPath         = "_testdata/root-ca.crt"
+)
+
+func readTestdata(t *testing.T, paths ...string) string {
+	t.Helper()
+	var out []byte
+	for _, p := range paths {
+		c, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, c...)
+	}
+	return string(out)
+}
+
+func decodeJKS(t *testing.T, b64 string) string {
+	t.Helper()
+	raw, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+// pkcs8Key returns the PKCS#8 PEM encoding of the test key.
+func pkcs8Key(t *testing.T) string {
+	t.Helper()
+	block, _ := pem.Decode([]byte(readTestdata(t, leafKeyPath)))
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := pemEncode(der, pemTypeKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestPemToJKS(t *testing.T) {
+	// deliberately in the wrong order, the extracted chain is ordered.
+	chain := readTestdata(t, leafCertPath, rootCertPath, intermediateCertPath)
+	orderedChain := readTestdata(t, leafCertPath, intermediateCertPath, rootCertPath)
+	key := readTestdata(t, leafKeyPath)
+
+	t.Run("same store and key password", func(t *testing.T) {
+		out, err := pemToJKS(chain, key, "foo", "changeit")
+		if err != nil {
+			t.Fatalf("pemToJKS() got error '%v', expected none", err)
+		}
+		store := decodeJKS(t, out)
+
+		gotKey, err := jksKey("changeit", store)
+		if err != nil {
+			t.Fatalf("jksKey() got error '%v', expected none", err)
+		}
+		if diff := cmp.Diff(pkcs8Key(t), gotKey); diff != "" {
+			t.Errorf("jksKey() = diff:\n%s", diff)
+		}
+		gotCert, err := jksCert("changeit", store)
+		if err != nil {
+			t.Fatalf("jksCert() got error '%v', expected none", err)
+		}
+		if diff := cmp.Diff(orderedChain, gotCert); diff != "" {
+			t.Errorf("jksCert() = diff:\n%s", diff)
+		}
+	})
+
+	t.Run("different store and key password", func(t *testing.T) {
+		out, err := pemToJKSPass(chain, key, "foo", "storepass", "keypass")
+		if err != nil {
+			t.Fatalf("pemToJKSPass() got error '%v', expected none", err)
+		}
+		store := decodeJKS(t, out)
+
+		if _, err := jksKey("storepass", store); err == nil {
+			t.Error("jksKey() expected error when key password differs")
+		}
+		gotKey, err := jksKeyAlias("foo", "storepass", "keypass", store)
+		if err != nil {
+			t.Fatalf("jksKeyAlias() got error '%v', expected none", err)
+		}
+		if diff := cmp.Diff(pkcs8Key(t), gotKey); diff != "" {
+			t.Errorf("jksKeyAlias() = diff:\n%s", diff)
+		}
+		gotCert, err := jksCertAlias("foo", "storepass", store)
+		if err != nil {
+			t.Fatalf("jksCertAlias() got error '%v', expected none", err)
+		}
+		if diff := cmp.Diff(orderedChain, gotCert); diff != "" {
+			t.Errorf("jksCertAlias() = diff:\n%s", diff)
+		}
+	})
+
+	t.Run("wrong store password", func(t *testing.T) {
+		out, err := pemToJKS(chain, key, "foo", "changeit")
+		if err != nil {
+			t.Fatalf("pemToJKS() got error '%v', expected none", err)
+		}
+		if _, err := jksCert("wrongpass", decodeJKS(t, out)); err == nil {
+			t.Error("jksCert() expected error for wrong password")
+		}
+	})
+
+	t.Run("unknown alias", func(t *testing.T) {
+		out, err := pemToJKS(chain, key, "foo", "changeit")
+		if err != nil {
+			t.Fatalf("pemToJKS() got error '%v', expected none", err)
+		}
+		if _, err := jksCertAlias("bar", "changeit", decodeJKS(t, out)); err == nil {
+			t.Error("jksCertAlias() expected error for unknown alias")
+		}
+	})
+
+	t.Run("invalid certificate", func(t *testing.T) {
+		if _, err := pemToJKS("not a pem", key, "foo", "changeit"); err == nil {
+			t.Error("pemToJKS() expected error for invalid certificate")
+		}
+	})
+}
+
+func TestPemTruststoreToJKS(t *testing.T) {
+	certs := readTestdata(t, rootCertPath, intermediateCertPath)
+	out, err := pemTruststoreToJKS(certs, "changeit")
+	if err != nil {
+		t.Fatalf("pemTruststoreToJKS() got error '%v', expected none", err)
+	}
+	store := decodeJKS(t, out)
+
+	// a truststore has no private key, all trusted certificates are returned
+	if _, err := jksKey("changeit", store); err == nil {
+		t.Error("jksKey() expected error for truststore")
+	}
+	got, err := jksCert("changeit", store)
+	if err != nil {
+		t.Fatalf("jksCert() got error '%v', expected none", err)
+	}
+	if diff := cmp.Diff(certs, got); diff != "" {
+		t.Errorf("jksCert() = diff:\n%s", diff)
+	}
+	got, err = jksCertAlias("ca-1", "changeit", store)
+	if err != nil {
+		t.Fatalf("jksCertAlias() got error '%v', expected none", err)
+	}
+	if diff := cmp.Diff(readTestdata(t, intermediateCertPath), got); diff != "" {
+		t.Errorf("jksCertAlias() = diff:\n%s", diff)
+	}
+}
+
+func TestLoadJCEKS(t *testing.T) {
+	// a JCEKS keystore starts with its magic number, followed by the version
+	store := string([]byte{0xce, 0xce, 0xce, 0xce, 0x00, 0x00, 0x00, 0x02})
+	_, err := jksKey("changeit", store)
+	if err == nil || err.Error() != errJCEKS {
+		t.Errorf("jksKey() got error '%v', expected '%s'", err, errJCEKS)
+	}
+}
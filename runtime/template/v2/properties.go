@@ -0,0 +1,199 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const errPropertiesEscape = "line %d: invalid unicode escape"
+
+// toProperties renders a map as Java properties file. Keys are sorted,
+// keys and values are escaped as described in java.util.Properties#store.
+// Non-ASCII characters are written as unicode escapes, so the file can be read
+// as ISO 8859-1 as well as UTF-8.
+// It will always return a string, even on error (empty string).
+//
+// This is designed to be called from a template.
+func toProperties(v map[string]any) string {
+	var sb strings.Builder
+	for _, k := range sortedKeys(v) {
+		val, ok := scalarString(v[k])
+		if !ok {
+			// Swallow errors inside of a template.
+			return ""
+		}
+		sb.WriteString(escapeProperty(k, true))
+		sb.WriteByte('=')
+		sb.WriteString(escapeProperty(val, false))
+		sb.WriteByte('\n')
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func escapeProperty(s string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case ' ':
+			// spaces in keys and leading spaces in values must be escaped.
+			if isKey || i == 0 {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(r)
+		default:
+			if r < 0x20 || r > 0x7e {
+				for _, c := range utf16.Encode([]rune{r}) {
+					fmt.Fprintf(&sb, `\u%04X`, c)
+				}
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// fromProperties converts a Java properties file into a map[string]any.
+// It supports comments, line continuations, the `=`, `:` and whitespace separators
+// and escape sequences including unicode escapes.
+//
+// Because its intended use is within templates it tolerates errors.
+// It will insert the returned error message string into m["Error"]
+// in the returned map.
+func fromProperties(str string) map[string]any {
+	m := map[string]any{}
+	if err := parseProperties(str, m); err != nil {
+		m["Error"] = err.Error()
+	}
+	return m
+}
+
+func parseProperties(str string, m map[string]any) error {
+	lines := strings.Split(strings.ReplaceAll(str, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// join continuation lines, leading whitespace of the following lines is ignored.
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		key, val := splitProperty(line)
+		k, err := unescapeProperty(key)
+		if err != nil {
+			return fmt.Errorf(errPropertiesEscape, lineNo)
+		}
+		v, err := unescapeProperty(val)
+		if err != nil {
+			return fmt.Errorf(errPropertiesEscape, lineNo)
+		}
+		m[k] = v
+	}
+	return nil
+}
+
+// endsWithContinuation reports if the line ends with an odd number of backslashes.
+func endsWithContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty splits a logical line at the first unescaped separator.
+func splitProperty(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return line[:i], strings.TrimLeft(line[i+1:], " \t\f")
+		case ' ', '\t', '\f':
+			rest := strings.TrimLeft(line[i:], " \t\f")
+			if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+				rest = strings.TrimLeft(rest[1:], " \t\f")
+			}
+			return line[:i], rest
+		}
+	}
+	return line, ""
+}
+
+func unescapeProperty(s string) (string, error) {
+	var sb strings.Builder
+	var pending []uint16
+	flush := func() {
+		sb.WriteString(string(utf16.Decode(pending)))
+		pending = pending[:0]
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			flush()
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		if s[i] == 'u' {
+			if i+5 > len(s) {
+				return "", strconv.ErrSyntax
+			}
+			c, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", err
+			}
+			pending = append(pending, uint16(c))
+			i += 4
+			continue
+		}
+		flush()
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'f':
+			sb.WriteByte('\f')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	flush()
+	return sb.String(), nil
+}
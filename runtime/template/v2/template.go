@@ -46,16 +46,40 @@ var tplFuncs = tpl.FuncMap{
 	"pemTruststoreToPKCS12":     pemTruststoreToPKCS12,
 	"pemTruststoreToPKCS12Pass": pemTruststoreToPKCS12Pass,
 
+	"jksKey":             jksKey,
+	"jksKeyAlias":        jksKeyAlias,
+	"jksCert":            jksCert,
+	"jksCertAlias":       jksCertAlias,
+	"pemToJKS":           pemToJKS,
+	"pemToJKSPass":       pemToJKSPass,
+	"pemTruststoreToJKS": pemTruststoreToJKS,
+
 	"filterPEM":       filterPEM,
 	"filterCertChain": filterCertChain,
 
+	"x509Parse":       x509Parse,
+	"x509SortChain":   x509SortChain,
+	"x509VerifyChain": x509VerifyChain,
+	"pemPublicKey":    pemPublicKey,
+
 	"jwkPublicKeyPem":  jwkPublicKeyPem,
 	"jwkPrivateKeyPem": jwkPrivateKeyPem,
 
-	"toYaml":   toYAML,
-	"fromYaml": fromYAML,
-
-	"rsaDecrypt": rsaDecrypt,
+	"toYaml":         toYAML,
+	"fromYaml":       fromYAML,
+	"toToml":         toTOML,
+	"fromToml":       fromTOML,
+	"toIni":          toINI,
+	"fromIni":        fromINI,
+	"toDotenv":       toDotenv,
+	"fromDotenv":     fromDotenv,
+	"toProperties":   toProperties,
+	"fromProperties": fromProperties,
+
+	"rsaDecrypt":     rsaDecrypt,
+	"ageDecrypt":     ageDecrypt,
+	"pgpDecrypt":     pgpDecrypt,
+	"pgpDecryptPass": pgpDecryptPass,
 }
 
 var leftDelim, rightDelim string
@@ -97,7 +121,10 @@ func init() {
 	})
 }
 
-func applyToTarget(k string, val []byte, target string, obj client.Object) error {
+// ApplyToTarget sets the rendered value of key k at the target of the object.
+// Data, annotations and labels are set as map entries, other targets are treated
+// as dotted path into the object and the value is parsed as YAML.
+func ApplyToTarget(k string, val []byte, target string, obj client.Object) error {
 	target = strings.ToLower(target)
 	switch target {
 	case "annotations":
@@ -175,7 +202,7 @@ func valueScopeApply(tplMap, data map[string][]byte, target string, secret clien
 		if err != nil {
 			return fmt.Errorf(errExecute, k, err)
 		}
-		if err := applyToTarget(k, val, target, secret); err != nil {
+		if err := ApplyToTarget(k, val, target, secret); err != nil {
 			return fmt.Errorf("failed to apply to target: %w", err)
 		}
 	}
@@ -198,7 +225,7 @@ func mapScopeApply(tpl string, data map[string][]byte, target string, secret cli
 			return fmt.Errorf("could not unmarshal template to 'map[string][]byte': %w", err)
 		}
 		for k, val := range src {
-			if err := applyToTarget(k, []byte(val), target, secret); err != nil {
+			if err := ApplyToTarget(k, []byte(val), target, secret); err != nil {
 				return fmt.Errorf("failed to apply to target: %w", err)
 			}
 		}
@@ -215,7 +242,7 @@ func mapScopeApply(tpl string, data map[string][]byte, target string, secret cli
 		return fmt.Errorf("could not unmarshal template YAML: %w", err)
 	}
 
-	return applyParsedToPath(parsed, target, secret)
+	return ApplyParsedToPath(parsed, target, secret)
 }
 
 // Execute renders the secret data as template. If an error occurs processing is stopped immediately.
@@ -313,8 +340,8 @@ func tryParseYAML(value any) any {
 	return value
 }
 
-// applyParsedToPath applies a parsed YAML structure to a specific path in the object.
-func applyParsedToPath(parsed any, target string, obj client.Object) error {
+// ApplyParsedToPath applies a parsed YAML structure to a specific path in the object.
+func ApplyParsedToPath(parsed any, target string, obj client.Object) error {
 	unstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
 	if err != nil {
 		return fmt.Errorf(errConvertingToUnstructured, err)
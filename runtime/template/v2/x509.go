@@ -0,0 +1,199 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	errNoCertificate  = "no certificate found"
+	errNoPublicKey    = "no public key found"
+	errUnsupportedPEM = "unsupported pem block type %q"
+
+	pemTypePublicKey = "PUBLIC KEY"
+)
+
+// x509Parse returns the most relevant fields of the first certificate of the
+// given PEM bundle (or DER encoded certificate) as a map, so they can be used
+// in templates, e.g. to expose the expiry date as annotation.
+func x509Parse(input string) (map[string]any, error) {
+	certs, err := parseX509Certificates(input)
+	if err != nil {
+		return nil, err
+	}
+	cert := certs[0]
+
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	uris := make([]string, 0, len(cert.URIs))
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+	}
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	return map[string]any{
+		"subject":            cert.Subject.String(),
+		"subjectCommonName":  cert.Subject.CommonName,
+		"issuer":             cert.Issuer.String(),
+		"issuerCommonName":   cert.Issuer.CommonName,
+		"dnsNames":           append([]string{}, cert.DNSNames...),
+		"ipAddresses":        ips,
+		"emailAddresses":     append([]string{}, cert.EmailAddresses...),
+		"uris":               uris,
+		"serialNumber":       fmt.Sprintf("%X", cert.SerialNumber),
+		"notBefore":          cert.NotBefore.UTC().Format(time.RFC3339),
+		"notAfter":           cert.NotAfter.UTC().Format(time.RFC3339),
+		"isCA":               cert.IsCA,
+		"fingerprint":        hex.EncodeToString(fingerprint[:]),
+		"signatureAlgorithm": cert.SignatureAlgorithm.String(),
+		"publicKeyAlgorithm": cert.PublicKeyAlgorithm.String(),
+	}, nil
+}
+
+// x509SortChain orders the certificates of a PEM bundle from leaf to root.
+func x509SortChain(input string) (string, error) {
+	chain, err := fetchCertChains([]byte(trimJunk(input)))
+	if err != nil {
+		return "", err
+	}
+	if len(chain) == 0 {
+		return "", errors.New(errNoCertificate)
+	}
+	return string(chain), nil
+}
+
+// x509VerifyChain reports whether the leaf of the given PEM bundle chains up
+// to one of the supplied root certificates at the current time. The remaining
+// certificates of the bundle are used as intermediates.
+func x509VerifyChain(roots, input string) (bool, error) {
+	rootCerts, err := parseX509Certificates(roots)
+	if err != nil {
+		return false, fmt.Errorf("error parsing root certificates: %w", err)
+	}
+	certs, err := parseX509Certificates(input)
+	if err != nil {
+		return false, err
+	}
+	// fall back to the given order if the bundle can not be sorted,
+	// e.g. because it contains unrelated certificates.
+	if ordered, err := fetchX509CertChains([]byte(trimJunk(input))); err == nil {
+		certs = ordered
+	}
+
+	rootPool := x509.NewCertPool()
+	for _, cert := range rootCerts {
+		rootPool.AddCert(cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil, nil
+}
+
+// pemPublicKey extracts the public key of the first certificate, certificate
+// request or private key of the input and encodes it as PKIX PEM block.
+func pemPublicKey(input string) (string, error) {
+	data := []byte(trimJunk(input))
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return "", errors.New(errNoPublicKey)
+		}
+		data = rest
+
+		pub, err := publicKeyFromBlock(block)
+		if err != nil {
+			return "", err
+		}
+		if pub == nil {
+			continue
+		}
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			return "", err
+		}
+		return pemEncode(der, pemTypePublicKey)
+	}
+}
+
+func publicKeyFromBlock(block *pem.Block) (crypto.PublicKey, error) {
+	switch block.Type {
+	case pemTypeCertificate:
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	case "CERTIFICATE REQUEST", "NEW CERTIFICATE REQUEST":
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return csr.PublicKey, nil
+	case pemTypePublicKey:
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+		key, err := parsePrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf(errUnsupportedPEM, block.Type)
+		}
+		return signer.Public(), nil
+	}
+	// skip unrelated blocks like EC PARAMETERS
+	if strings.HasSuffix(block.Type, "PARAMETERS") {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(errUnsupportedPEM, block.Type)
+}
+
+// parseX509Certificates parses all certificates of a PEM bundle. If the input
+// contains no PEM data it is parsed as DER encoded certificate.
+func parseX509Certificates(input string) ([]*x509.Certificate, error) {
+	certs, err := parsePEMCertificates([]byte(trimJunk(input)))
+	if !errors.Is(err, errDecodeCertPEM) {
+		return certs, err
+	}
+	cert, err := x509.ParseCertificate([]byte(input))
+	if err != nil {
+		return nil, errors.New(errNoCertificate)
+	}
+	return []*x509.Certificate{cert}, nil
+}
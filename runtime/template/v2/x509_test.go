@@ -0,0 +1,203 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/pem"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const fooPublicKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEqnxdeInykx8JZsLi13rZLekoG2co
+sQ3F+2InVNy7hCQ7soMqdaJsGQ6LFtovogUFtOOTRWrunblqNWGZsowHbA==
+-----END PUBLIC KEY-----
+`
+
+func TestX509Parse(t *testing.T) {
+	leaf := readTestdata(t, leafCertPath)
+	want := map[string]any{
+		"subject":            "CN=foo",
+		"subjectCommonName":  "foo",
+		"issuer":             "CN=intermediate-ca",
+		"issuerCommonName":   "intermediate-ca",
+		"dnsNames":           []string{"foo"},
+		"ipAddresses":        []string{},
+		"emailAddresses":     []string{},
+		"uris":               []string{},
+		"serialNumber":       "F9C61AC05431B6619A1E5076761D0665",
+		"notBefore":          "2022-02-09T10:25:31Z",
+		"notAfter":           "2022-02-10T10:25:31Z",
+		"isCA":               false,
+		"fingerprint":        "24974851f46a964ad30cd04750372d5363b155c2b2d5e4dc0fde2b09fa6f819a",
+		"signatureAlgorithm": "ECDSA-SHA256",
+		"publicKeyAlgorithm": "ECDSA",
+	}
+
+	got, err := x509Parse("junk before the bundle\n" + leaf + readTestdata(t, rootCertPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("x509Parse() = diff:\n%s", diff)
+	}
+
+	block, _ := pem.Decode([]byte(leaf))
+	got, err = x509Parse(string(block.Bytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("x509Parse(der) = diff:\n%s", diff)
+	}
+
+	root, err := x509Parse(readTestdata(t, rootCertPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root["isCA"] != true {
+		t.Errorf("x509Parse() isCA = %v, want true", root["isCA"])
+	}
+
+	if _, err := x509Parse("not a certificate"); err == nil {
+		t.Error("x509Parse() expected error")
+	}
+}
+
+func TestX509SortChain(t *testing.T) {
+	want := readTestdata(t, leafCertPath, intermediateCertPath, rootCertPath)
+
+	got, err := x509SortChain(readTestdata(t, rootCertPath, leafCertPath, intermediateCertPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("x509SortChain() = diff:\n%s", diff)
+	}
+
+	if _, err := x509SortChain(readTestdata(t, "_testdata/disjunct-chain.pem")); err == nil {
+		t.Error("x509SortChain() expected error for disjunct chain")
+	}
+	if _, err := x509SortChain(""); err == nil {
+		t.Error("x509SortChain() expected error for empty input")
+	}
+}
+
+func TestX509VerifyChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		roots   string
+		input   string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:  "intermediate signed by root",
+			roots: readTestdata(t, rootCertPath),
+			input: readTestdata(t, intermediateCertPath),
+			want:  true,
+		},
+		{
+			name:  "unordered bundle",
+			roots: readTestdata(t, rootCertPath),
+			input: readTestdata(t, rootCertPath, intermediateCertPath),
+			want:  true,
+		},
+		{
+			name:  "expired leaf",
+			roots: readTestdata(t, rootCertPath),
+			input: readTestdata(t, leafCertPath, intermediateCertPath),
+			want:  false,
+		},
+		{
+			name:  "untrusted root",
+			roots: readTestdata(t, "_testdata/disjunct-root-ca.crt"),
+			input: readTestdata(t, intermediateCertPath),
+			want:  false,
+		},
+		{
+			name:    "invalid roots",
+			roots:   "junk",
+			input:   readTestdata(t, intermediateCertPath),
+			wantErr: true,
+		},
+		{
+			name:    "invalid input",
+			roots:   readTestdata(t, rootCertPath),
+			input:   "junk",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := x509VerifyChain(tt.roots, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("x509VerifyChain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("x509VerifyChain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPemPublicKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "certificate",
+			input: readTestdata(t, leafCertPath),
+			want:  fooPublicKey,
+		},
+		{
+			name:  "ec private key",
+			input: readTestdata(t, leafKeyPath),
+			want:  fooPublicKey,
+		},
+		{
+			name:  "pkcs8 private key",
+			input: pkcs8Key(t),
+			want:  fooPublicKey,
+		},
+		{
+			name:  "public key",
+			input: fooPublicKey,
+			want:  fooPublicKey,
+		},
+		{
+			name:    "no pem data",
+			input:   "junk",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pemPublicKey(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pemPublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("pemPublicKey() = diff:\n%s", diff)
+			}
+		})
+	}
+}
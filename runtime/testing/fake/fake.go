@@ -45,6 +45,7 @@ type Client struct {
 	SecretExistsFn  func(context.Context, esv1.PushSecretRemoteRef) (bool, error)
 	SetSecretFn     func() error
 	DeleteSecretFn  func() error
+	capabilities    esv1.SecretStoreCapabilities
 }
 
 // New returns a fake provider/client.
@@ -70,6 +71,7 @@ func New() *Client {
 			return nil
 		},
 		pushSecretData: map[string]SetSecretCallArgs{},
+		capabilities:   esv1.SecretStoreReadOnly,
 	}
 
 	v.NewFn = func(context.Context, esv1.GenericStore, client.Client, string) (esv1.SecretsClient, error) {
@@ -182,7 +184,17 @@ func (v *Client) WithNew(f func(context.Context, esv1.GenericStore, client.Clien
 
 // Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
 func (v *Client) Capabilities() esv1.SecretStoreCapabilities {
-	return esv1.SecretStoreReadOnly
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.capabilities
+}
+
+// WithCapabilities sets the capabilities of the fake provider.
+func (v *Client) WithCapabilities(capabilities esv1.SecretStoreCapabilities) *Client {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.capabilities = capabilities
+	return v
 }
 
 // NewClient returns a new fake provider.
@@ -202,4 +214,5 @@ func (v *Client) Reset() {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	v.pushSecretData = map[string]SetSecretCallArgs{}
+	v.capabilities = esv1.SecretStoreReadOnly
 }